@@ -15,21 +15,38 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/product": {
+        "/api/categories": {
             "post": {
-                "description": "Create product by id",
-                "produces": [
+                "description": "Create a category under parent_id, or a root without it",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
                 "tags": [
-                    "Products"
+                    "Categories"
+                ],
+                "summary": "Create category",
+                "parameters": [
+                    {
+                        "description": "Category",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.CreateCategoryDTO"
+                        }
+                    }
                 ],
-                "summary": "Create product",
                 "responses": {
                     "201": {
-                        "description": "Product",
+                        "description": "Category",
                         "schema": {
-                            "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                         }
                     },
                     "400": {
@@ -38,6 +55,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug already used by a sibling",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -47,23 +76,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/products": {
-            "post": {
-                "description": "Bulk create products",
+        "/api/categories/": {
+            "get": {
+                "description": "Get the whole category tree ordered by depth and position, or the children of parent_id",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
+                ],
+                "summary": "Get categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return children of this category",
+                        "name": "parent_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Bulk create products",
                 "responses": {
-                    "201": {
-                        "description": "Products",
+                    "200": {
+                        "description": "Categories",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                                "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                             }
                         }
                     },
@@ -73,6 +113,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -80,23 +126,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Bulk update products",
-                "produces": [
+            }
+        },
+        "/api/categories/reorder": {
+            "post": {
+                "description": "Set the order of the children of parent_id, or of the roots without it",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
                 "tags": [
-                    "Products"
+                    "Categories"
+                ],
+                "summary": "Reorder categories",
+                "parameters": [
+                    {
+                        "description": "Children in their new order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.ReorderCategoriesDTO"
+                        }
+                    }
                 ],
-                "summary": "Bulk update products",
                 "responses": {
                     "200": {
-                        "description": "Products",
+                        "description": "Reordered children",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                                "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                             }
                         }
                     },
@@ -106,6 +171,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -115,40 +186,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/products/": {
+        "/api/categories/{id}": {
             "get": {
-                "description": "Get products list by limit and offset",
+                "description": "Get category by id",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
                 ],
-                "summary": "Get products",
+                "summary": "Get category",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "List limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "List offset",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product",
+                        "description": "Category",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
-                            }
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                         }
                     },
                     "400": {
@@ -157,6 +221,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -164,23 +240,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/products/{id}": {
-            "get": {
-                "description": "Get product by id",
+            },
+            "delete": {
+                "description": "Delete a category without children, its products stay but lose the assignment",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
                 ],
                 "tags": [
-                    "Products"
+                    "Categories"
+                ],
+                "summary": "Delete category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get product",
                 "responses": {
                     "200": {
-                        "description": "Product",
+                        "description": "Deleted category",
                         "schema": {
-                            "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                         }
                     },
                     "400": {
@@ -195,6 +281,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Category has children",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -203,20 +301,44 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete product by id",
-                "produces": [
+            "patch": {
+                "description": "Rename a category or change its slug",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
                 "tags": [
-                    "Products"
+                    "Categories"
+                ],
+                "summary": "PartialUpdate category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.PartialUpdateCategoryDTO"
+                        }
+                    }
                 ],
-                "summary": "Delete product",
                 "responses": {
-                    "204": {
-                        "description": "No content",
+                    "200": {
+                        "description": "Category",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                         }
                     },
                     "400": {
@@ -231,6 +353,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug already used by a sibling",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -238,21 +372,106 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "PartialUpdate product by id",
+            }
+        },
+        "/api/categories/{id}/breadcrumbs": {
+            "get": {
+                "description": "Get the category and its ancestors, root first",
                 "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category breadcrumbs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Categories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/categories/{id}/move": {
+            "post": {
+                "description": "Move a category with its subtree under parent_id, or to the roots without it",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
                 "tags": [
-                    "Products"
+                    "Categories"
+                ],
+                "summary": "Move category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New parent and position",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.MoveCategoryDTO"
+                        }
+                    }
                 ],
-                "summary": "PartialUpdate product",
                 "responses": {
                     "200": {
-                        "description": "Product",
+                        "description": "Category",
                         "schema": {
-                            "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
                         }
                     },
                     "400": {
@@ -262,7 +481,19 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not found",
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Move would create a cycle or slug already used",
                         "schema": {
                             "type": "string"
                         }
@@ -275,25 +506,4479 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "go_template_project_internal_domain_products.Product": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "deleted_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "title": {
+        },
+        "/api/categories/{id}/products": {
+            "get": {
+                "description": "Get published products assigned to the category or any of its descendants, under /api/admin all of them",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "List limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "List offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{id}": {
+            "get": {
+                "description": "Get background job status, progress and result by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{id}/artifact": {
+            "get": {
+                "description": "Download the file produced by a finished job, e.g. an export",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Download job artifact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Artifact",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{id}/cancel": {
+            "post": {
+                "description": "Cancel a queued job right away or ask a running job to stop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Cancel job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Job",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Job already finished",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/media/{id}/{name}": {
+            "get": {
+                "description": "Download the original of a media or one of its thumbnails by size. Files never change under the same URL, so they are served with an ETag and long lived caching headers.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Get media file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "original or a thumbnail size",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product": {
+            "post": {
+                "description": "Create product by id",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create product",
+                "responses": {
+                    "201": {
+                        "description": "Product",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products": {
+            "post": {
+                "description": "Bulk create products",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk create products",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Products",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Job accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Bulk update products",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk update products",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Job accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/": {
+            "get": {
+                "description": "Get published products list by limit and offset, under /api/admin/products/ products in every status",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "List limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "List offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search in names and titles, translated ones included",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID, includes its descendants",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency, adds the current price to each product",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lowest current price in minor units, needs currency",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Highest current price in minor units, needs currency",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "created_at",
+                            "-created_at",
+                            "price",
+                            "-price"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, price sorts need currency",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute filter, for example attr.color=red",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry all of",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry one of",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses (draft, in_review, published, archived), only published products are listed outside /api/admin",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return (id,name,title,attributes,created_at,updated_at,deleted_at,status,publish_at,unpublish_at)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to embed (categories,prices,tags,media,variants)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to translate names and titles to, overrides Accept-Language",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to translate names and titles to",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached page",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date of a cached page",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/batch-get": {
+            "post": {
+                "description": "Get up to 100 products by ids in one request. Found products keep the request order, ids of unknown or unpublished products are listed in missing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Batch get products",
+                "parameters": [
+                    {
+                        "description": "Product ids",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.GetProductsByIDsDTO"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to translate names and titles to, overrides Accept-Language",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to translate names and titles to",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.BatchGetProductsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/export": {
+            "get": {
+                "description": "Stream products matching the list filters as CSV, NDJSON or XLSX. An XLSX export continues on another sheet past the Excel limit of 1048576 rows per sheet",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Export products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns (id,name,title,created_at,updated_at,deleted_at)",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search in names and titles, translated ones included",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID, includes its descendants",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the price filters",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lowest current price in minor units, needs currency",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Highest current price in minor units, needs currency",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute filter, for example attr.color=red",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry all of",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry one of",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses (draft, in_review, published, archived), only published products are exported outside /api/admin",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to search translated names and titles in, overrides Accept-Language",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to search translated names and titles in",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job, the file is then served as the job artifact",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products export",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Job accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/facets": {
+            "get": {
+                "description": "Count the products matched by the list filters per category, tag, price bucket and status in one query. Each facet ignores its own filter (category, tags_any, price_min and price_max, status), so values that are not selected yet keep their counts. Without facets all are computed, price only with a currency and price buckets. The status facet counts publication statuses, outside /api/admin it only ever has published.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product facets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated facets, category, tag, price or status",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated ascending lower bounds of the price buckets after the first one, in minor units of currency",
+                        "name": "price_buckets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of category and tag values",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search in names and titles, translated ones included",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID, includes its descendants",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the price facet and filters",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lowest current price in minor units, needs currency",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Highest current price in minor units, needs currency",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute filter, for example attr.color=red",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry all of",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry one of",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses (draft, in_review, published, archived), only published products are counted outside /api/admin",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to search translated names and titles in, overrides Accept-Language",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to search translated names and titles in",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Facets",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.ProductsFacets"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/tag": {
+            "post": {
+                "description": "Add tags to many products at once. Tags are normalized to lower case with whitespace collapsed and created on first use. Products that do not exist or are deleted are reported as missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk tag products",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Products and tags",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_tags.TagProductsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_tags.TagProductsResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Job accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/tags/counts": {
+            "get": {
+                "description": "Count the tags of the products matched by the list filters, the most used first, for facet displays",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get tag counts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of tags",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search in names and titles, translated ones included",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID, includes its descendants",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the price filters",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lowest current price in minor units, needs currency",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Highest current price in minor units, needs currency",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute filter, for example attr.color=red",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry all of",
+                        "name": "tags_all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags a product has to carry one of",
+                        "name": "tags_any",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses (draft, in_review, published, archived), only published products are counted outside /api/admin",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to search translated names and titles in, overrides Accept-Language",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to search translated names and titles in",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_tags.TagCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/translations/missing": {
+            "get": {
+                "description": "List products without a translation in at least one of the locales, in creation order. Soft-deleted products are left out.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get missing translations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated locales, defaults to the configured ones",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "List limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "List offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products missing translations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.MissingTranslation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/untag": {
+            "post": {
+                "description": "Remove tags from many products at once. Tags are matched in their normalized form, unknown ones are ignored. Products that do not exist or are deleted are reported as missing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk untag products",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Run as a background job",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Products and tags",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_tags.TagProductsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_tags.TagProductsResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Job accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_jobs.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}": {
+            "get": {
+                "description": "Get a published product by id, other products are not found. Under /api/admin/products/{id} products in every status are found",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return (id,name,title,attributes,created_at,updated_at,deleted_at,status,publish_at,unpublish_at)",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to embed (categories,prices,tags,media,variants)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to translate name and title to, overrides Accept-Language",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locales to translate name and title to",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached representation",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date of a cached representation",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete product by id, it is removed for good by purge-deleted",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete product",
+                "responses": {
+                    "204": {
+                        "description": "No content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "PartialUpdate product by id",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "PartialUpdate product",
+                "responses": {
+                    "200": {
+                        "description": "Product",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/categories": {
+            "get": {
+                "description": "Get the categories a product is assigned to",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get product categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Categories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the categories a product is assigned to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Set product categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category ids",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_categories.SetProductCategoriesDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Categories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/media": {
+            "get": {
+                "description": "Get the gallery of a product in display order. Thumbnails are listed once generated, until then status is processing.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Get product media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Media",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_media.Media"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an image to the end of the gallery of a product as the file field of a multipart form. The type is sniffed from the data, jpeg, png, gif and webp are accepted. Thumbnails are generated in the background.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload product media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Uploaded media",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_media.Media"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/media/order": {
+            "put": {
+                "description": "Set the display order of the gallery, ids has to list every media of the product once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Order product media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Media IDs in display order",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_media.SetMediaOrderDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Media",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_media.Media"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/media/{media_id}": {
+            "delete": {
+                "description": "Remove a media from the gallery, its files are deleted in the background",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Delete product media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted media",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_media.Media"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/options": {
+            "get": {
+                "description": "Get the option types of a product in display order",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Variants"
+                ],
+                "summary": "Get product option types",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Option types",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_variants.OptionType"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an option type such as size or colour with its allowed values. Option types can only be added while the product has no variants.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Variants"
+                ],
+                "summary": "Create product option type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Option type",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_variants.CreateOptionTypeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created option type",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_variants.OptionType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Name taken or product has variants",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/prices": {
+            "get": {
+                "description": "Get past, current and scheduled prices of a product, amounts are in minor units",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Prices"
+                ],
+                "summary": "Get product prices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prices",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_prices.Price"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedule a price for [valid_from, valid_to), overlapping prices in the same currency are trimmed, split or removed. valid_from defaults to now, a missing valid_to keeps the price until it is replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Prices"
+                ],
+                "summary": "Set product price",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_prices.SetProductPriceDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created price",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_prices.Price"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicting concurrent change",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/prices/history": {
+            "get": {
+                "description": "Get every change made to the prices of a product, newest first",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Prices"
+                ],
+                "summary": "Get product price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "List limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "List offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price changes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_prices.PriceChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/prices/{price_id}": {
+            "delete": {
+                "description": "Delete a price, the gap it leaves is not filled. The deletion is kept in the price history.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Prices"
+                ],
+                "summary": "Delete product price",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price ID",
+                        "name": "price_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted price",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_prices.Price"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/reservations": {
+            "post": {
+                "description": "Hold units of a product in a warehouse until the reservation is committed, released or expires. expires_in is in seconds, omitted uses the configured default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Reserve product stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reservation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.ReserveStockDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created reservation",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/schedule": {
+            "put": {
+                "description": "Set when a product is published and unpublished, a null time clears it. Once publish_at has come a product in review is published, a draft waits for its review. Once unpublish_at has come a published product is archived. Both moves are recorded as made by the scheduler.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Schedule product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.SetProductScheduleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.ProductSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/stock": {
+            "get": {
+                "description": "Get stock levels of a product per warehouse",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get product stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock levels",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_inventory.StockLevel"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/stock/adjustments": {
+            "get": {
+                "description": "Get the stock ledger of a product, newest first",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get product stock adjustments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "warehouse_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "List limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "List offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Adjustments",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_inventory.Adjustment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add or remove units on hand with a reason code and return the resulting stock level. Units can not drop below zero or below the reserved units.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Adjust product stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjustment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.AdjustStockDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock level",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.StockLevel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/transitions": {
+            "get": {
+                "description": "Get the status changes of a product, the latest first",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product transitions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of transitions",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of transitions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transitions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.ProductTransition"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Move a product along the publication workflow and record who moved it and why. Drafts go to review, reviewed products are published or sent back, published products are archived or reworked as drafts, archived products are reworked as drafts. Other moves are rejected. The actor is recorded as sent, it is not checked against any identity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Transition product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transition",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.TransitionProductDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Recorded transition",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_products.ProductTransition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Illegal transition",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/translations": {
+            "get": {
+                "description": "Get the stored translations of a product ordered by locale",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product translations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Translations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_products.ProductTranslation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/variants": {
+            "get": {
+                "description": "Get the variants of a product in creation order, soft-deleted variants are left out",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Variants"
+                ],
+                "summary": "Get product variants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "List limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "List offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Variants",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_variants.Variant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a variant with a unique SKU. options must pick one allowed value of every option type of the product, attributes are free form.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Variants"
+                ],
+                "summary": "Create product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_variants.CreateVariantDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created variant",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_variants.Variant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "SKU or options already taken",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/variants/{variant_id}": {
+            "get": {
+                "description": "Get a variant of a product by id",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Variants"
+                ],
+                "summary": "Get product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Variant",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_variants.Variant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-delete a variant, its SKU and options can be used again",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Variants"
+                ],
+                "summary": "Delete product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted variant",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_variants.Variant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reservations/{id}": {
+            "get": {
+                "description": "Get a stock reservation by id",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reservations/{id}/commit": {
+            "post": {
+                "description": "Take the reserved units out of stock and record the sale in the ledger",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Commit reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation no longer active",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reservations/{id}/release": {
+            "post": {
+                "description": "Return the reserved units to the available stock",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Release reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation no longer active",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "description": "Find tags starting with a prefix, the ones on most published products first. The prefix is matched in the normalized form tags are stored in.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Autocomplete tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Beginning of the tag",
+                        "name": "prefix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of tags",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags with product counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_tags.TagCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/warehouses": {
+            "get": {
+                "description": "Get all warehouses ordered by code",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get warehouses",
+                "responses": {
+                    "200": {
+                        "description": "Warehouses",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go_template_project_internal_domain_inventory.Warehouse"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a warehouse, codes are unique",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "application/cbor"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Create warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.CreateWarehouseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created warehouse",
+                        "schema": {
+                            "$ref": "#/definitions/go_template_project_internal_domain_inventory.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Code already taken",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Execute GraphQL queries and mutations over products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphQL endpoint",
+                "responses": {
+                    "200": {
+                        "description": "GraphQL result",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "go_template_project_internal_domain_categories.Category": {
+            "type": "object",
+            "properties": {
+                "attribute_schema": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_categories.CreateCategoryDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "slug"
+            ],
+            "properties": {
+                "attribute_schema": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position defaults to after the last sibling.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 250
+                }
+            }
+        },
+        "go_template_project_internal_domain_categories.MoveCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "go_template_project_internal_domain_categories.PartialUpdateCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "attribute_schema": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 250
+                }
+            }
+        },
+        "go_template_project_internal_domain_categories.ReorderCategoriesDTO": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_categories.SetProductCategoriesDTO": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.AdjustStockDTO": {
+            "type": "object",
+            "required": [
+                "delta",
+                "reason",
+                "warehouse_id"
+            ],
+            "properties": {
+                "delta": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "received",
+                        "returned",
+                        "damaged",
+                        "lost",
+                        "recount",
+                        "correction"
+                    ]
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.Adjustment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reservation_id": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.CreateWarehouseDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.Reservation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.ReserveStockDTO": {
+            "type": "object",
+            "required": [
+                "warehouse_id"
+            ],
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "maximum": 604800,
+                    "minimum": 0
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.StockLevel": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "on_hand": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_inventory.Warehouse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_jobs.Job": {
+            "type": "object",
+            "properties": {
+                "artifact_content_type": {
+                    "type": "string"
+                },
+                "artifact_name": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "cancel_requested": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "has_artifact": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "$ref": "#/definitions/go_template_project_internal_domain_jobs.Progress"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_jobs.Progress": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go_template_project_internal_domain_media.Media": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "thumbnails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_media.Thumbnail"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go_template_project_internal_domain_media.SetMediaOrderDTO": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_media.Thumbnail": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go_template_project_internal_domain_prices.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_prices.Price": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_prices.PriceChange": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "price_id": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_prices.SetProductPriceDTO": {
+            "type": "object",
+            "required": [
+                "currency"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.BatchGetProductsResult": {
+            "type": "object",
+            "properties": {
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_products.Product"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.GetProductsByIDsDTO": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.MissingTranslation": {
+            "type": "object",
+            "properties": {
+                "missing_locales": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.PriceBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.Product": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Attributes are custom fields checked against the attribute schemas of\nthe product's categories.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "categories": {
+                    "description": "Categories, Prices, Tags, Media and Variants are only loaded when\nexpanded, and left out when the product has none.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_categories.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "Locale is the locale Name and Title were translated to, empty when\nthey are the untranslated ones.",
+                    "type": "string"
+                },
+                "media": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_media.Media"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is the current price in the currency a list was asked for.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/go_template_project_internal_domain_prices.Money"
+                        }
+                    ]
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_prices.Price"
+                    }
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the publication status, PublishAt and UnpublishAt are when\nthe scheduler publishes and unpublishes the product.",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unpublish_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_variants.Variant"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.ProductSchedule": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unpublish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.ProductTransition": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.ProductTranslation": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.ProductsFacets": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_products.FacetCount"
+                    }
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_products.PriceBucket"
+                    }
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_products.FacetCount"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_products.FacetCount"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.SetProductScheduleDTO": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "unpublish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_products.TransitionProductDTO": {
+            "type": "object",
+            "required": [
+                "actor",
+                "status"
+            ],
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "in_review",
+                        "published",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "go_template_project_internal_domain_tags.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_tags.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go_template_project_internal_domain_tags.TagProductsDTO": {
+            "type": "object",
+            "required": [
+                "product_ids",
+                "tags"
+            ],
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_tags.TagProductsResult": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go_template_project_internal_domain_tags.Tag"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_variants.CreateOptionTypeDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_variants.CreateVariantDTO": {
+            "type": "object",
+            "required": [
+                "sku"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "go_template_project_internal_domain_variants.OptionType": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "go_template_project_internal_domain_variants.Variant": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "sku": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -315,7 +5000,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "GO TEMPLATE PROJECT",
-	Description:      "GO TEMPLATE PROJECT",
+	Description:      "GO TEMPLATE PROJECT\nWith ADMIN_TOKEN set, every /api endpoint is also served under /api/admin to clients sending it as a bearer token, where product reads see unpublished products as well. Without it /api/admin is not served.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
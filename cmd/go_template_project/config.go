@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"go_template_project/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the merged configuration",
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "validate",
+			Short: "Load and validate the configuration",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				if _, err := c.loadEnvVars(); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "configuration is valid")
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "print",
+			Short: "Print the merged configuration with secrets redacted",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				envVars, err := c.loader.Load()
+				if err != nil {
+					return err
+				}
+				return config.Print(cmd.OutOrStdout(), envVars)
+			},
+		},
+	)
+	return cmd
+}
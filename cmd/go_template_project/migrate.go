@@ -2,49 +2,220 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"go_template_project/internal/config"
+	"go_template_project/internal/migrator"
+	"go_template_project/internal/migrator/backfill"
+	dbRepo "go_template_project/internal/repository"
 	"log"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
 )
 
-const (
-	dialect string = "pgx"
-	dir     string = "./migrations"
-	command string = "up"
-)
+// prepareDatabase applies pending migrations when enabled and makes sure the
+// database schema matches the migrations this binary was built with.
+func prepareDatabase(ctx context.Context, conf config.Config) error {
+	db, err := dbRepo.NewSQLDB(ctx, conf.Repository)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
 
-func migrateUp(conf config.Config) error {
-	log.Println("Start migrations...")
+	m, err := migrator.New(db, conf.Migrations.Dir)
+	if err != nil {
+		return err
+	}
 
-	ctx := context.Background()
+	if conf.Migrations.AutoMigrate {
+		log.Println("Start migrations...")
+		results, err := m.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("apply migrations: %w", err)
+		}
+		for _, result := range results {
+			log.Printf("Applied %s in %s", result.Source.Path, result.Duration)
+		}
+		log.Println("Migrations completed.")
+	}
 
-	dbString := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable",
-		conf.Repository.Host,
-		conf.Repository.Username,
-		conf.Repository.Password,
-		conf.Repository.Name,
-		conf.Repository.Port,
-	)
+	return m.Check(ctx)
+}
 
-	db, err := goose.OpenDBWithDriver(dialect, dbString)
-	if err != nil {
-		log.Fatalln(err.Error())
+var gooseCommands = []string{
+	"up", "up-by-one", "up-to", "down", "down-to", "redo", "reset",
+	"status", "version", "create", "fix",
+}
+
+func (c *cli) newMigrateCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "migrate COMMAND [ARGS]",
+		Short: "Run database migrations",
+		Long: `Run a goose command against the database, schema changes hold an advisory lock.
+
+Commands:
+    up                   Migrate the DB to the most recent version available
+    up-by-one            Migrate the DB up by 1
+    up-to VERSION        Migrate the DB to a specific VERSION
+    down                 Roll back the version by 1
+    down-to VERSION      Roll back to a specific VERSION
+    redo                 Re-run the latest migration
+    reset                Roll back all migrations
+    status               Dump the migration status for the current DB
+    version              Print the current version of the database
+    create NAME [sql|go] Creates new migration file with the current timestamp (needs --dir)
+    fix                  Apply sequential ordering to migrations (needs --dir)`,
+		Example:   "  go_template_project migrate status",
+		Args:      cobra.MinimumNArgs(1),
+		ValidArgs: gooseCommands,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withMigrator(cmd.Context(), dir, func(m *migrator.Migrator, _ *sql.DB) error {
+				return m.Run(cmd.Context(), args[0], args[1:]...)
+			})
+		},
 	}
+	cmd.PersistentFlags().StringVar(&dir, "dir", "", "directory with migration files (overrides migrations_dir and the embedded ones)")
 
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Fatalln(err.Error())
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "plan",
+			Short: "Print the SQL of pending migrations without applying it",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return c.withMigrator(cmd.Context(), dir, func(m *migrator.Migrator, _ *sql.DB) error {
+					return m.Plan(cmd.Context(), cmd.OutOrStdout())
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "verify",
+			Short: "Check applied migrations against the checksums recorded when they ran",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return c.withMigrator(cmd.Context(), dir, func(m *migrator.Migrator, _ *sql.DB) error {
+					problems, err := m.Verify(cmd.Context())
+					for _, problem := range problems {
+						fmt.Fprintf(cmd.OutOrStdout(), "%d\t%s\t%s\n", problem.Version, problem.Path, problem.Reason)
+					}
+					if err == nil {
+						fmt.Fprintln(cmd.OutOrStdout(), "all applied migrations match")
+					}
+					return err
+				})
+			},
+		},
+		c.newBackfillCmd(&dir),
+	)
+	return cmd
+}
+
+func (c *cli) newBackfillCmd(dir *string) *cobra.Command {
+	var opts backfill.RunOptions
+
+	withRunner := func(ctx context.Context, fn func(*backfill.Runner) error) error {
+		return c.withMigrator(ctx, *dir, func(_ *migrator.Migrator, db *sql.DB) error {
+			return fn(backfill.NewRunner(db))
+		})
+	}
+	backfillNames := func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, 0)
+		for _, b := range backfill.All() {
+			names = append(names, b.Name+"\t"+b.Description)
 		}
-	}()
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	run := &cobra.Command{
+		Use:               "run NAME",
+		Short:             "Run or resume a backfill in batches",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: backfillNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// stop between batches on Ctrl+C, the checkpoint keeps the progress
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			return withRunner(ctx, func(runner *backfill.Runner) error {
+				return runner.Run(ctx, args[0], opts)
+			})
+		},
+	}
+	run.Flags().IntVar(&opts.BatchSize, "batch-size", 0, "rows per batch (default: per backfill)")
+	run.Flags().DurationVar(&opts.Throttle, "throttle", 0, "pause between batches (default: per backfill)")
+
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Manage batched data backfills",
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "status",
+			Short: "List backfills with their progress",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return withRunner(cmd.Context(), func(runner *backfill.Runner) error {
+					checkpoints, err := runner.Status(cmd.Context())
+					if err != nil {
+						return err
+					}
+					w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+					fmt.Fprintln(w, "NAME\tSTATUS\tPROCESSED\tREMAINING\tLAST ERROR")
+					for _, cp := range checkpoints {
+						remaining, lastError := "-", ""
+						if cp.Remaining != nil {
+							remaining = strconv.FormatInt(*cp.Remaining, 10)
+						}
+						if cp.LastError != nil {
+							lastError = *cp.LastError
+						}
+						fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", cp.Name, cp.Status, cp.Processed, remaining, lastError)
+					}
+					return w.Flush()
+				})
+			},
+		},
+		run,
+		&cobra.Command{
+			Use:               "pause NAME",
+			Short:             "Stop a running backfill after its current batch",
+			Args:              cobra.ExactArgs(1),
+			ValidArgsFunction: backfillNames,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return withRunner(cmd.Context(), func(runner *backfill.Runner) error {
+					return runner.Pause(cmd.Context(), args[0])
+				})
+			},
+		},
+	)
+	return cmd
+}
 
-	if err := goose.RunContext(ctx, command, db, dir); err != nil {
-		log.Fatalf("migrate %v: %v", command, err)
+// withMigrator opens a database/sql handle and a migrator for fn, dir
+// overrides the configured migrations directory when set.
+func (c *cli) withMigrator(ctx context.Context, dir string, fn func(*migrator.Migrator, *sql.DB) error) error {
+	envVars, err := c.loadEnvVars()
+	if err != nil {
+		return err
+	}
+	conf := config.NewConfig(envVars)
+	if dir != "" {
+		conf.Migrations.Dir = dir
 	}
 
-	log.Println("Migrations completed.")
+	db, err := dbRepo.NewSQLDB(ctx, conf.Repository)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
 
-	return nil
+	m, err := migrator.New(db, conf.Migrations.Dir)
+	if err != nil {
+		return err
+	}
+	return fn(m, db)
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go_template_project/api"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func newOpenAPICmd() *cobra.Command {
+	var format string
+
+	dump := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the OpenAPI document served at /docs/",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			doc := api.SwaggerInfo.ReadDoc()
+			switch format {
+			case "json":
+				fmt.Fprintln(cmd.OutOrStdout(), doc)
+				return nil
+			case "yaml":
+				var v any
+				if err := json.Unmarshal([]byte(doc), &v); err != nil {
+					return err
+				}
+				out, err := yaml.Marshal(v)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(cmd.OutOrStdout(), string(out))
+				return nil
+			default:
+				return fmt.Errorf("unsupported format %q, use json or yaml", format)
+			}
+		},
+	}
+	dump.Flags().StringVar(&format, "format", "json", "json or yaml")
+
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Work with the OpenAPI specification",
+	}
+	cmd.AddCommand(dump)
+	return cmd
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+
+	productsDomain "go_template_project/internal/domain/products"
+	productsService "go_template_project/internal/services/http/products"
+)
+
+func (c *cli) newProductsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "products",
+		Short: "Manage the product catalog",
+	}
+	cmd.AddCommand(c.newProductsImportCmd(), c.newProductsExportCmd(), c.newProductsPurgeDeletedCmd())
+	return cmd
+}
+
+func (c *cli) newProductsImportCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Create products from a CSV (with header) or NDJSON file, - reads stdin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data := productsDomain.ImportProductsDTO{Format: format}
+			if data.Format == "" {
+				data.Format = strings.TrimPrefix(filepath.Ext(args[0]), ".")
+			}
+			if err := validator.New().Struct(data); err != nil {
+				return fmt.Errorf("unsupported format %q, use --format csv or ndjson", data.Format)
+			}
+
+			var r io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				r = file
+			}
+
+			repo, closeRepo, err := c.openRepository(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer closeRepo()
+
+			created, err := productsService.New(repo).ImportProducts(cmd.Context(), data, r)
+			fmt.Fprintf(cmd.OutOrStdout(), "created %d products\n", created)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "", "csv or ndjson (default: from the file extension)")
+	return cmd
+}
+
+func (c *cli) newProductsExportCmd() *cobra.Command {
+	var (
+		data   productsDomain.ExportProductsDTO
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Stream products as CSV, NDJSON or XLSX",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validator.New().Struct(data); err != nil {
+				return err
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				file, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
+			}
+
+			repo, closeRepo, err := c.openRepository(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer closeRepo()
+
+			return productsService.New(repo).ExportProducts(cmd.Context(), data, w)
+		},
+	}
+	cmd.Flags().StringVar(&data.Format, "format", productsDomain.ExportFormatCSV, "csv, ndjson or xlsx")
+	cmd.Flags().StringSliceVar(&data.Columns, "columns", nil, "columns to export (default: all)")
+	cmd.Flags().StringVar(&data.Filter.Name, "name", "", "filter by name")
+	cmd.Flags().StringVar(&data.Filter.Title, "title", "", "filter by title")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file to write (default: stdout)")
+	return cmd
+}
+
+func (c *cli) newProductsPurgeDeletedCmd() *cobra.Command {
+	var olderThan time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "purge-deleted",
+		Short: "Permanently remove products soft-deleted longer ago than --older-than",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if olderThan <= 0 {
+				return fmt.Errorf("--older-than must be positive, got %s", olderThan)
+			}
+
+			repo, closeRepo, err := c.openRepository(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer closeRepo()
+
+			ids, err := productsService.New(repo).PurgeDeletedProducts(cmd.Context(), productsDomain.PurgeDeletedProductsDTO{
+				DeletedBefore: time.Now().Add(-olderThan),
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "purged %d products\n", len(ids))
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "minimum time since deletion, e.g. 720h")
+	_ = cmd.MarkFlagRequired("older-than")
+	return cmd
+}
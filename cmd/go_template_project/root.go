@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"go_template_project/internal/config"
+	dbRepo "go_template_project/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+// cli holds state shared by all commands, most importantly the config
+// loader whose flags are available on every command.
+type cli struct {
+	loader *config.Loader
+}
+
+func newRootCmd() *cobra.Command {
+	configFlags := flag.NewFlagSet("config", flag.ContinueOnError)
+	c := &cli{loader: config.NewLoader(configFlags)}
+
+	root := &cobra.Command{
+		Use:          "go_template_project",
+		Short:        "GO TEMPLATE PROJECT server and admin tools",
+		SilenceUsage: true,
+	}
+	root.PersistentFlags().AddGoFlagSet(configFlags)
+
+	root.AddCommand(
+		c.newServeCmd(),
+		c.newMigrateCmd(),
+		c.newSeedCmd(),
+		c.newProductsCmd(),
+		c.newConfigCmd(),
+		newOpenAPICmd(),
+		newVersionCmd(),
+	)
+	return root
+}
+
+// loadEnvVars reads settings from every source and validates them.
+func (c *cli) loadEnvVars() (config.EnvVars, error) {
+	envVars, err := c.loader.Load()
+	if err != nil {
+		return envVars, err
+	}
+	if err := config.Validate(envVars); err != nil {
+		return envVars, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return envVars, nil
+}
+
+// openRepository connects to the database for admin commands, the returned
+// func closes the connection.
+func (c *cli) openRepository(ctx context.Context) (*dbRepo.Repository, func(), error) {
+	envVars, err := c.loadEnvVars()
+	if err != nil {
+		return nil, nil, err
+	}
+	conf := config.NewConfig(envVars)
+
+	conn, err := dbRepo.NewRouter(ctx, conf.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dbRepo.NewRepo(conn, conn.Reader(), conf.Cache), conn.Close, nil
+}
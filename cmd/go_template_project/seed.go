@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	productsDomain "go_template_project/internal/domain/products"
+	productsService "go_template_project/internal/services/http/products"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// Seeded products are created seedBatchSize at a time.
+const seedBatchSize = 500
+
+func (c *cli) newSeedCmd() *cobra.Command {
+	var count int
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Fill the database with generated products for development",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if count < 1 {
+				return fmt.Errorf("--count must be at least 1, got %d", count)
+			}
+
+			repo, closeRepo, err := c.openRepository(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer closeRepo()
+			service := productsService.New(repo)
+
+			// a run tag keeps names unique across runs
+			run := uuid.NewString()[:8]
+			created := 0
+			for created < count {
+				batch := make([]productsDomain.Product, 0, min(seedBatchSize, count-created))
+				for i := created; i < count && len(batch) < seedBatchSize; i++ {
+					batch = append(batch, productsDomain.Product{
+						Name:  fmt.Sprintf("product-%s-%d", run, i+1),
+						Title: fmt.Sprintf("Seeded product %d", i+1),
+					})
+				}
+				products, err := service.BulkCreateProducts(cmd.Context(), batch)
+				if err != nil {
+					return err
+				}
+				created += len(products)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "created %d products\n", created)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&count, "count", 100, "number of products to create")
+	return cmd
+}
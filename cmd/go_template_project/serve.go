@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go_template_project/internal/app"
+	"go_template_project/internal/config"
+	"go_template_project/internal/logger"
+
+	"github.com/spf13/cobra"
+)
+
+func (c *cli) newServeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP server and background job workers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			envVars, err := c.loadEnvVars()
+			if err != nil {
+				return err
+			}
+
+			if err := logger.Setup(envVars.LogLevel); err != nil {
+				return err
+			}
+
+			var (
+				wg   = &sync.WaitGroup{}
+				conf = config.NewConfig(envVars)
+				ctx  = runSignalHandler(cmd.Context(), wg)
+			)
+
+			// Применяем миграции БД
+			if err := prepareDatabase(ctx, conf); err != nil {
+				return err
+			}
+
+			// Создаём новое приложение
+			service, err := app.NewApp(ctx, conf)
+			if err != nil {
+				return err
+			}
+			// Завершаем приложение gracefully
+			defer service.Close()
+
+			service.Run(ctx, wg)
+
+			c.runReloadHandler(ctx, wg, service, envVars)
+
+			wg.Wait()
+			return nil
+		},
+	}
+}
+
+// runReloadHandler re-reads settings on SIGHUP and applies the ones that are
+// safe to change without a restart.
+func (c *cli) runReloadHandler(ctx context.Context, wg *sync.WaitGroup, service *app.App, started config.EnvVars) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer signal.Stop(sighup)
+
+		for {
+			select {
+			case <-sighup:
+				envVars, err := c.loadEnvVars()
+				if err != nil {
+					log.Printf("[reload] invalid configuration, keeping current settings:\n%v", err)
+					continue
+				}
+				if keys := config.RestartRequired(started, envVars); len(keys) > 0 {
+					log.Printf("[reload] restart required to apply: %s", strings.Join(keys, ", "))
+				}
+				if err := service.Reload(config.NewConfig(envVars)); err != nil {
+					log.Printf("[reload] failed: %v", err)
+					continue
+				}
+				log.Println("[reload] configuration reloaded")
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func runSignalHandler(ctx context.Context, wg *sync.WaitGroup) context.Context {
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+
+	sigCtx, cancel := context.WithCancel(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer fmt.Println("[signal] terminate")
+		defer signal.Stop(sigterm)
+		defer wg.Done()
+		defer cancel()
+
+		for {
+			select {
+			case sig, ok := <-sigterm:
+				if !ok {
+					fmt.Printf("[signal] signal chan closed: %s\n", sig.String())
+					return
+				}
+
+				fmt.Printf("[signal] signal recv: %s\n", sig.String())
+				return
+			case _, ok := <-sigCtx.Done():
+				if !ok {
+					fmt.Println("[signal] context closed")
+					return
+				}
+
+				fmt.Printf("[signal] ctx done: %s\n", ctx.Err().Error())
+				return
+			}
+		}
+	}()
+
+	return sigCtx
+}
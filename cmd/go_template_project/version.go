@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// Set at build time with -ldflags "-X main.version=... -X main.commit=...",
+// commit falls back to the VCS revision recorded by the go tool.
+var (
+	version = "dev"
+	commit  = ""
+)
+
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print version information",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			revision := commit
+			if info, ok := debug.ReadBuildInfo(); ok && revision == "" {
+				for _, setting := range info.Settings {
+					if setting.Key == "vcs.revision" {
+						revision = setting.Value
+					}
+				}
+			}
+			if revision == "" {
+				revision = "unknown"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "go_template_project %s (commit %s, %s)\n", version, revision, runtime.Version())
+		},
+	}
+}
@@ -1,89 +0,0 @@
-package main
-
-import (
-	"context"
-	"flag"
-	"fmt"
-	"log"
-	"os"
-
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/pressly/goose/v3"
-
-	appConfig "go_template_project/internal/config"
-)
-
-const (
-	dialect string = "pgx"
-)
-
-var (
-	flags = flag.NewFlagSet("migrate", flag.ExitOnError)
-	dir   = flags.String("dir", "./migrations", "directory with migration files")
-)
-
-func main() {
-	ctx := context.Background()
-	var conf = appConfig.NewConfig(envVars)
-
-	dbString := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable",
-		conf.Repository.Host,
-		conf.Repository.Username,
-		conf.Repository.Password,
-		conf.Repository.Name,
-		conf.Repository.Port,
-	)
-
-	flags.Usage = usage
-	flags.Parse(os.Args[1:])
-
-	args := flags.Args()
-	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
-		flags.Usage()
-		return
-	}
-
-	command := args[0]
-
-	db, err := goose.OpenDBWithDriver(dialect, dbString)
-	if err != nil {
-		log.Fatalln(err.Error())
-	}
-
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Fatalln(err.Error())
-		}
-	}()
-	// ctx context.Context, command string, db *sql.DB, dir string, args ...string
-	if err := goose.RunContext(ctx, command, db, *dir, args[1:]...); err != nil {
-		log.Fatalf("migrate %v: %v", command, err)
-	}
-}
-
-func usage() {
-	fmt.Println(usagePrefix)
-	flags.PrintDefaults()
-	fmt.Println(usageCommands)
-}
-
-var (
-	usagePrefix = `Usage: migrate COMMAND
-Examples:
-    migrate status
-`
-
-	usageCommands = `
-Commands:
-    up                   Migrate the DB to the most recent version available
-    up-by-one            Migrate the DB up by 1
-    up-to VERSION        Migrate the DB to a specific VERSION
-    down                 Roll back the version by 1
-    down-to VERSION      Roll back to a specific VERSION
-    redo                 Re-run the latest migration
-    reset                Roll back all migrations
-    status               Dump the migration status for the current DB
-    version              Print the current version of the database
-    create NAME [sql|go] Creates new migration file with the current timestamp
-    fix                  Apply sequential ordering to migrations`
-)
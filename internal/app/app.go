@@ -5,8 +5,17 @@ import (
 	"errors"
 	"fmt"
 	appHttp "go_template_project/internal/app/http"
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
 	"go_template_project/internal/config"
+	"go_template_project/internal/logger"
 	dbRepo "go_template_project/internal/repository"
+	mediaHttpService "go_template_project/internal/services/http/media"
+	productsService "go_template_project/internal/services/http/products"
+	inventoryService "go_template_project/internal/services/inventory"
+	jobsService "go_template_project/internal/services/jobs"
+	mediaService "go_template_project/internal/services/media"
+	publicationService "go_template_project/internal/services/publication"
+	"go_template_project/internal/storage"
 	"log"
 	"net/http"
 	"sync"
@@ -16,33 +25,63 @@ import (
 
 type (
 	App struct {
-		config     config.Config
-		repository *dbRepo.Repository
-		server     *http.Server
+		config      config.Config
+		db          *dbRepo.Router
+		repository  *dbRepo.Repository
+		server      *http.Server
+		jobs        *jobsService.Pool
+		sweeper     *inventoryService.Sweeper
+		cleaner     *mediaService.Cleaner
+		scheduler   *publicationService.Scheduler
+		cors        *middlewaresHttp.Cors
+		rateLimiter *middlewaresHttp.RateLimiter
 	}
 )
 
 func NewApp(ctx context.Context, config config.Config) (*App, error) {
 	// DB connection
-	conn, err := dbRepo.NewPgxConn(ctx, config.Repository)
+	conn, err := dbRepo.NewRouter(ctx, config.Repository)
 	if err != nil {
 		return nil, err
 	}
 
 	// Repository
-	repo := dbRepo.NewRepo(conn)
+	repo := dbRepo.NewRepo(conn, conn.Reader(), config.Cache)
+
+	// Media files and job artifacts
+	store, err := storage.NewLocal(config.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Background jobs
+	jobs := jobsService.NewPool(repo, config.Jobs)
+	jobsService.RegisterProductsHandlers(jobs, productsService.New(repo), store)
+	jobsService.RegisterMediaHandlers(jobs, mediaHttpService.New(repo, store, config.Media))
+	sweeper := inventoryService.NewSweeper(repo, config.Inventory)
+	cleaner := mediaService.NewCleaner(repo, store, config.Media)
+	scheduler := publicationService.NewScheduler(repo, config.Publication)
 
 	// HTTP router
-	mux := appHttp.RegisterRoutes(config, repo)
+	cors := middlewaresHttp.NewCors(config.Server.AllowOrigins)
+	rateLimiter := middlewaresHttp.NewRateLimiter(config.RateLimit.RPS, config.RateLimit.Burst)
+	mux := appHttp.RegisterRoutes(config, repo, store, cors, rateLimiter)
 
 	// Merge components into app
 	return &App{
 		config:     config,
+		db:         conn,
 		repository: repo,
 		server: &http.Server{
 			Addr:    fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port),
 			Handler: mux,
 		},
+		jobs:        jobs,
+		sweeper:     sweeper,
+		cleaner:     cleaner,
+		scheduler:   scheduler,
+		cors:        cors,
+		rateLimiter: rateLimiter,
 	}, nil
 }
 
@@ -55,15 +94,48 @@ func (a *App) Run(ctx context.Context, wg *sync.WaitGroup) error {
 		}
 	}()
 
+	// Start replica health checks
+	a.db.Run(ctx, wg)
+
+	// Start background job workers, jobs read from the primary since they
+	// usually run right after the write that enqueued them
+	log.Println("Starting job workers")
+	a.jobs.Run(dbRepo.WithReadPrimary(ctx), wg)
+
+	// Start expiring overdue stock reservations
+	a.sweeper.Run(ctx, wg)
+
+	// Start removing files of deleted media
+	a.cleaner.Run(ctx, wg)
+
+	// Start publishing and unpublishing scheduled products
+	a.scheduler.Run(ctx, wg)
+
 	log.Println("All components started")
 
 	return nil
 }
 
+// Reload applies settings that are safe to change while running: log level,
+// CORS origins and rate limits. Anything else needs a restart.
+func (a *App) Reload(config config.Config) error {
+	if err := logger.SetLevel(config.Log.Level); err != nil {
+		return err
+	}
+	a.cors.SetOrigins(config.Server.AllowOrigins)
+	a.rateLimiter.SetLimits(config.RateLimit.RPS, config.RateLimit.Burst)
+
+	a.config.Log = config.Log
+	a.config.Server.AllowOrigins = config.Server.AllowOrigins
+	a.config.RateLimit = config.RateLimit
+	return nil
+}
+
 func (a *App) Close() error {
 	err := a.server.Close()
 	if err != nil {
 		return err
 	}
+	a.db.Close()
 	return nil
 }
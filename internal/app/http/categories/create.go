@@ -0,0 +1,157 @@
+package categories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	createCommand interface {
+		CreateCategory(ctx context.Context, data categoriesDomain.CreateCategoryDTO) (*categoriesDomain.Category, error)
+	}
+
+	CreateHandler struct {
+		name          string
+		createCommand createCommand
+	}
+
+	createRequest struct {
+		body categoriesDomain.CreateCategoryDTO
+	}
+)
+
+func NewCategoryCreateHandler(command createCommand, name string) *CreateHandler {
+	return &CreateHandler{
+		name:          name,
+		createCommand: command,
+	}
+}
+
+// @Summary		Create category
+// @Description	Create a category under parent_id, or a root without it
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			body	body		categoriesDomain.CreateCategoryDTO	true	"Category"
+// @Success		201		{object}	categoriesDomain.Category			"Category"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		409		{string}	string								"Slug already used by a sibling"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/categories [post]
+func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *createRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.createCommand.CreateCategory(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrParentCategoryNotFound), errors.Is(err, categoriesDomain.ErrInvalidAttributeSchema):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrCategorySlugTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *CreateHandler) getRequestData(r *http.Request) (requestData *createRequest, err error) {
+	requestData = &createRequest{}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &categoriesDomain.CreateCategoryDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+
+	return
+}
+
+func (h *CreateHandler) validateRequestData(requestData *createRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,142 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"net/http"
+)
+
+type (
+	deleteCommand interface {
+		DeleteCategory(ctx context.Context, data categoriesDomain.DeleteCategoryDTO) (*categoriesDomain.Category, error)
+	}
+
+	DeleteHandler struct {
+		name          string
+		deleteCommand deleteCommand
+	}
+
+	deleteRequest struct {
+		params categoriesDomain.DeleteCategoryDTO
+	}
+)
+
+func NewCategoryDeleteHandler(command deleteCommand, name string) *DeleteHandler {
+	return &DeleteHandler{
+		name:          name,
+		deleteCommand: command,
+	}
+}
+
+// @Summary		Delete category
+// @Description	Delete a category without children, its products stay but lose the assignment
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Category ID"
+// @Success		200	{object}	categoriesDomain.Category	"Deleted category"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		409	{string}	string						"Category has children"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/categories/{id} [delete]
+func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *deleteRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.deleteCommand.DeleteCategory(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrCategoryNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrCategoryHasChildren):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *DeleteHandler) getRequestData(r *http.Request) (requestData *deleteRequest, err error) {
+	requestData = &deleteRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ID = id
+	return
+}
+
+func (h *DeleteHandler) validateRequestData(requestData *deleteRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,133 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"net/http"
+)
+
+type (
+	getCommand interface {
+		GetCategory(ctx context.Context, data categoriesDomain.GetCategoryDTO) (*categoriesDomain.Category, error)
+	}
+
+	GetHandler struct {
+		name       string
+		getCommand getCommand
+	}
+
+	getRequest struct {
+		params categoriesDomain.GetCategoryDTO
+	}
+)
+
+func NewCategoryGetHandler(command getCommand, name string) *GetHandler {
+	return &GetHandler{
+		name:       name,
+		getCommand: command,
+	}
+}
+
+// @Summary		Get category
+// @Description	Get category by id
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Category ID"
+// @Success		200	{object}	categoriesDomain.Category	"Category"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/categories/{id} [get]
+func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getCommand.GetCategory(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrCategoryNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetHandler) getRequestData(r *http.Request) (requestData *getRequest, err error) {
+	requestData = &getRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ID = id
+	return
+}
+
+func (h *GetHandler) validateRequestData(requestData *getRequest) error {
+	return validator.New().Struct(requestData.params)
+}
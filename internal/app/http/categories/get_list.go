@@ -0,0 +1,123 @@
+package categories
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"net/http"
+)
+
+type (
+	getListCommand interface {
+		GetCategories(ctx context.Context, data categoriesDomain.GetCategoriesDTO) ([]categoriesDomain.Category, error)
+	}
+
+	GetListHandler struct {
+		name           string
+		getListCommand getListCommand
+	}
+
+	getListRequest struct {
+		params categoriesDomain.GetCategoriesDTO
+	}
+)
+
+func NewCategoriesGetHandler(command getListCommand, name string) *GetListHandler {
+	return &GetListHandler{
+		name:           name,
+		getListCommand: command,
+	}
+}
+
+// @Summary		Get categories
+// @Description	Get the whole category tree ordered by depth and position, or the children of parent_id
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			parent_id	query		string						false	"Only return children of this category"
+// @Success		200			{array}		categoriesDomain.Category	"Categories"
+// @Failure		400			{string}	string						"Bad Request"
+// @Failure		406			{string}	string						"Not Acceptable"
+// @Failure		500			{string}	string						"Internal Server Error"
+// @Router			/api/categories/ [get]
+func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getListRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getListCommand.GetCategories(ctx, requestData.params)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetListHandler) getRequestData(r *http.Request) (requestData *getListRequest, err error) {
+	requestData = &getListRequest{}
+	parentID := r.FormValue("parent_id")
+	if parentID != "" {
+		var id uuid.UUID
+		if id, err = uuid.Parse(parentID); err != nil {
+			return
+		}
+		requestData.params.ParentID = &id
+	}
+
+	return
+}
+
+func (h *GetListHandler) validateRequestData(requestData *getListRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,154 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strconv"
+)
+
+type (
+	getProductsCommand interface {
+		GetCategoryProducts(ctx context.Context, data categoriesDomain.GetCategoryProductsDTO) ([]productsDomain.Product, error)
+	}
+
+	GetProductsHandler struct {
+		name               string
+		getProductsCommand getProductsCommand
+	}
+
+	getProductsRequest struct {
+		params categoriesDomain.GetCategoryProductsDTO
+	}
+)
+
+func NewCategoryProductsGetHandler(command getProductsCommand, name string) *GetProductsHandler {
+	return &GetProductsHandler{
+		name:               name,
+		getProductsCommand: command,
+	}
+}
+
+// @Summary		Get category products
+// @Description	Get published products assigned to the category or any of its descendants, under /api/admin all of them
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id		path		string					true	"Category ID"
+// @Param			limit	query		int						false	"List limit"	default(50)	max(50)
+// @Param			offset	query		int						false	"List offset"	default(0)
+// @Success		200		{array}		productsDomain.Product	"Products"
+// @Failure		400		{string}	string					"Bad Request"
+// @Failure		404		{string}	string					"Not Found"
+// @Failure		406		{string}	string					"Not Acceptable"
+// @Failure		500		{string}	string					"Internal Server Error"
+// @Router			/api/categories/{id}/products [get]
+func (h *GetProductsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getProductsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getProductsCommand.GetCategoryProducts(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrCategoryNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetProductsHandler) getRequestData(r *http.Request) (requestData *getProductsRequest, err error) {
+	requestData = &getProductsRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ID = id
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit == 0 || limit > 50 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	offset, err := strconv.Atoi(r.FormValue("offset"))
+	if err != nil {
+		offset = 0
+		err = nil
+	}
+	requestData.params.Offset = int64(offset)
+	requestData.params.PublishedOnly = httpResponses.PublishedOnly(r.Context())
+
+	return
+}
+
+func (h *GetProductsHandler) validateRequestData(requestData *getProductsRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,181 @@
+package categories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	moveCommand interface {
+		MoveCategory(ctx context.Context, data categoriesDomain.MoveCategoryDTO) (*categoriesDomain.Category, error)
+	}
+
+	MoveHandler struct {
+		name        string
+		moveCommand moveCommand
+	}
+
+	moveRequest struct {
+		body categoriesDomain.MoveCategoryDTO
+	}
+)
+
+func NewCategoryMoveHandler(command moveCommand, name string) *MoveHandler {
+	return &MoveHandler{
+		name:        name,
+		moveCommand: command,
+	}
+}
+
+// @Summary		Move category
+// @Description	Move a category with its subtree under parent_id, or to the roots without it
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string								true	"Category ID"
+// @Param			body	body		categoriesDomain.MoveCategoryDTO	true	"New parent and position"
+// @Success		200		{object}	categoriesDomain.Category			"Category"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		404		{string}	string								"Not Found"
+// @Failure		409		{string}	string								"Move would create a cycle or slug already used"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/categories/{id}/move [post]
+func (h *MoveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *moveRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.moveCommand.MoveCategory(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrCategoryNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrParentCategoryNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrCategoryCycle):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrCategorySlugTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *MoveHandler) getRequestData(r *http.Request) (requestData *moveRequest, err error) {
+	requestData = &moveRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &categoriesDomain.MoveCategoryDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ID = id
+
+	return
+}
+
+func (h *MoveHandler) validateRequestData(requestData *moveRequest) error {
+	return validator.New().Struct(requestData.body)
+}
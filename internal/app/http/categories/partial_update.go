@@ -0,0 +1,173 @@
+package categories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	partialUpdateCommand interface {
+		PartialUpdateCategory(ctx context.Context, data categoriesDomain.PartialUpdateCategoryDTO) (*categoriesDomain.Category, error)
+	}
+
+	PartialUpdateHandler struct {
+		name                 string
+		partialUpdateCommand partialUpdateCommand
+	}
+
+	partialUpdateRequest struct {
+		body categoriesDomain.PartialUpdateCategoryDTO
+	}
+)
+
+func NewCategoryPartialUpdateHandler(command partialUpdateCommand, name string) *PartialUpdateHandler {
+	return &PartialUpdateHandler{
+		name:                 name,
+		partialUpdateCommand: command,
+	}
+}
+
+// @Summary		PartialUpdate category
+// @Description	Rename a category or change its slug
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string										true	"Category ID"
+// @Param			body	body		categoriesDomain.PartialUpdateCategoryDTO	true	"Fields to change"
+// @Success		200		{object}	categoriesDomain.Category					"Category"
+// @Failure		400		{string}	string										"Bad Request"
+// @Failure		404		{string}	string										"Not Found"
+// @Failure		409		{string}	string										"Slug already used by a sibling"
+// @Failure		406		{string}	string										"Not Acceptable"
+// @Failure		500		{string}	string										"Internal Server Error"
+// @Router			/api/categories/{id} [patch]
+func (h *PartialUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *partialUpdateRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.partialUpdateCommand.PartialUpdateCategory(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrCategoryNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrInvalidAttributeSchema):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrCategorySlugTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *PartialUpdateHandler) getRequestData(r *http.Request) (requestData *partialUpdateRequest, err error) {
+	requestData = &partialUpdateRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &categoriesDomain.PartialUpdateCategoryDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ID = id
+
+	return
+}
+
+func (h *PartialUpdateHandler) validateRequestData(requestData *partialUpdateRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,134 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+)
+
+type (
+	productCategoriesGetCommand interface {
+		GetProductCategories(ctx context.Context, data categoriesDomain.GetProductCategoriesDTO) ([]categoriesDomain.Category, error)
+	}
+
+	ProductCategoriesGetHandler struct {
+		name                        string
+		productCategoriesGetCommand productCategoriesGetCommand
+	}
+
+	productCategoriesGetRequest struct {
+		params categoriesDomain.GetProductCategoriesDTO
+	}
+)
+
+func NewProductCategoriesGetHandler(command productCategoriesGetCommand, name string) *ProductCategoriesGetHandler {
+	return &ProductCategoriesGetHandler{
+		name:                        name,
+		productCategoriesGetCommand: command,
+	}
+}
+
+// @Summary		Get product categories
+// @Description	Get the categories a product is assigned to
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Product ID"
+// @Success		200	{array}		categoriesDomain.Category	"Categories"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/products/{id}/categories [get]
+func (h *ProductCategoriesGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *productCategoriesGetRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.productCategoriesGetCommand.GetProductCategories(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *ProductCategoriesGetHandler) getRequestData(r *http.Request) (requestData *productCategoriesGetRequest, err error) {
+	requestData = &productCategoriesGetRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = id
+	return
+}
+
+func (h *ProductCategoriesGetHandler) validateRequestData(requestData *productCategoriesGetRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,165 @@
+package categories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	productCategoriesSetCommand interface {
+		SetProductCategories(ctx context.Context, data categoriesDomain.SetProductCategoriesDTO) ([]categoriesDomain.Category, error)
+	}
+
+	ProductCategoriesSetHandler struct {
+		name                        string
+		productCategoriesSetCommand productCategoriesSetCommand
+	}
+
+	productCategoriesSetRequest struct {
+		body categoriesDomain.SetProductCategoriesDTO
+	}
+)
+
+func NewProductCategoriesSetHandler(command productCategoriesSetCommand, name string) *ProductCategoriesSetHandler {
+	return &ProductCategoriesSetHandler{
+		name:                        name,
+		productCategoriesSetCommand: command,
+	}
+}
+
+// @Summary		Set product categories
+// @Description	Replace the categories a product is assigned to
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string										true	"Product ID"
+// @Param			body	body		categoriesDomain.SetProductCategoriesDTO	true	"Category ids"
+// @Success		200		{array}		categoriesDomain.Category					"Categories"
+// @Failure		400		{string}	string										"Bad Request"
+// @Failure		404		{string}	string										"Not Found"
+// @Failure		406		{string}	string										"Not Acceptable"
+// @Failure		500		{string}	string										"Internal Server Error"
+// @Router			/api/products/{id}/categories [put]
+func (h *ProductCategoriesSetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *productCategoriesSetRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.productCategoriesSetCommand.SetProductCategories(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, categoriesDomain.ErrCategoryNotFound), errors.Is(err, productsDomain.ErrInvalidAttributes):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *ProductCategoriesSetHandler) getRequestData(r *http.Request) (requestData *productCategoriesSetRequest, err error) {
+	requestData = &productCategoriesSetRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &categoriesDomain.SetProductCategoriesDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *ProductCategoriesSetHandler) validateRequestData(requestData *productCategoriesSetRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,148 @@
+package categories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	reorderCommand interface {
+		ReorderCategories(ctx context.Context, data categoriesDomain.ReorderCategoriesDTO) ([]categoriesDomain.Category, error)
+	}
+
+	ReorderHandler struct {
+		name           string
+		reorderCommand reorderCommand
+	}
+
+	reorderRequest struct {
+		body categoriesDomain.ReorderCategoriesDTO
+	}
+)
+
+func NewCategoriesReorderHandler(command reorderCommand, name string) *ReorderHandler {
+	return &ReorderHandler{
+		name:           name,
+		reorderCommand: command,
+	}
+}
+
+// @Summary		Reorder categories
+// @Description	Set the order of the children of parent_id, or of the roots without it
+// @Tags			Categories
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			body	body		categoriesDomain.ReorderCategoriesDTO	true	"Children in their new order"
+// @Success		200		{array}		categoriesDomain.Category				"Reordered children"
+// @Failure		400		{string}	string									"Bad Request"
+// @Failure		406		{string}	string									"Not Acceptable"
+// @Failure		500		{string}	string									"Internal Server Error"
+// @Router			/api/categories/reorder [post]
+func (h *ReorderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *reorderRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.reorderCommand.ReorderCategories(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, categoriesDomain.ErrCategoryNotChild):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *ReorderHandler) getRequestData(r *http.Request) (requestData *reorderRequest, err error) {
+	requestData = &reorderRequest{}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &categoriesDomain.ReorderCategoriesDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+
+	return
+}
+
+func (h *ReorderHandler) validateRequestData(requestData *reorderRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,137 @@
+package categories
+
+import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/categories"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	repo *dbRepo.Repository,
+) {
+	// Get categories
+	mux.Handle(
+		"GET /api/categories/",
+		middlewaresHttp.Negotiate(
+			NewCategoriesGetHandler(
+				command.New(repo),
+				"GET /api/categories/",
+			),
+		),
+	)
+
+	// Get category
+	mux.Handle(
+		"GET /api/categories/{id}",
+		middlewaresHttp.Negotiate(
+			NewCategoryGetHandler(
+				command.New(repo),
+				"GET /api/categories/{id}",
+			),
+		),
+	)
+
+	// Get category breadcrumbs
+	mux.Handle(
+		"GET /api/categories/{id}/breadcrumbs",
+		middlewaresHttp.Negotiate(
+			NewCategoryBreadcrumbsHandler(
+				command.New(repo),
+				"GET /api/categories/{id}/breadcrumbs",
+			),
+		),
+	)
+
+	// Get products in the category subtree
+	mux.Handle(
+		"GET /api/categories/{id}/products",
+		middlewaresHttp.Negotiate(
+			NewCategoryProductsGetHandler(
+				command.New(repo),
+				"GET /api/categories/{id}/products",
+			),
+		),
+	)
+
+	// Create category
+	mux.Handle(
+		"POST /api/categories",
+		middlewaresHttp.Negotiate(
+			NewCategoryCreateHandler(
+				command.New(repo),
+				"POST /api/categories",
+			),
+		),
+	)
+
+	// Partial update category
+	mux.Handle(
+		"PATCH /api/categories/{id}",
+		middlewaresHttp.Negotiate(
+			NewCategoryPartialUpdateHandler(
+				command.New(repo),
+				"PATCH /api/categories/{id}",
+			),
+		),
+	)
+
+	// Delete category
+	mux.Handle(
+		"DELETE /api/categories/{id}",
+		middlewaresHttp.Negotiate(
+			NewCategoryDeleteHandler(
+				command.New(repo),
+				"DELETE /api/categories/{id}",
+			),
+		),
+	)
+
+	// Move category with its subtree
+	mux.Handle(
+		"POST /api/categories/{id}/move",
+		middlewaresHttp.Negotiate(
+			NewCategoryMoveHandler(
+				command.New(repo),
+				"POST /api/categories/{id}/move",
+			),
+		),
+	)
+
+	// Reorder sibling categories
+	mux.Handle(
+		"POST /api/categories/reorder",
+		middlewaresHttp.Negotiate(
+			NewCategoriesReorderHandler(
+				command.New(repo),
+				"POST /api/categories/reorder",
+			),
+		),
+	)
+
+	// Get product categories
+	mux.Handle(
+		"GET /api/products/{id}/categories",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductCategoriesGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/categories",
+				),
+			),
+		),
+	)
+
+	// Set product categories
+	mux.Handle(
+		"PUT /api/products/{id}/categories",
+		middlewaresHttp.Negotiate(
+			NewProductCategoriesSetHandler(
+				command.New(repo),
+				"PUT /api/products/{id}/categories",
+			),
+		),
+	)
+}
@@ -0,0 +1,230 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	graphqlGo "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	httpResponses "go_template_project/internal/app/http/responses"
+)
+
+type (
+	Handler struct {
+		name          string
+		schema        graphqlGo.Schema
+		command       productsCommand
+		maxDepth      int
+		maxComplexity int
+	}
+
+	graphQLRequest struct {
+		body graphQLRequestBody
+	}
+
+	graphQLRequestBody struct {
+		Query         string                 `json:"query" validate:"required"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+)
+
+func NewGraphQLHandler(command productsCommand, maxDepth, maxComplexity int, name string) (*Handler, error) {
+	schema, err := NewSchema(command)
+	if err != nil {
+		return nil, fmt.Errorf("graphql schema build failed: %w", err)
+	}
+	return &Handler{
+		name:          name,
+		schema:        schema,
+		command:       command,
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+	}, nil
+}
+
+// @Summary		GraphQL endpoint
+// @Description	Execute GraphQL queries and mutations over products
+// @Tags			GraphQL
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	object	"GraphQL result"
+// @Failure		400	{object}	object	"Invalid query"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/graphql [post]
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *graphQLRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(requestData.body.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		h.writeErrors(w, http.StatusBadRequest, gqlerrors.FormatError(err))
+		return
+	}
+
+	validation := graphqlGo.ValidateDocument(&h.schema, doc, graphqlGo.SpecifiedRules)
+	if !validation.IsValid {
+		h.writeErrors(w, http.StatusBadRequest, validation.Errors...)
+		return
+	}
+
+	err = checkLimits(
+		doc,
+		requestData.body.OperationName,
+		requestData.body.Variables,
+		h.maxDepth,
+		h.maxComplexity,
+	)
+	if err != nil {
+		h.writeErrors(w, http.StatusBadRequest, gqlerrors.FormatError(err))
+		return
+	}
+
+	if r.Method == http.MethodGet && hasMutation(doc, requestData.body.OperationName) {
+		h.writeErrors(
+			w,
+			http.StatusMethodNotAllowed,
+			gqlerrors.FormatError(errors.New("mutations are only allowed over POST")),
+		)
+		return
+	}
+
+	result := graphqlGo.Execute(graphqlGo.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: requestData.body.OperationName,
+		Args:          requestData.body.Variables,
+		Context:       withProductLoader(ctx, newProductLoader(h.command)),
+	})
+
+	responseBody, err := json.Marshal(result)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("json marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *Handler) getRequestData(r *http.Request) (requestData *graphQLRequest, err error) {
+	requestData = &graphQLRequest{}
+
+	if r.Method == http.MethodGet {
+		requestData.body.Query = r.FormValue("query")
+		requestData.body.OperationName = r.FormValue("operationName")
+		if variables := r.FormValue("variables"); variables != "" {
+			err = json.Unmarshal([]byte(variables), &requestData.body.Variables)
+		}
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	err = json.Unmarshal(body, &requestData.body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	return
+}
+
+func (h *Handler) validateRequestData(requestData *graphQLRequest) error {
+	return validator.New().Struct(requestData.body)
+}
+
+func (h *Handler) writeErrors(w http.ResponseWriter, statusCode int, errs ...gqlerrors.FormattedError) {
+	responseBody, err := json.Marshal(&graphqlGo.Result{Errors: errs})
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("json marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		statusCode,
+		&responseBody,
+	)
+}
+
+func hasMutation(doc *ast.Document, operationName string) bool {
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (operation.Name == nil || operation.Name.Value != operationName) {
+			continue
+		}
+		if operation.Operation == ast.OperationTypeMutation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Fields of list connections multiply the cost of their selection by the
+// requested page size; without an explicit size the default page is assumed.
+const defaultListCost = defaultPageSize
+
+type queryAnalyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// checkLimits rejects operations nested deeper than maxDepth or with an
+// estimated cost above maxComplexity. Introspection fields are not counted.
+func checkLimits(
+	doc *ast.Document,
+	operationName string,
+	variables map[string]interface{},
+	maxDepth int,
+	maxComplexity int,
+) error {
+	analyzer := queryAnalyzer{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+	operations := make([]*ast.OperationDefinition, 0)
+	for _, definition := range doc.Definitions {
+		switch def := definition.(type) {
+		case *ast.FragmentDefinition:
+			analyzer.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operations = append(operations, def)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		depth := analyzer.depth(operation.SelectionSet, map[string]bool{})
+		if maxDepth > 0 && depth > maxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, maxDepth)
+		}
+		complexity := analyzer.complexity(operation.SelectionSet, map[string]bool{})
+		if maxComplexity > 0 && complexity > maxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, maxComplexity)
+		}
+	}
+	return nil
+}
+
+func (a queryAnalyzer) depth(set *ast.SelectionSet, visited map[string]bool) int {
+	if set == nil {
+		return 0
+	}
+	maxDepth := 0
+	for _, selection := range set.Selections {
+		depth := 0
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			depth = 1 + a.depth(sel.SelectionSet, visited)
+		case *ast.InlineFragment:
+			depth = a.depth(sel.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			fragment, ok := a.fragments[sel.Name.Value]
+			if !ok || visited[sel.Name.Value] {
+				continue
+			}
+			visited[sel.Name.Value] = true
+			depth = a.depth(fragment.SelectionSet, visited)
+			delete(visited, sel.Name.Value)
+		}
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	return maxDepth
+}
+
+func (a queryAnalyzer) complexity(set *ast.SelectionSet, visited map[string]bool) int {
+	if set == nil {
+		return 0
+	}
+	total := 0
+	for _, selection := range set.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			total += 1 + a.multiplier(sel)*a.complexity(sel.SelectionSet, visited)
+		case *ast.InlineFragment:
+			total += a.complexity(sel.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			fragment, ok := a.fragments[sel.Name.Value]
+			if !ok || visited[sel.Name.Value] {
+				continue
+			}
+			visited[sel.Name.Value] = true
+			total += a.complexity(fragment.SelectionSet, visited)
+			delete(visited, sel.Name.Value)
+		}
+	}
+	return total
+}
+
+func (a queryAnalyzer) multiplier(field *ast.Field) int {
+	isList := false
+	for _, argument := range field.Arguments {
+		switch argument.Name.Value {
+		case "first", "last":
+			if size, ok := a.intValue(argument.Value); ok && size > 0 {
+				return size
+			}
+			isList = true
+		case "ids":
+			if list, ok := argument.Value.(*ast.ListValue); ok {
+				return max(len(list.Values), 1)
+			}
+			if values, ok := a.variableValue(argument.Value).([]interface{}); ok {
+				return max(len(values), 1)
+			}
+			isList = true
+		}
+	}
+	if isList || field.Name.Value == "products" {
+		return defaultListCost
+	}
+	return 1
+}
+
+func (a queryAnalyzer) intValue(value ast.Value) (int, bool) {
+	if v, ok := value.(*ast.IntValue); ok {
+		size, err := strconv.Atoi(v.Value)
+		return size, err == nil
+	}
+	switch v := a.variableValue(value).(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+func (a queryAnalyzer) variableValue(value ast.Value) interface{} {
+	variable, ok := value.(*ast.Variable)
+	if !ok {
+		return nil
+	}
+	return a.variables[variable.Name.Value]
+}
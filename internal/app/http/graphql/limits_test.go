@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestCheckLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		maxDepth      int
+		maxComplexity int
+		wantErr       string
+	}{
+		{
+			name:          "within limits",
+			query:         `{ products(first: 10) { edges { node { id name } } } }`,
+			maxDepth:      4,
+			maxComplexity: 41,
+		},
+		{
+			name:     "too deep",
+			query:    `{ products(first: 10) { edges { node { id name } } } }`,
+			maxDepth: 3,
+			wantErr:  "query depth 4 exceeds the limit of 3",
+		},
+		{
+			name:          "page size multiplies the selection",
+			query:         `{ products(first: 10) { edges { node { id name } } } }`,
+			maxComplexity: 40,
+			wantErr:       "query complexity 41 exceeds the limit of 40",
+		},
+		{
+			name:          "default page size without first",
+			query:         `{ products { edges { node { id } } } }`,
+			maxComplexity: 150,
+			wantErr:       "query complexity 151 exceeds the limit of 150",
+		},
+		{
+			name:          "page size from a variable",
+			query:         `query($n: Int) { products(first: $n) { edges { node { id } } } }`,
+			variables:     map[string]interface{}{"n": float64(2)},
+			maxComplexity: 6,
+			wantErr:       "query complexity 7 exceeds the limit of 6",
+		},
+		{
+			name:          "ids count multiplies the selection",
+			query:         `{ productsByIds(ids: ["a", "b", "c"]) { id name } }`,
+			maxComplexity: 6,
+			wantErr:       "query complexity 7 exceeds the limit of 6",
+		},
+		{
+			name:     "fragments count towards depth",
+			query:    `{ product(id: "a") { ...fields } } fragment fields on Product { id name }`,
+			maxDepth: 1,
+			wantErr:  "query depth 2 exceeds the limit of 1",
+		},
+		{
+			name:     "recursive fragments are not followed",
+			query:    `{ product(id: "a") { ...fields } } fragment fields on Product { id ...fields }`,
+			maxDepth: 2,
+		},
+		{
+			name:          "introspection is not counted",
+			query:         `{ __schema { types { name fields { name } } } }`,
+			maxDepth:      1,
+			maxComplexity: 1,
+		},
+		{
+			name: "only the named operation is checked",
+			query: `query Small { product(id: "a") { id } }
+				query Large { products { edges { node { id } } } }`,
+			operationName: "Small",
+			maxDepth:      2,
+			maxComplexity: 2,
+		},
+		{
+			name:  "zero limits are disabled",
+			query: `{ products(first: 100) { edges { node { id name } } } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			err = checkLimits(doc, tt.operationName, tt.variables, tt.maxDepth, tt.maxComplexity)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("got error %q, want none", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type (
+	batchGetCommand interface {
+		GetProductsByIDs(
+			ctx context.Context,
+			data productsDomain.GetProductsByIDsDTO,
+		) ([]productsDomain.Product, error)
+	}
+
+	// productLoader collects product ids requested by resolvers of the same
+	// execution level and fetches them with a single GetProductsByIDs call.
+	productLoader struct {
+		command batchGetCommand
+
+		mu    sync.Mutex
+		batch *productBatch
+		cache map[uuid.UUID]*productBatch
+	}
+
+	productBatch struct {
+		once     sync.Once
+		ids      []uuid.UUID
+		products map[uuid.UUID]productsDomain.Product
+		err      error
+	}
+
+	loaderCtxKey struct{}
+)
+
+func newProductLoader(command batchGetCommand) *productLoader {
+	return &productLoader{
+		command: command,
+		cache:   make(map[uuid.UUID]*productBatch),
+	}
+}
+
+func withProductLoader(ctx context.Context, loader *productLoader) context.Context {
+	return context.WithValue(ctx, loaderCtxKey{}, loader)
+}
+
+func productLoaderFromContext(ctx context.Context) *productLoader {
+	loader, _ := ctx.Value(loaderCtxKey{}).(*productLoader)
+	return loader
+}
+
+// Load schedules id for the next batch and returns a thunk resolving to the
+// product. A nil product without error means the id was not found.
+func (l *productLoader) Load(ctx context.Context, id uuid.UUID) func() (*productsDomain.Product, error) {
+	l.mu.Lock()
+	batch, ok := l.cache[id]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &productBatch{}
+		}
+		batch = l.batch
+		batch.ids = append(batch.ids, id)
+		l.cache[id] = batch
+	}
+	l.mu.Unlock()
+
+	return func() (*productsDomain.Product, error) {
+		batch.once.Do(func() {
+			l.mu.Lock()
+			if l.batch == batch {
+				l.batch = nil
+			}
+			l.mu.Unlock()
+			batch.dispatch(ctx, l.command)
+		})
+		if batch.err != nil {
+			return nil, batch.err
+		}
+		product, ok := batch.products[id]
+		if !ok {
+			return nil, nil
+		}
+		return &product, nil
+	}
+}
+
+// Prime stores already loaded products so later Load calls do not hit the
+// database again.
+func (l *productLoader) Prime(products ...productsDomain.Product) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, product := range products {
+		batch := &productBatch{
+			products: map[uuid.UUID]productsDomain.Product{product.ID: product},
+		}
+		batch.once.Do(func() {})
+		l.cache[product.ID] = batch
+	}
+}
+
+// Clear forgets id, so the next Load fetches a fresh copy after a mutation.
+func (l *productLoader) Clear(id uuid.UUID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.cache, id)
+}
+
+func (b *productBatch) dispatch(ctx context.Context, command batchGetCommand) {
+	products, err := command.GetProductsByIDs(ctx, productsDomain.GetProductsByIDsDTO{
+		IDs:           b.ids,
+		PublishedOnly: true,
+	})
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.products = make(map[uuid.UUID]productsDomain.Product, len(products))
+	for _, product := range products {
+		b.products[product.ID] = product
+	}
+}
@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+)
+
+var playgroundTemplate = template.Must(template.New("graphiql").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>GraphiQL</title>
+  <style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css" />
+</head>
+<body>
+  <div id="graphiql">Loading...</div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: {{ .Endpoint }} });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(
+      React.createElement(GraphiQL, { fetcher: fetcher })
+    );
+  </script>
+</body>
+</html>
+`))
+
+type PlaygroundHandler struct {
+	endpoint string
+}
+
+func NewPlaygroundHandler(endpoint string) *PlaygroundHandler {
+	return &PlaygroundHandler{
+		endpoint: endpoint,
+	}
+}
+
+func (h *PlaygroundHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := playgroundTemplate.Execute(w, struct{ Endpoint string }{Endpoint: h.endpoint})
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,301 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	graphqlGo "github.com/graphql-go/graphql"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 50
+	cursorPrefix    = "offset:"
+)
+
+type (
+	productsCommand interface {
+		batchGetCommand
+		GetProducts(
+			ctx context.Context,
+			data productsDomain.GetProductsDTO,
+		) ([]productsDomain.Product, error)
+		CreateProduct(
+			ctx context.Context,
+			data productsDomain.CreateProductDTO,
+		) (*productsDomain.Product, error)
+		BulkCreateProducts(
+			ctx context.Context,
+			data []productsDomain.Product,
+		) ([]productsDomain.Product, error)
+		PartialUpdateProduct(
+			ctx context.Context,
+			data productsDomain.PartialUpdateProductDTO,
+		) (*productsDomain.Product, error)
+		DeleteProduct(
+			ctx context.Context,
+			data productsDomain.DeleteProductDTO,
+		) (*productsDomain.Product, error)
+	}
+
+	resolver struct {
+		command productsCommand
+	}
+)
+
+func (r resolver) product(p graphqlGo.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	thunk := r.loader(p.Context).Load(p.Context, id)
+	return func() (interface{}, error) {
+		product, err := thunk()
+		if err != nil || product == nil {
+			return nil, err
+		}
+		return *product, nil
+	}, nil
+}
+
+func (r resolver) productsByIDs(p graphqlGo.ResolveParams) (interface{}, error) {
+	rawIDs, _ := p.Args["ids"].([]interface{})
+	if len(rawIDs) > maxPageSize {
+		return nil, fmt.Errorf("at most %d ids can be requested at once", maxPageSize)
+	}
+	loader := r.loader(p.Context)
+	thunks := make([]func() (*productsDomain.Product, error), 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := parseID(rawID)
+		if err != nil {
+			return nil, err
+		}
+		thunks = append(thunks, loader.Load(p.Context, id))
+	}
+	return func() (interface{}, error) {
+		products := make([]interface{}, 0, len(thunks))
+		for _, thunk := range thunks {
+			product, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if product == nil {
+				products = append(products, nil)
+				continue
+			}
+			products = append(products, *product)
+		}
+		return products, nil
+	}, nil
+}
+
+func (r resolver) products(p graphqlGo.ResolveParams) (interface{}, error) {
+	first, hasFirst := p.Args["first"].(int)
+	last, hasLast := p.Args["last"].(int)
+	if hasFirst && hasLast {
+		return nil, errors.New("first and last cannot be combined")
+	}
+	if (hasFirst && first < 0) || (hasLast && last < 0) {
+		return nil, errors.New("first and last must not be negative")
+	}
+
+	start := 0
+	if after, ok := p.Args["after"].(string); ok {
+		offset, err := decodeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		start = offset + 1
+	}
+	end := -1
+	if before, ok := p.Args["before"].(string); ok {
+		offset, err := decodeCursor(before)
+		if err != nil {
+			return nil, err
+		}
+		end = offset
+	}
+
+	size := defaultPageSize
+	switch {
+	case hasFirst:
+		size = first
+	case hasLast:
+		if end < 0 {
+			return nil, errors.New("last requires a before cursor")
+		}
+		size = last
+		start = max(start, end-last)
+	}
+	size = min(size, maxPageSize)
+	if end >= 0 {
+		size = min(size, max(end-start, 0))
+	}
+
+	dto := productsDomain.GetProductsDTO{
+		Limit:         int64(size) + 1,
+		Offset:        int64(start),
+		PublishedOnly: true,
+	}
+	if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+		dto.Name, _ = filter["name"].(string)
+		dto.Title, _ = filter["title"].(string)
+		if category, ok := filter["category"].(string); ok {
+			id, err := parseID(category)
+			if err != nil {
+				return nil, err
+			}
+			dto.Category = &id
+		}
+	}
+
+	var products []productsDomain.Product
+	if size > 0 {
+		var err error
+		products, err = r.command.GetProducts(p.Context, dto)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hasNextPage := len(products) > size
+	if hasNextPage {
+		products = products[:size]
+	}
+	r.loader(p.Context).Prime(products...)
+
+	edges := make([]interface{}, 0, len(products))
+	for i, product := range products {
+		edges = append(edges, map[string]interface{}{
+			"cursor": encodeCursor(start + i),
+			"node":   product,
+		})
+	}
+	pageInfo := map[string]interface{}{
+		"hasNextPage":     hasNextPage,
+		"hasPreviousPage": start > 0,
+		"startCursor":     nil,
+		"endCursor":       nil,
+	}
+	if len(products) > 0 {
+		pageInfo["startCursor"] = encodeCursor(start)
+		pageInfo["endCursor"] = encodeCursor(start + len(products) - 1)
+	}
+
+	return map[string]interface{}{
+		"edges":    edges,
+		"pageInfo": pageInfo,
+	}, nil
+}
+
+func (r resolver) createProduct(p graphqlGo.ResolveParams) (interface{}, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	dto := productsDomain.CreateProductDTO{}
+	dto.Name, _ = input["name"].(string)
+	dto.Title, _ = input["title"].(string)
+
+	product, err := r.command.CreateProduct(p.Context, dto)
+	if err != nil {
+		return nil, err
+	}
+	r.loader(p.Context).Prime(*product)
+	return *product, nil
+}
+
+func (r resolver) bulkCreateProducts(p graphqlGo.ResolveParams) (interface{}, error) {
+	inputs, _ := p.Args["input"].([]interface{})
+	if len(inputs) > maxPageSize {
+		return nil, fmt.Errorf("at most %d products can be created at once", maxPageSize)
+	}
+	data := make([]productsDomain.Product, 0, len(inputs))
+	for _, rawInput := range inputs {
+		input, _ := rawInput.(map[string]interface{})
+		product := productsDomain.Product{}
+		product.Name, _ = input["name"].(string)
+		product.Title, _ = input["title"].(string)
+		data = append(data, product)
+	}
+
+	products, err := r.command.BulkCreateProducts(p.Context, data)
+	if err != nil {
+		return nil, err
+	}
+	r.loader(p.Context).Prime(products...)
+	return products, nil
+}
+
+func (r resolver) updateProduct(p graphqlGo.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	input, _ := p.Args["input"].(map[string]interface{})
+	dto := productsDomain.PartialUpdateProductDTO{ID: id}
+	dto.Name, _ = input["name"].(string)
+	dto.Title, _ = input["title"].(string)
+
+	product, err := r.command.PartialUpdateProduct(p.Context, dto)
+	if err != nil {
+		return nil, err
+	}
+	loader := r.loader(p.Context)
+	loader.Clear(id)
+	loader.Prime(*product)
+	return *product, nil
+}
+
+func (r resolver) deleteProduct(p graphqlGo.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+	product, err := r.command.DeleteProduct(p.Context, productsDomain.DeleteProductDTO{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	r.loader(p.Context).Clear(id)
+	return product.ID.String(), nil
+}
+
+// loader returns the request scoped loader, falling back to a fresh one when
+// the schema is executed outside of Handler.
+func (r resolver) loader(ctx context.Context) *productLoader {
+	if loader := productLoaderFromContext(ctx); loader != nil {
+		return loader
+	}
+	return newProductLoader(r.command)
+}
+
+func resolveProductID(p graphqlGo.ResolveParams) (interface{}, error) {
+	return p.Source.(productsDomain.Product).ID.String(), nil
+}
+
+func parseID(value interface{}) (uuid.UUID, error) {
+	raw, _ := value.(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid id %q: %w", raw, err)
+	}
+	return id, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return offset, nil
+}
@@ -0,0 +1,34 @@
+package graphql
+
+import (
+	"go_template_project/internal/config"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/products"
+	"log"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	config config.Config,
+	repo *dbRepo.Repository,
+) {
+	handler, err := NewGraphQLHandler(
+		command.New(repo),
+		config.GraphQL.MaxDepth,
+		config.GraphQL.MaxComplexity,
+		"/graphql",
+	)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	// GraphQL queries and mutations
+	mux.Handle("POST /graphql", handler)
+	mux.Handle("GET /graphql", handler)
+
+	// GraphiQL playground (if enabled in config)
+	if config.Server.GraphiQL {
+		mux.Handle("GET /graphiql", NewPlaygroundHandler("/graphql"))
+	}
+}
@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	graphqlGo "github.com/graphql-go/graphql"
+)
+
+var (
+	productType = graphqlGo.NewObject(graphqlGo.ObjectConfig{
+		Name: "Product",
+		Fields: graphqlGo.Fields{
+			"id":          &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.ID), Resolve: resolveProductID},
+			"name":        &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.String)},
+			"title":       &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.String)},
+			"createdAt":   &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.DateTime)},
+			"updatedAt":   &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.DateTime)},
+			"deletedAt":   &graphqlGo.Field{Type: graphqlGo.DateTime},
+			"status":      &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.String)},
+			"publishAt":   &graphqlGo.Field{Type: graphqlGo.DateTime},
+			"unpublishAt": &graphqlGo.Field{Type: graphqlGo.DateTime},
+		},
+	})
+
+	pageInfoType = graphqlGo.NewObject(graphqlGo.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphqlGo.Fields{
+			"hasNextPage":     &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.Boolean)},
+			"hasPreviousPage": &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.Boolean)},
+			"startCursor":     &graphqlGo.Field{Type: graphqlGo.String},
+			"endCursor":       &graphqlGo.Field{Type: graphqlGo.String},
+		},
+	})
+
+	productEdgeType = graphqlGo.NewObject(graphqlGo.ObjectConfig{
+		Name: "ProductEdge",
+		Fields: graphqlGo.Fields{
+			"cursor": &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.String)},
+			"node":   &graphqlGo.Field{Type: graphqlGo.NewNonNull(productType)},
+		},
+	})
+
+	productConnectionType = graphqlGo.NewObject(graphqlGo.ObjectConfig{
+		Name: "ProductConnection",
+		Fields: graphqlGo.Fields{
+			"edges":    &graphqlGo.Field{Type: graphqlGo.NewNonNull(graphqlGo.NewList(graphqlGo.NewNonNull(productEdgeType)))},
+			"pageInfo": &graphqlGo.Field{Type: graphqlGo.NewNonNull(pageInfoType)},
+		},
+	})
+
+	productFilterInput = graphqlGo.NewInputObject(graphqlGo.InputObjectConfig{
+		Name: "ProductFilter",
+		Fields: graphqlGo.InputObjectConfigFieldMap{
+			"name":     &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.String},
+			"title":    &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.String},
+			"category": &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.ID},
+		},
+	})
+
+	createProductInput = graphqlGo.NewInputObject(graphqlGo.InputObjectConfig{
+		Name: "CreateProductInput",
+		Fields: graphqlGo.InputObjectConfigFieldMap{
+			"name":  &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.NewNonNull(graphqlGo.String)},
+			"title": &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.NewNonNull(graphqlGo.String)},
+		},
+	})
+
+	updateProductInput = graphqlGo.NewInputObject(graphqlGo.InputObjectConfig{
+		Name: "UpdateProductInput",
+		Fields: graphqlGo.InputObjectConfigFieldMap{
+			"name":  &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.String},
+			"title": &graphqlGo.InputObjectFieldConfig{Type: graphqlGo.String},
+		},
+	})
+)
+
+func NewSchema(command productsCommand) (graphqlGo.Schema, error) {
+	r := resolver{command: command}
+
+	query := graphqlGo.NewObject(graphqlGo.ObjectConfig{
+		Name: "Query",
+		Fields: graphqlGo.Fields{
+			"product": &graphqlGo.Field{
+				Type: productType,
+				Args: graphqlGo.FieldConfigArgument{
+					"id": &graphqlGo.ArgumentConfig{Type: graphqlGo.NewNonNull(graphqlGo.ID)},
+				},
+				Resolve: r.product,
+			},
+			"productsByIds": &graphqlGo.Field{
+				Type: graphqlGo.NewNonNull(graphqlGo.NewList(productType)),
+				Args: graphqlGo.FieldConfigArgument{
+					"ids": &graphqlGo.ArgumentConfig{
+						Type: graphqlGo.NewNonNull(graphqlGo.NewList(graphqlGo.NewNonNull(graphqlGo.ID))),
+					},
+				},
+				Resolve: r.productsByIDs,
+			},
+			"products": &graphqlGo.Field{
+				Type: graphqlGo.NewNonNull(productConnectionType),
+				Args: graphqlGo.FieldConfigArgument{
+					"first":  &graphqlGo.ArgumentConfig{Type: graphqlGo.Int},
+					"after":  &graphqlGo.ArgumentConfig{Type: graphqlGo.String},
+					"last":   &graphqlGo.ArgumentConfig{Type: graphqlGo.Int},
+					"before": &graphqlGo.ArgumentConfig{Type: graphqlGo.String},
+					"filter": &graphqlGo.ArgumentConfig{Type: productFilterInput},
+				},
+				Resolve: r.products,
+			},
+		},
+	})
+
+	mutation := graphqlGo.NewObject(graphqlGo.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphqlGo.Fields{
+			"createProduct": &graphqlGo.Field{
+				Type: graphqlGo.NewNonNull(productType),
+				Args: graphqlGo.FieldConfigArgument{
+					"input": &graphqlGo.ArgumentConfig{Type: graphqlGo.NewNonNull(createProductInput)},
+				},
+				Resolve: r.createProduct,
+			},
+			"bulkCreateProducts": &graphqlGo.Field{
+				Type: graphqlGo.NewNonNull(graphqlGo.NewList(graphqlGo.NewNonNull(productType))),
+				Args: graphqlGo.FieldConfigArgument{
+					"input": &graphqlGo.ArgumentConfig{
+						Type: graphqlGo.NewNonNull(graphqlGo.NewList(graphqlGo.NewNonNull(createProductInput))),
+					},
+				},
+				Resolve: r.bulkCreateProducts,
+			},
+			"updateProduct": &graphqlGo.Field{
+				Type: graphqlGo.NewNonNull(productType),
+				Args: graphqlGo.FieldConfigArgument{
+					"id":    &graphqlGo.ArgumentConfig{Type: graphqlGo.NewNonNull(graphqlGo.ID)},
+					"input": &graphqlGo.ArgumentConfig{Type: graphqlGo.NewNonNull(updateProductInput)},
+				},
+				Resolve: r.updateProduct,
+			},
+			"deleteProduct": &graphqlGo.Field{
+				Type: graphqlGo.NewNonNull(graphqlGo.ID),
+				Args: graphqlGo.FieldConfigArgument{
+					"id": &graphqlGo.ArgumentConfig{Type: graphqlGo.NewNonNull(graphqlGo.ID)},
+				},
+				Resolve: r.deleteProduct,
+			},
+		},
+	})
+
+	return graphqlGo.NewSchema(graphqlGo.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
@@ -0,0 +1,174 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	adjustStockCommand interface {
+		AdjustStock(ctx context.Context, data inventoryDomain.AdjustStockDTO) (*inventoryDomain.StockLevel, error)
+	}
+
+	AdjustStockHandler struct {
+		name               string
+		adjustStockCommand adjustStockCommand
+	}
+
+	adjustStockRequest struct {
+		body inventoryDomain.AdjustStockDTO
+	}
+)
+
+func NewStockAdjustHandler(command adjustStockCommand, name string) *AdjustStockHandler {
+	return &AdjustStockHandler{
+		name:               name,
+		adjustStockCommand: command,
+	}
+}
+
+// @Summary		Adjust product stock
+// @Description	Add or remove units on hand with a reason code and return the resulting stock level. Units can not drop below zero or below the reserved units.
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string							true	"Product ID"
+// @Param			body	body		inventoryDomain.AdjustStockDTO	true	"Adjustment"
+// @Success		201		{object}	inventoryDomain.StockLevel		"Stock level"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		404		{string}	string							"Not Found"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		409		{string}	string							"Insufficient stock"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/{id}/stock/adjustments [post]
+func (h *AdjustStockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *adjustStockRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.adjustStockCommand.AdjustStock(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, inventoryDomain.ErrWarehouseNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, inventoryDomain.ErrInsufficientStock):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *AdjustStockHandler) getRequestData(r *http.Request) (requestData *adjustStockRequest, err error) {
+	requestData = &adjustStockRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &inventoryDomain.AdjustStockDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *AdjustStockHandler) validateRequestData(requestData *adjustStockRequest) error {
+	return validator.New().Struct(requestData.body)
+}
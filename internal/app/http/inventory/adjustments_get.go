@@ -0,0 +1,162 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strconv"
+)
+
+type (
+	getAdjustmentsCommand interface {
+		GetStockAdjustments(ctx context.Context, data inventoryDomain.GetStockAdjustmentsDTO) ([]inventoryDomain.Adjustment, error)
+	}
+
+	GetAdjustmentsHandler struct {
+		name                  string
+		getAdjustmentsCommand getAdjustmentsCommand
+	}
+
+	getAdjustmentsRequest struct {
+		params inventoryDomain.GetStockAdjustmentsDTO
+	}
+)
+
+func NewStockAdjustmentsGetHandler(command getAdjustmentsCommand, name string) *GetAdjustmentsHandler {
+	return &GetAdjustmentsHandler{
+		name:                  name,
+		getAdjustmentsCommand: command,
+	}
+}
+
+// @Summary		Get product stock adjustments
+// @Description	Get the stock ledger of a product, newest first
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id				path		string						true	"Product ID"
+// @Param			warehouse_id	query		string						false	"Warehouse ID"
+// @Param			limit			query		int							false	"List limit"	default(50)	max(50)
+// @Param			offset			query		int							false	"List offset"	default(0)
+// @Success		200				{array}		inventoryDomain.Adjustment	"Adjustments"
+// @Failure		400				{string}	string						"Bad Request"
+// @Failure		404				{string}	string						"Not Found"
+// @Failure		406				{string}	string						"Not Acceptable"
+// @Failure		500				{string}	string						"Internal Server Error"
+// @Router			/api/products/{id}/stock/adjustments [get]
+func (h *GetAdjustmentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getAdjustmentsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getAdjustmentsCommand.GetStockAdjustments(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetAdjustmentsHandler) getRequestData(r *http.Request) (requestData *getAdjustmentsRequest, err error) {
+	requestData = &getAdjustmentsRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+
+	if warehouse := r.FormValue("warehouse_id"); warehouse != "" {
+		var warehouseID uuid.UUID
+		if warehouseID, err = uuid.Parse(warehouse); err != nil {
+			return
+		}
+		requestData.params.WarehouseID = &warehouseID
+	}
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit == 0 || limit > 50 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	offset, err := strconv.Atoi(r.FormValue("offset"))
+	if err != nil {
+		offset = 0
+		err = nil
+	}
+	requestData.params.Offset = int64(offset)
+
+	return
+}
+
+func (h *GetAdjustmentsHandler) validateRequestData(requestData *getAdjustmentsRequest) error {
+	return validator.New().Struct(requestData.params)
+}
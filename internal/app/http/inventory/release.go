@@ -0,0 +1,142 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"net/http"
+)
+
+type (
+	releaseCommand interface {
+		ReleaseReservation(ctx context.Context, data inventoryDomain.ReleaseReservationDTO) (*inventoryDomain.Reservation, error)
+	}
+
+	ReleaseHandler struct {
+		name           string
+		releaseCommand releaseCommand
+	}
+
+	releaseRequest struct {
+		params inventoryDomain.ReleaseReservationDTO
+	}
+)
+
+func NewReservationReleaseHandler(command releaseCommand, name string) *ReleaseHandler {
+	return &ReleaseHandler{
+		name:           name,
+		releaseCommand: command,
+	}
+}
+
+// @Summary		Release reservation
+// @Description	Return the reserved units to the available stock
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Reservation ID"
+// @Success		200	{object}	inventoryDomain.Reservation	"Reservation"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		409	{string}	string						"Reservation no longer active"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/reservations/{id}/release [post]
+func (h *ReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *releaseRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.releaseCommand.ReleaseReservation(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, inventoryDomain.ErrReservationNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, inventoryDomain.ErrReservationNotActive):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *ReleaseHandler) getRequestData(r *http.Request) (requestData *releaseRequest, err error) {
+	requestData = &releaseRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ID = id
+
+	return
+}
+
+func (h *ReleaseHandler) validateRequestData(requestData *releaseRequest) error {
+	return validator.New().Struct(requestData.params)
+}
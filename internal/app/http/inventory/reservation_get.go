@@ -0,0 +1,133 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"net/http"
+)
+
+type (
+	getReservationCommand interface {
+		GetReservation(ctx context.Context, data inventoryDomain.GetReservationDTO) (*inventoryDomain.Reservation, error)
+	}
+
+	GetReservationHandler struct {
+		name                  string
+		getReservationCommand getReservationCommand
+	}
+
+	getReservationRequest struct {
+		params inventoryDomain.GetReservationDTO
+	}
+)
+
+func NewReservationGetHandler(command getReservationCommand, name string) *GetReservationHandler {
+	return &GetReservationHandler{
+		name:                  name,
+		getReservationCommand: command,
+	}
+}
+
+// @Summary		Get reservation
+// @Description	Get a stock reservation by id
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Reservation ID"
+// @Success		200	{object}	inventoryDomain.Reservation	"Reservation"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/reservations/{id} [get]
+func (h *GetReservationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getReservationRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getReservationCommand.GetReservation(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, inventoryDomain.ErrReservationNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetReservationHandler) getRequestData(r *http.Request) (requestData *getReservationRequest, err error) {
+	requestData = &getReservationRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ID = id
+
+	return
+}
+
+func (h *GetReservationHandler) validateRequestData(requestData *getReservationRequest) error {
+	return validator.New().Struct(requestData.params)
+}
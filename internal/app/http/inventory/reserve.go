@@ -0,0 +1,174 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	reserveCommand interface {
+		ReserveStock(ctx context.Context, data inventoryDomain.ReserveStockDTO) (*inventoryDomain.Reservation, error)
+	}
+
+	ReserveHandler struct {
+		name           string
+		reserveCommand reserveCommand
+	}
+
+	reserveRequest struct {
+		body inventoryDomain.ReserveStockDTO
+	}
+)
+
+func NewReserveHandler(command reserveCommand, name string) *ReserveHandler {
+	return &ReserveHandler{
+		name:           name,
+		reserveCommand: command,
+	}
+}
+
+// @Summary		Reserve product stock
+// @Description	Hold units of a product in a warehouse until the reservation is committed, released or expires. expires_in is in seconds, omitted uses the configured default.
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string							true	"Product ID"
+// @Param			body	body		inventoryDomain.ReserveStockDTO	true	"Reservation"
+// @Success		201		{object}	inventoryDomain.Reservation		"Created reservation"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		404		{string}	string							"Not Found"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		409		{string}	string							"Insufficient stock"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/{id}/reservations [post]
+func (h *ReserveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *reserveRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.reserveCommand.ReserveStock(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, inventoryDomain.ErrWarehouseNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, inventoryDomain.ErrInsufficientStock):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *ReserveHandler) getRequestData(r *http.Request) (requestData *reserveRequest, err error) {
+	requestData = &reserveRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &inventoryDomain.ReserveStockDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *ReserveHandler) validateRequestData(requestData *reserveRequest) error {
+	return validator.New().Struct(requestData.body)
+}
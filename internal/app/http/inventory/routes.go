@@ -0,0 +1,120 @@
+package inventory
+
+import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	"go_template_project/internal/config"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/inventory"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	config config.Config,
+	repo *dbRepo.Repository,
+) {
+	// Get warehouses
+	mux.Handle(
+		"GET /api/warehouses",
+		middlewaresHttp.Negotiate(
+			NewWarehousesGetHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"GET /api/warehouses",
+			),
+		),
+	)
+
+	// Create warehouse
+	mux.Handle(
+		"POST /api/warehouses",
+		middlewaresHttp.Negotiate(
+			NewWarehouseCreateHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"POST /api/warehouses",
+			),
+		),
+	)
+
+	// Get product stock
+	mux.Handle(
+		"GET /api/products/{id}/stock",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductStockGetHandler(
+					command.New(repo, config.Inventory.ReservationTTL),
+					"GET /api/products/{id}/stock",
+				),
+			),
+		),
+	)
+
+	// Get product stock adjustments
+	mux.Handle(
+		"GET /api/products/{id}/stock/adjustments",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewStockAdjustmentsGetHandler(
+					command.New(repo, config.Inventory.ReservationTTL),
+					"GET /api/products/{id}/stock/adjustments",
+				),
+			),
+		),
+	)
+
+	// Adjust product stock
+	mux.Handle(
+		"POST /api/products/{id}/stock/adjustments",
+		middlewaresHttp.Negotiate(
+			NewStockAdjustHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"POST /api/products/{id}/stock/adjustments",
+			),
+		),
+	)
+
+	// Reserve product stock
+	mux.Handle(
+		"POST /api/products/{id}/reservations",
+		middlewaresHttp.Negotiate(
+			NewReserveHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"POST /api/products/{id}/reservations",
+			),
+		),
+	)
+
+	// Get reservation
+	mux.Handle(
+		"GET /api/reservations/{id}",
+		middlewaresHttp.Negotiate(
+			NewReservationGetHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"GET /api/reservations/{id}",
+			),
+		),
+	)
+
+	// Release reservation
+	mux.Handle(
+		"POST /api/reservations/{id}/release",
+		middlewaresHttp.Negotiate(
+			NewReservationReleaseHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"POST /api/reservations/{id}/release",
+			),
+		),
+	)
+
+	// Commit reservation
+	mux.Handle(
+		"POST /api/reservations/{id}/commit",
+		middlewaresHttp.Negotiate(
+			NewReservationCommitHandler(
+				command.New(repo, config.Inventory.ReservationTTL),
+				"POST /api/reservations/{id}/commit",
+			),
+		),
+	)
+}
@@ -0,0 +1,134 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+)
+
+type (
+	getStockCommand interface {
+		GetProductStock(ctx context.Context, data inventoryDomain.GetProductStockDTO) ([]inventoryDomain.StockLevel, error)
+	}
+
+	GetStockHandler struct {
+		name            string
+		getStockCommand getStockCommand
+	}
+
+	getStockRequest struct {
+		params inventoryDomain.GetProductStockDTO
+	}
+)
+
+func NewProductStockGetHandler(command getStockCommand, name string) *GetStockHandler {
+	return &GetStockHandler{
+		name:            name,
+		getStockCommand: command,
+	}
+}
+
+// @Summary		Get product stock
+// @Description	Get stock levels of a product per warehouse
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Product ID"
+// @Success		200	{array}		inventoryDomain.StockLevel	"Stock levels"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/products/{id}/stock [get]
+func (h *GetStockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getStockRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getStockCommand.GetProductStock(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetStockHandler) getRequestData(r *http.Request) (requestData *getStockRequest, err error) {
+	requestData = &getStockRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+
+	return
+}
+
+func (h *GetStockHandler) validateRequestData(requestData *getStockRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,149 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	createWarehouseCommand interface {
+		CreateWarehouse(ctx context.Context, data inventoryDomain.CreateWarehouseDTO) (*inventoryDomain.Warehouse, error)
+	}
+
+	CreateWarehouseHandler struct {
+		name                   string
+		createWarehouseCommand createWarehouseCommand
+	}
+
+	createWarehouseRequest struct {
+		body inventoryDomain.CreateWarehouseDTO
+	}
+)
+
+func NewWarehouseCreateHandler(command createWarehouseCommand, name string) *CreateWarehouseHandler {
+	return &CreateWarehouseHandler{
+		name:                   name,
+		createWarehouseCommand: command,
+	}
+}
+
+// @Summary		Create warehouse
+// @Description	Create a warehouse, codes are unique
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			body	body		inventoryDomain.CreateWarehouseDTO	true	"Warehouse"
+// @Success		201		{object}	inventoryDomain.Warehouse			"Created warehouse"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		409		{string}	string								"Code already taken"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/warehouses [post]
+func (h *CreateWarehouseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *createWarehouseRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.createWarehouseCommand.CreateWarehouse(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, inventoryDomain.ErrWarehouseCodeTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *CreateWarehouseHandler) getRequestData(r *http.Request) (requestData *createWarehouseRequest, err error) {
+	requestData = &createWarehouseRequest{}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &inventoryDomain.CreateWarehouseDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+
+	return
+}
+
+func (h *CreateWarehouseHandler) validateRequestData(requestData *createWarehouseRequest) error {
+	return validator.New().Struct(requestData.body)
+}
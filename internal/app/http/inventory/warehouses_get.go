@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"net/http"
+)
+
+type (
+	getWarehousesCommand interface {
+		GetWarehouses(ctx context.Context, data inventoryDomain.GetWarehousesDTO) ([]inventoryDomain.Warehouse, error)
+	}
+
+	GetWarehousesHandler struct {
+		name                 string
+		getWarehousesCommand getWarehousesCommand
+	}
+
+	getWarehousesRequest struct {
+		params inventoryDomain.GetWarehousesDTO
+	}
+)
+
+func NewWarehousesGetHandler(command getWarehousesCommand, name string) *GetWarehousesHandler {
+	return &GetWarehousesHandler{
+		name:                 name,
+		getWarehousesCommand: command,
+	}
+}
+
+// @Summary		Get warehouses
+// @Description	Get all warehouses ordered by code
+// @Tags			Inventory
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Success		200	{array}		inventoryDomain.Warehouse	"Warehouses"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/warehouses [get]
+func (h *GetWarehousesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getWarehousesRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getWarehousesCommand.GetWarehouses(ctx, requestData.params)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetWarehousesHandler) getRequestData(r *http.Request) (requestData *getWarehousesRequest, err error) {
+	requestData = &getWarehousesRequest{}
+
+	return
+}
+
+func (h *GetWarehousesHandler) validateRequestData(requestData *getWarehousesRequest) error {
+	return validator.New().Struct(requestData.params)
+}
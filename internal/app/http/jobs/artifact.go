@@ -0,0 +1,134 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+type (
+	artifactCommand interface {
+		GetJobArtifact(ctx context.Context, data jobsDomain.GetJobDTO) (*jobsDomain.ArtifactFile, error)
+	}
+
+	ArtifactHandler struct {
+		name            string
+		artifactCommand artifactCommand
+	}
+
+	artifactRequest struct {
+		params jobsDomain.GetJobDTO
+	}
+)
+
+func NewJobArtifactHandler(command artifactCommand, name string) *ArtifactHandler {
+	return &ArtifactHandler{
+		name:            name,
+		artifactCommand: command,
+	}
+}
+
+// @Summary		Download job artifact
+// @Description	Download the file produced by a finished job, e.g. an export
+// @Tags			Jobs
+// @Produce		octet-stream
+// @Param			id	path		string	true	"Job ID"
+// @Success		200	{file}		file	"Artifact"
+// @Failure		400	{string}	string	"Bad Request"
+// @Failure		404	{string}	string	"Not Found"
+// @Failure		500	{string}	string	"Internal Server Error"
+// @Router			/api/jobs/{id}/artifact [get]
+func (h *ArtifactHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *artifactRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	artifact, err := h.artifactCommand.GetJobArtifact(ctx, requestData.params)
+	if err != nil {
+		if errors.Is(err, jobsDomain.ErrJobArtifactNotFound) {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+			return
+		}
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(artifact.Body)
+
+	w.Header().Set("Content-Type", artifact.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, artifact.Name))
+
+	// ServeContent sets the length and answers range requests
+	if body, ok := artifact.Body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", time.Time{}, body)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err = io.Copy(w, artifact.Body); err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *ArtifactHandler) getRequestData(r *http.Request) (requestData *artifactRequest, err error) {
+	requestData = &artifactRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ID = id
+	return
+}
+
+func (h *ArtifactHandler) validateRequestData(requestData *artifactRequest) error {
+	return validator.New().Struct(requestData.params)
+}
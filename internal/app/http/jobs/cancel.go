@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"net/http"
+)
+
+type (
+	cancelCommand interface {
+		CancelJob(ctx context.Context, data jobsDomain.CancelJobDTO) (*jobsDomain.Job, error)
+	}
+
+	CancelHandler struct {
+		name          string
+		cancelCommand cancelCommand
+	}
+
+	cancelRequest struct {
+		params jobsDomain.CancelJobDTO
+	}
+)
+
+func NewJobCancelHandler(command cancelCommand, name string) *CancelHandler {
+	return &CancelHandler{
+		name:          name,
+		cancelCommand: command,
+	}
+}
+
+// @Summary		Cancel job
+// @Description	Cancel a queued job right away or ask a running job to stop
+// @Tags			Jobs
+// @Produce		json
+// @Param			id	path		string			true	"Job ID"
+// @Success		202	{object}	jobsDomain.Job	"Job"
+// @Failure		400	{string}	string			"Bad Request"
+// @Failure		404	{string}	string			"Not Found"
+// @Failure		409	{string}	string			"Job already finished"
+// @Failure		500	{string}	string			"Internal Server Error"
+// @Router			/api/jobs/{id}/cancel [post]
+func (h *CancelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *cancelRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.cancelCommand.CancelJob(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, jobsDomain.ErrJobNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, jobsDomain.ErrJobFinished):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("json marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusAccepted,
+		&responseBody,
+	)
+}
+
+func (h *CancelHandler) getRequestData(r *http.Request) (requestData *cancelRequest, err error) {
+	requestData = &cancelRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ID = id
+	return
+}
+
+func (h *CancelHandler) validateRequestData(requestData *cancelRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/jobs"
+	"go_template_project/internal/storage"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	repo *dbRepo.Repository,
+	store storage.Storage,
+) {
+	// Get job
+	mux.Handle(
+		"GET /api/jobs/{id}",
+		NewJobGetHandler(
+			command.New(repo, store),
+			"GET /api/jobs/{id}",
+		),
+	)
+
+	// Cancel job
+	mux.Handle(
+		"POST /api/jobs/{id}/cancel",
+		NewJobCancelHandler(
+			command.New(repo, store),
+			"POST /api/jobs/{id}/cancel",
+		),
+	)
+
+	// Download job artifact
+	mux.Handle(
+		"GET /api/jobs/{id}/artifact",
+		NewJobArtifactHandler(
+			command.New(repo, store),
+			"GET /api/jobs/{id}/artifact",
+		),
+	)
+}
@@ -0,0 +1,140 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	mediaDomain "go_template_project/internal/domain/media"
+	"net/http"
+)
+
+type (
+	deleteCommand interface {
+		DeleteMedia(ctx context.Context, data mediaDomain.DeleteMediaDTO) (*mediaDomain.Media, error)
+	}
+
+	DeleteHandler struct {
+		name          string
+		deleteCommand deleteCommand
+	}
+
+	deleteRequest struct {
+		params mediaDomain.DeleteMediaDTO
+	}
+)
+
+func NewMediaDeleteHandler(command deleteCommand, name string) *DeleteHandler {
+	return &DeleteHandler{
+		name:          name,
+		deleteCommand: command,
+	}
+}
+
+// @Summary		Delete product media
+// @Description	Remove a media from the gallery, its files are deleted in the background
+// @Tags			Media
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id			path		string				true	"Product ID"
+// @Param			media_id	path		string				true	"Media ID"
+// @Success		200			{object}	mediaDomain.Media	"Deleted media"
+// @Failure		400			{string}	string				"Bad Request"
+// @Failure		404			{string}	string				"Not Found"
+// @Failure		406			{string}	string				"Not Acceptable"
+// @Failure		500			{string}	string				"Internal Server Error"
+// @Router			/api/products/{id}/media/{media_id} [delete]
+func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *deleteRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.deleteCommand.DeleteMedia(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, mediaDomain.ErrMediaNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *DeleteHandler) getRequestData(r *http.Request) (requestData *deleteRequest, err error) {
+	requestData = &deleteRequest{}
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	id, err := uuid.Parse(r.PathValue("media_id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = productID
+	requestData.params.ID = id
+
+	return
+}
+
+func (h *DeleteHandler) validateRequestData(requestData *deleteRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,148 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	mediaDomain "go_template_project/internal/domain/media"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	fileCommand interface {
+		GetMediaFile(ctx context.Context, data mediaDomain.GetMediaFileDTO) (*mediaDomain.File, error)
+	}
+
+	FileHandler struct {
+		name        string
+		fileCommand fileCommand
+	}
+
+	fileRequest struct {
+		params mediaDomain.GetMediaFileDTO
+	}
+)
+
+func NewMediaFileHandler(command fileCommand, name string) *FileHandler {
+	return &FileHandler{
+		name:        name,
+		fileCommand: command,
+	}
+}
+
+// @Summary		Get media file
+// @Description	Download the original of a media or one of its thumbnails by size. Files never change under the same URL, so they are served with an ETag and long lived caching headers.
+// @Tags			Media
+// @Produce		octet-stream
+// @Param			id				path		string	true	"Media ID"
+// @Param			name			path		string	true	"original or a thumbnail size"
+// @Param			If-None-Match	header		string	false	"ETag of a cached copy"
+// @Success		200				{file}		file	"File"
+// @Success		304				{string}	string	"Not Modified"
+// @Failure		400				{string}	string	"Bad Request"
+// @Failure		404				{string}	string	"Not Found"
+// @Failure		500				{string}	string	"Internal Server Error"
+// @Router			/api/media/{id}/{name} [get]
+func (h *FileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *fileRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	file, err := h.fileCommand.GetMediaFile(ctx, requestData.params)
+	if err != nil {
+		if errors.Is(err, mediaDomain.ErrMediaNotFound) {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+			return
+		}
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(file.Body)
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%s-%s"`, requestData.params.ID, requestData.params.Name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent answers conditional and range requests
+	if body, ok := file.Body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", time.Time{}, body)
+		return
+	}
+
+	if r.Header.Get("If-None-Match") == w.Header().Get("ETag") {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(file.FileSize, 10))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err = io.Copy(w, file.Body); err != nil {
+		log.Println(err)
+	}
+}
+
+func (h *FileHandler) getRequestData(r *http.Request) (requestData *fileRequest, err error) {
+	requestData = &fileRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ID = id
+	requestData.params.Name = r.PathValue("name")
+	return
+}
+
+func (h *FileHandler) validateRequestData(requestData *fileRequest) error {
+	return validator.New().Struct(requestData.params)
+}
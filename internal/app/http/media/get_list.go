@@ -0,0 +1,135 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+)
+
+type (
+	getListCommand interface {
+		GetProductMedia(ctx context.Context, data mediaDomain.GetProductMediaDTO) ([]mediaDomain.Media, error)
+	}
+
+	GetListHandler struct {
+		name           string
+		getListCommand getListCommand
+	}
+
+	getListRequest struct {
+		params mediaDomain.GetProductMediaDTO
+	}
+)
+
+func NewProductMediaGetHandler(command getListCommand, name string) *GetListHandler {
+	return &GetListHandler{
+		name:           name,
+		getListCommand: command,
+	}
+}
+
+// @Summary		Get product media
+// @Description	Get the gallery of a product in display order. Thumbnails are listed once generated, until then status is processing.
+// @Tags			Media
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string				true	"Product ID"
+// @Success		200	{array}		mediaDomain.Media	"Media"
+// @Failure		400	{string}	string				"Bad Request"
+// @Failure		404	{string}	string				"Not Found"
+// @Failure		406	{string}	string				"Not Acceptable"
+// @Failure		500	{string}	string				"Internal Server Error"
+// @Router			/api/products/{id}/media [get]
+func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getListRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getListCommand.GetProductMedia(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetListHandler) getRequestData(r *http.Request) (requestData *getListRequest, err error) {
+	requestData = &getListRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = id
+
+	return
+}
+
+func (h *GetListHandler) validateRequestData(requestData *getListRequest) error {
+	return validator.New().Struct(requestData.params)
+}
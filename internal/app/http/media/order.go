@@ -0,0 +1,165 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	orderCommand interface {
+		SetMediaOrder(ctx context.Context, data mediaDomain.SetMediaOrderDTO) ([]mediaDomain.Media, error)
+	}
+
+	OrderHandler struct {
+		name         string
+		orderCommand orderCommand
+	}
+
+	orderRequest struct {
+		body mediaDomain.SetMediaOrderDTO
+	}
+)
+
+func NewMediaOrderHandler(command orderCommand, name string) *OrderHandler {
+	return &OrderHandler{
+		name:         name,
+		orderCommand: command,
+	}
+}
+
+// @Summary		Order product media
+// @Description	Set the display order of the gallery, ids has to list every media of the product once
+// @Tags			Media
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string							true	"Product ID"
+// @Param			body	body		mediaDomain.SetMediaOrderDTO	true	"Media IDs in display order"
+// @Success		200		{array}		mediaDomain.Media				"Media"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		404		{string}	string							"Not Found"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/{id}/media/order [put]
+func (h *OrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *orderRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.orderCommand.SetMediaOrder(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, mediaDomain.ErrInvalidMediaOrder):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *OrderHandler) getRequestData(r *http.Request) (requestData *orderRequest, err error) {
+	requestData = &orderRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &mediaDomain.SetMediaOrderDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *OrderHandler) validateRequestData(requestData *orderRequest) error {
+	return validator.New().Struct(requestData.body)
+}
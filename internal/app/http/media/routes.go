@@ -0,0 +1,76 @@
+package media
+
+import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	"go_template_project/internal/config"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/media"
+	"go_template_project/internal/storage"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	config config.Config,
+	repo *dbRepo.Repository,
+	store storage.Storage,
+) {
+	// Get product media
+	mux.Handle(
+		"GET /api/products/{id}/media",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductMediaGetHandler(
+					command.New(repo, store, config.Media),
+					"GET /api/products/{id}/media",
+				),
+			),
+		),
+	)
+
+	// Upload product media
+	mux.Handle(
+		"POST /api/products/{id}/media",
+		middlewaresHttp.Negotiate(
+			NewMediaUploadHandler(
+				command.New(repo, store, config.Media),
+				"POST /api/products/{id}/media",
+			),
+		),
+	)
+
+	// Order product media
+	mux.Handle(
+		"PUT /api/products/{id}/media/order",
+		middlewaresHttp.Negotiate(
+			NewMediaOrderHandler(
+				command.New(repo, store, config.Media),
+				"PUT /api/products/{id}/media/order",
+			),
+		),
+	)
+
+	// Delete product media
+	mux.Handle(
+		"DELETE /api/products/{id}/media/{media_id}",
+		middlewaresHttp.Negotiate(
+			NewMediaDeleteHandler(
+				command.New(repo, store, config.Media),
+				"DELETE /api/products/{id}/media/{media_id}",
+			),
+		),
+	)
+
+	// Get media file (served as stored, not negotiated)
+	mux.Handle(
+		"GET /api/media/{id}/{name}",
+		middlewaresHttp.CacheControl(
+			config.CacheControl.Media,
+			NewMediaFileHandler(
+				command.New(repo, store, config.Media),
+				"GET /api/media/{id}/{name}",
+			),
+		),
+	)
+}
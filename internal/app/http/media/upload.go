@@ -0,0 +1,184 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"net/http"
+)
+
+type (
+	uploadCommand interface {
+		UploadMedia(ctx context.Context, data mediaDomain.UploadMediaDTO) (*mediaDomain.Media, error)
+	}
+
+	UploadHandler struct {
+		name          string
+		uploadCommand uploadCommand
+	}
+
+	uploadRequest struct {
+		params mediaDomain.UploadMediaDTO
+	}
+)
+
+var errMissingFile = errors.New("multipart form has no file field")
+
+func NewMediaUploadHandler(command uploadCommand, name string) *UploadHandler {
+	return &UploadHandler{
+		name:          name,
+		uploadCommand: command,
+	}
+}
+
+// @Summary		Upload product media
+// @Description	Add an image to the end of the gallery of a product as the file field of a multipart form. The type is sniffed from the data, jpeg, png, gif and webp are accepted. Thumbnails are generated in the background.
+// @Tags			Media
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			multipart/form-data
+// @Param			id		path		string				true	"Product ID"
+// @Param			file	formData	file				true	"Image"
+// @Success		201		{object}	mediaDomain.Media	"Uploaded media"
+// @Failure		400		{string}	string				"Bad Request"
+// @Failure		404		{string}	string				"Not Found"
+// @Failure		406		{string}	string				"Not Acceptable"
+// @Failure		413		{string}	string				"File too large"
+// @Failure		415		{string}	string				"Unsupported media type"
+// @Failure		500		{string}	string				"Internal Server Error"
+// @Router			/api/products/{id}/media [post]
+func (h *UploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *uploadRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.uploadCommand.UploadMedia(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, mediaDomain.ErrInvalidImage):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, mediaDomain.ErrMediaTooLarge):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusRequestEntityTooLarge,
+				nil,
+			)
+		case errors.Is(err, mediaDomain.ErrUnsupportedMediaType):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusUnsupportedMediaType,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *UploadHandler) getRequestData(r *http.Request) (requestData *uploadRequest, err error) {
+	requestData = &uploadRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return
+	}
+	// the part is read by the command, other fields are skipped
+	for {
+		part, partErr := reader.NextPart()
+		if errors.Is(partErr, io.EOF) {
+			return nil, errMissingFile
+		}
+		if partErr != nil {
+			return nil, partErr
+		}
+		if part.FormName() == "file" {
+			requestData.params.Body = part
+			break
+		}
+	}
+
+	requestData.params.ProductID = id
+
+	return
+}
+
+func (h *UploadHandler) validateRequestData(requestData *uploadRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	httpResponses "go_template_project/internal/app/http/responses"
+	"net/http"
+	"strings"
+)
+
+// AdminPrefix is where the API is served again for back-office clients.
+const AdminPrefix = "/api/admin/"
+
+// Admin serves AdminPrefix<path> as /api/<path> from next with product reads
+// seeing every publication status, drafts included. Only requests with token
+// as their bearer token get through, others are unauthorized. Responses are
+// private, CacheControl leaves them alone.
+func Admin(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path, ok := strings.CutPrefix(req.URL.Path, AdminPrefix)
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Cache-Control", "private, no-cache")
+		if !adminAuthorized(req, token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		req = req.WithContext(httpResponses.WithAllStatuses(req.Context()))
+		u := *req.URL
+		u.Path, u.RawPath = "/api/"+path, ""
+		req.URL = &u
+		next.ServeHTTP(w, req)
+	})
+}
+
+// adminAuthorized compares the bearer token in constant time, an empty
+// token never matches.
+func adminAuthorized(req *http.Request, token string) bool {
+	scheme, credentials, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(credentials)), []byte(token)) == 1
+}
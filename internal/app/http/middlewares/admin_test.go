@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	httpResponses "go_template_project/internal/app/http/responses"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "no credentials", token: "secret", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", authorization: "Bearer guess", wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", token: "secret", authorization: "Basic secret", wantStatus: http.StatusUnauthorized},
+		{name: "empty token never matches", authorization: "Bearer ", wantStatus: http.StatusUnauthorized},
+		{name: "bearer token", token: "secret", authorization: "Bearer secret", wantStatus: http.StatusOK},
+		{name: "scheme is case insensitive", token: "secret", authorization: "bearer secret", wantStatus: http.StatusOK},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/products/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Published-Only", strconv.FormatBool(httpResponses.PublishedOnly(r.Context())))
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/products/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			Admin(tt.token, mux).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "private, no-cache" {
+				t.Errorf("got Cache-Control %q, want private", got)
+			}
+			if tt.wantStatus == http.StatusOK && rec.Header().Get("X-Published-Only") != "false" {
+				t.Errorf("admin read is limited to published products")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	httpResponses "go_template_project/internal/app/http/responses"
+	"net/http"
+)
+
+type cacheControlResponseWriter struct {
+	http.ResponseWriter
+	directives  string
+	wroteHeader bool
+}
+
+// CacheControl sets the Cache-Control header on successful and 304 responses
+// of next. Errors are never cached, neither are responses seeing
+// unpublished products.
+func CacheControl(directives string, next http.Handler) http.Handler {
+	if directives == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !httpResponses.PublishedOnly(req.Context()) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		next.ServeHTTP(&cacheControlResponseWriter{ResponseWriter: w, directives: directives}, req)
+	})
+}
+
+func (w *cacheControlResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code < http.StatusMultipleChoices || code == http.StatusNotModified {
+			w.Header().Set("Cache-Control", w.directives)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
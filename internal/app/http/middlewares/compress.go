@@ -0,0 +1,297 @@
+package middlewares
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingBrotli  = "br"
+	encodingZstd    = "zstd"
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+type (
+	compressor interface {
+		io.WriteCloser
+		Flush() error
+		Reset(w io.Writer)
+	}
+
+	compressResponseWriter struct {
+		http.ResponseWriter
+		encoding    string
+		minSize     int
+		statusCode  int
+		wroteHeader bool
+		buf         []byte
+		compressor  compressor
+		// decided is set once the response is known to be sent as is or
+		// compressed, buffering stops from then on.
+		decided bool
+		// ifNoneMatch is the header as the client sent it, before the
+		// encoded entity tags were stripped for the handler.
+		ifNoneMatch string
+	}
+)
+
+// Server preference when the client accepts several encodings equally.
+var encodingPreference = []string{encodingBrotli, encodingZstd, encodingGzip, encodingDeflate}
+
+var (
+	_ compressor = (*brotli.Writer)(nil)
+	_ compressor = (*zstd.Encoder)(nil)
+	_ compressor = (*gzip.Writer)(nil)
+	_ compressor = (*flate.Writer)(nil)
+)
+
+var compressorPools = map[string]*sync.Pool{
+	encodingBrotli: {New: func() any {
+		return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+	}},
+	encodingZstd: {New: func() any {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return w
+	}},
+	encodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+	encodingDeflate: {New: func() any {
+		w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+		return w
+	}},
+}
+
+// Compress encodes responses with the best encoding the client accepts.
+// Bodies shorter than minSize, already compressed content and responses
+// taking part in range requests are sent as is, byte ranges count the
+// identity body.
+// A compressed body is a representation of its own, its ETag gets the
+// encoding appended, and If-None-Match is stripped of the suffix so handlers
+// keep comparing their own tags.
+func Compress(minSize int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(req.Header.Get("Accept-Encoding"))
+		if encoding == "" || req.Method == http.MethodHead {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		cw := &compressResponseWriter{
+			ResponseWriter: w,
+			encoding:       encoding,
+			minSize:        minSize,
+			statusCode:     http.StatusOK,
+			ifNoneMatch:    req.Header.Get("If-None-Match"),
+		}
+		if cw.ifNoneMatch != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("If-None-Match", decodeETags(cw.ifNoneMatch, encoding))
+		}
+		defer cw.close()
+		next.ServeHTTP(cw, req)
+	})
+}
+
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.statusCode = code
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressResponseWriter) Write(b []byte) (int, error) {
+	cw.wroteHeader = true
+	if !cw.decided {
+		if !cw.compressible() {
+			cw.decide(false)
+		} else if len(cw.buf)+len(b) < cw.minSize {
+			cw.buf = append(cw.buf, b...)
+			return len(b), nil
+		} else {
+			cw.decide(true)
+		}
+	}
+	if cw.compressor != nil {
+		return cw.compressor.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush starts compressing right away, streamed responses should not wait
+// for the threshold.
+func (cw *compressResponseWriter) Flush() {
+	if !cw.decided {
+		cw.decide(cw.compressible())
+	}
+	if cw.compressor != nil {
+		_ = cw.compressor.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// decide writes the status line and whatever was buffered so far. A 304
+// answers for the representation the client holds, it keeps the encoded
+// ETag when that is the one the client sent.
+func (cw *compressResponseWriter) decide(compress bool) {
+	cw.decided = true
+	if etag := cw.Header().Get("ETag"); etag != "" {
+		encoded := encodeETag(etag, cw.encoding)
+		if compress || (cw.statusCode == http.StatusNotModified && containsETag(cw.ifNoneMatch, encoded)) {
+			cw.Header().Set("ETag", encoded)
+		}
+	}
+	if compress {
+		cw.Header().Set("Content-Encoding", cw.encoding)
+		cw.Header().Del("Content-Length")
+		cw.compressor = compressorPools[cw.encoding].Get().(compressor)
+		cw.compressor.Reset(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+	if len(cw.buf) > 0 {
+		if cw.compressor != nil {
+			_, _ = cw.compressor.Write(cw.buf)
+		} else {
+			_, _ = cw.ResponseWriter.Write(cw.buf)
+		}
+		cw.buf = nil
+	}
+}
+
+func (cw *compressResponseWriter) close() {
+	if !cw.decided {
+		cw.decide(false)
+	}
+	if cw.compressor != nil {
+		_ = cw.compressor.Close()
+		compressorPools[cw.encoding].Put(cw.compressor)
+		cw.compressor = nil
+	}
+}
+
+// encodeETag appends the encoding inside the quotes, "abc" becomes
+// "abc-gzip" and W/"abc" becomes W/"abc-gzip".
+func encodeETag(etag, encoding string) string {
+	if !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// decodeETags strips the encoding from the tags of an If-None-Match list
+// carrying it.
+func decodeETags(list, encoding string) string {
+	suffix := "-" + encoding + `"`
+	tags := strings.Split(list, ",")
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if strings.HasSuffix(tag, suffix) {
+			tag = strings.TrimSuffix(tag, suffix) + `"`
+		}
+		tags[i] = tag
+	}
+	return strings.Join(tags, ", ")
+}
+
+func containsETag(list, etag string) bool {
+	for _, tag := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (cw *compressResponseWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	// ranges are served and resumed in identity bytes
+	if cw.statusCode == http.StatusPartialContent ||
+		header.Get("Content-Range") != "" ||
+		header.Get("Accept-Ranges") != "" {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "image/"),
+		strings.HasPrefix(contentType, "video/"),
+		strings.HasPrefix(contentType, "audio/"),
+		strings.HasPrefix(contentType, "application/zip"),
+		strings.HasPrefix(contentType, "application/gzip"),
+		strings.HasPrefix(contentType, "application/vnd.openxmlformats"):
+		return false
+	}
+	return true
+}
+
+// negotiateEncoding picks the supported encoding with the highest q-value,
+// ties are broken by encodingPreference.
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	weights := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, q := parseQuality(part)
+		if name == "*" {
+			wildcard = q
+			continue
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range encodingPreference {
+		q, ok := weights[encoding]
+		if !ok {
+			if wildcard < 0 {
+				continue
+			}
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// parseQuality splits a header list element like "gzip;q=0.8".
+func parseQuality(part string) (string, float64) {
+	name, params, _ := strings.Cut(part, ";")
+	name = strings.ToLower(strings.TrimSpace(name))
+	q := 1.0
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok && strings.EqualFold(key, "q") {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+	}
+	return name, q
+}
@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"bytes"
+	httpResponses "go_template_project/internal/app/http/responses"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{acceptEncoding: "", want: ""},
+		{acceptEncoding: "identity", want: ""},
+		{acceptEncoding: "gzip", want: encodingGzip},
+		{acceptEncoding: "gzip, deflate, br, zstd", want: encodingBrotli},
+		{acceptEncoding: "gzip;q=1, br;q=0.5", want: encodingGzip},
+		{acceptEncoding: "GZIP;Q=0.8, deflate;q=0.9", want: encodingDeflate},
+		{acceptEncoding: "br;q=0, gzip", want: encodingGzip},
+		{acceptEncoding: "*", want: encodingBrotli},
+		{acceptEncoding: "*;q=0.5, gzip;q=0.4", want: encodingBrotli},
+		{acceptEncoding: "*;q=0, zstd", want: encodingZstd},
+		{acceptEncoding: "gzip;q=0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			if got := negotiateEncoding(tt.acceptEncoding); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeETag(t *testing.T) {
+	tests := []struct {
+		etag string
+		want string
+	}{
+		{etag: `"abc"`, want: `"abc-gzip"`},
+		{etag: `W/"1-2"`, want: `W/"1-2-gzip"`},
+		{etag: `abc`, want: `abc`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.etag, func(t *testing.T) {
+			if got := encodeETag(tt.etag, encodingGzip); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeETags(t *testing.T) {
+	tests := []struct {
+		list string
+		want string
+	}{
+		{list: `"abc-gzip"`, want: `"abc"`},
+		{list: `W/"1-2-gzip", "abc"`, want: `W/"1-2", "abc"`},
+		{list: `"abc-br"`, want: `"abc-br"`},
+		{list: `*`, want: `*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.list, func(t *testing.T) {
+			if got := decodeETags(tt.list, encodingGzip); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat("id,name,title\n", 200)
+	tests := []struct {
+		name         string
+		target       string
+		header       http.Header
+		wantEncoding string
+		wantStatus   int
+		wantETag     string
+	}{
+		{
+			name:         "compressed",
+			target:       "/",
+			header:       http.Header{"Accept-Encoding": {"gzip"}},
+			wantEncoding: encodingGzip,
+			wantStatus:   http.StatusOK,
+			wantETag:     `"v1-gzip"`,
+		},
+		{
+			name:       "not accepted",
+			target:     "/",
+			header:     http.Header{},
+			wantStatus: http.StatusOK,
+			wantETag:   `"v1"`,
+		},
+		{
+			name:       "revalidated with the encoded tag",
+			target:     "/",
+			header:     http.Header{"Accept-Encoding": {"gzip"}, "If-None-Match": {`"v1-gzip"`}},
+			wantStatus: http.StatusNotModified,
+			wantETag:   `"v1-gzip"`,
+		},
+		{
+			name:       "ranges accepted",
+			target:     "/file",
+			header:     http.Header{"Accept-Encoding": {"gzip"}},
+			wantStatus: http.StatusOK,
+			wantETag:   `"v1"`,
+		},
+		{
+			name:       "range",
+			target:     "/file",
+			header:     http.Header{"Accept-Encoding": {"gzip"}, "Range": {"bytes=0-9"}},
+			wantStatus: http.StatusPartialContent,
+			wantETag:   `"v1"`,
+		},
+	}
+
+	handler := Compress(10, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("ETag", `"v1"`)
+		if r.URL.Path == "/file" {
+			http.ServeContent(w, r, "products.csv", time.Time{}, bytes.NewReader([]byte(body)))
+			return
+		}
+		if httpResponses.NotModified(r, `"v1"`, time.Time{}) {
+			httpResponses.WriteNotModified(w)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header = tt.header
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("got Content-Encoding %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("got ETag %s, want %s", got, tt.wantETag)
+			}
+		})
+	}
+}
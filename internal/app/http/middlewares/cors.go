@@ -2,14 +2,53 @@ package middlewares
 
 import (
 	"net/http"
+	"slices"
+	"sync/atomic"
 )
 
-func AllowCors(next http.Handler) http.Handler {
+// Cors answers cross-origin requests from the allowed origins. Origins can
+// be replaced while serving.
+type Cors struct {
+	origins atomic.Pointer[[]string]
+}
+
+func NewCors(origins []string) *Cors {
+	c := &Cors{}
+	c.SetOrigins(origins)
+	return c
+}
+
+// SetOrigins replaces the allowed origins, "*" allows any.
+func (c *Cors) SetOrigins(origins []string) {
+	origins = slices.Clone(origins)
+	c.origins.Store(&origins)
+}
+
+func (c *Cors) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		origin := req.Header.Get("Origin")
+		origins := *c.origins.Load()
+		w.Header().Add("Vary", "Origin")
+
+		switch {
+		case slices.Contains(origins, "*"):
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		case origin != "" && slices.Contains(origins, origin):
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		default:
+			next.ServeHTTP(w, req)
+			return
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, PATCH, DELETE")
-		//w.Header().Set("Access-Control-Allow-Headers", "X-Requested-With,content-type")
-		//w.Header().Set("Access-Control-Allow-Credentials", "true")
+
+		// Preflight
+		if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+			if headers := req.Header.Get("Access-Control-Request-Headers"); headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 		next.ServeHTTP(w, req)
 	})
 }
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	httpResponses "go_template_project/internal/app/http/responses"
+	"net/http"
+)
+
+// Localize picks the locales product names and titles are translated to
+// from the locale query parameter or the Accept-Language header, for
+// handlers reading them with responses.Locales. An invalid locale parameter
+// is answered with 400. With supported locales configured, others are
+// ignored and fall back to the untranslated values.
+func Localize(supported []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		locales, err := httpResponses.NegotiateLocales(
+			req.URL.Query().Get("locale"),
+			req.Header.Get("Accept-Language"),
+			supported,
+		)
+		if err != nil {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error() + "\n"))
+			return
+		}
+
+		next.ServeHTTP(w, req.WithContext(httpResponses.WithLocales(req.Context(), locales)))
+	})
+}
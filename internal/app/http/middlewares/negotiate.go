@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	httpResponses "go_template_project/internal/app/http/responses"
+	"net/http"
+	"strings"
+)
+
+// Negotiate picks the response media type from the Accept header for
+// handlers encoding their bodies with responses.Marshal, unsupported types
+// are answered with 406.
+func Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		mediaType := httpResponses.NegotiateMediaType(req.Header.Get("Accept"))
+		if mediaType == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusNotAcceptable)
+			_, _ = w.Write([]byte("supported media types: " + strings.Join(httpResponses.MediaTypes, ", ") + "\n"))
+			return
+		}
+
+		w.Header().Set("Content-Type", mediaType)
+		next.ServeHTTP(w, req.WithContext(httpResponses.WithMediaType(req.Context(), mediaType)))
+	})
+}
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const rateLimiterIdleTimeout = 10 * time.Minute
+
+type (
+	// RateLimiter limits requests per client IP with a token bucket. A zero
+	// rate disables it, limits can be changed while serving.
+	RateLimiter struct {
+		mu        sync.Mutex
+		limit     rate.Limit
+		burst     int
+		clients   map[string]*rateLimiterClient
+		lastSweep time.Time
+	}
+
+	rateLimiterClient struct {
+		limiter  *rate.Limiter
+		lastSeen time.Time
+	}
+)
+
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	l := &RateLimiter{clients: make(map[string]*rateLimiterClient)}
+	l.SetLimits(rps, burst)
+	return l
+}
+
+// SetLimits replaces the rate and burst, existing buckets are reset.
+func (l *RateLimiter) SetLimits(rps float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = rate.Limit(rps)
+	l.burst = burst
+	l.clients = make(map[string]*rateLimiterClient)
+}
+
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !l.allow(clientIP(req)) {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (l *RateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > rateLimiterIdleTimeout {
+		for key, c := range l.clients {
+			if now.Sub(c.lastSeen) > rateLimiterIdleTimeout {
+				delete(l.clients, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &rateLimiterClient{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+	return client.limiter.AllowN(now, 1)
+}
+
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	dbRepo "go_template_project/internal/repository"
+	"net/http"
+	"time"
+)
+
+const readPrimaryCookie = "read_primary"
+
+// ReadYourWrites pins reads to the primary database for window after a
+// client's last mutation, tracked with a short-lived cookie, so the client
+// does not read stale data from a lagging replica.
+func ReadYourWrites(window time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mutation := req.Method != http.MethodGet && req.Method != http.MethodHead && req.Method != http.MethodOptions
+
+		if mutation {
+			http.SetCookie(w, &http.Cookie{
+				Name:     readPrimaryCookie,
+				Value:    "1",
+				Path:     "/",
+				MaxAge:   max(1, int(window.Seconds())),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		if _, err := req.Cookie(readPrimaryCookie); mutation || err == nil {
+			req = req.WithContext(dbRepo.WithReadPrimary(req.Context()))
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
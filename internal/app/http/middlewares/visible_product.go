@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+	"net/http"
+)
+
+type productGetter interface {
+	GetProduct(ctx context.Context, data productsDomain.GetProductDTO) (*productsDomain.Product, error)
+}
+
+// VisibleProduct answers 404 for a sub-resource of the product in the id
+// path value when product reads of the request may not see the product,
+// see responses.PublishedOnly. A malformed id is left to next to report.
+func VisibleProduct(products productGetter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id, err := uuid.Parse(req.PathValue("id"))
+		if !httpResponses.PublishedOnly(ctx) || err != nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		_, err = products.GetProduct(ctx, productsDomain.GetProductDTO{
+			ID:            id,
+			Fields:        []string{"id"},
+			PublishedOnly: true,
+		})
+		if err != nil {
+			status := http.StatusNotFound
+			if !errors.Is(err, productsDomain.ErrProductNotFound) {
+				log.Println(err)
+				status = http.StatusInternalServerError
+			}
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,139 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	pricesDomain "go_template_project/internal/domain/prices"
+	"net/http"
+)
+
+type (
+	deleteCommand interface {
+		DeleteProductPrice(ctx context.Context, data pricesDomain.DeleteProductPriceDTO) (*pricesDomain.Price, error)
+	}
+
+	DeleteHandler struct {
+		name          string
+		deleteCommand deleteCommand
+	}
+
+	deleteRequest struct {
+		params pricesDomain.DeleteProductPriceDTO
+	}
+)
+
+func NewProductPriceDeleteHandler(command deleteCommand, name string) *DeleteHandler {
+	return &DeleteHandler{
+		name:          name,
+		deleteCommand: command,
+	}
+}
+
+// @Summary		Delete product price
+// @Description	Delete a price, the gap it leaves is not filled. The deletion is kept in the price history.
+// @Tags			Prices
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id			path		string				true	"Product ID"
+// @Param			price_id	path		string				true	"Price ID"
+// @Success		200			{object}	pricesDomain.Price	"Deleted price"
+// @Failure		400			{string}	string				"Bad Request"
+// @Failure		404			{string}	string				"Not Found"
+// @Failure		406			{string}	string				"Not Acceptable"
+// @Failure		500			{string}	string				"Internal Server Error"
+// @Router			/api/products/{id}/prices/{price_id} [delete]
+func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *deleteRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.deleteCommand.DeleteProductPrice(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, pricesDomain.ErrPriceNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *DeleteHandler) getRequestData(r *http.Request) (requestData *deleteRequest, err error) {
+	requestData = &deleteRequest{}
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	id, err := uuid.Parse(r.PathValue("price_id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = productID
+	requestData.params.ID = id
+	return
+}
+
+func (h *DeleteHandler) validateRequestData(requestData *deleteRequest) error {
+	return validator.New().Struct(requestData.params)
+}
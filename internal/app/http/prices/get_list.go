@@ -0,0 +1,137 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strings"
+)
+
+type (
+	getListCommand interface {
+		GetProductPrices(ctx context.Context, data pricesDomain.GetProductPricesDTO) ([]pricesDomain.Price, error)
+	}
+
+	GetListHandler struct {
+		name           string
+		getListCommand getListCommand
+	}
+
+	getListRequest struct {
+		params pricesDomain.GetProductPricesDTO
+	}
+)
+
+func NewProductPricesGetHandler(command getListCommand, name string) *GetListHandler {
+	return &GetListHandler{
+		name:           name,
+		getListCommand: command,
+	}
+}
+
+// @Summary		Get product prices
+// @Description	Get past, current and scheduled prices of a product, amounts are in minor units
+// @Tags			Prices
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id			path		string				true	"Product ID"
+// @Param			currency	query		string				false	"ISO 4217 currency"
+// @Success		200			{array}		pricesDomain.Price	"Prices"
+// @Failure		400			{string}	string				"Bad Request"
+// @Failure		404			{string}	string				"Not Found"
+// @Failure		406			{string}	string				"Not Acceptable"
+// @Failure		500			{string}	string				"Internal Server Error"
+// @Router			/api/products/{id}/prices [get]
+func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getListRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getListCommand.GetProductPrices(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetListHandler) getRequestData(r *http.Request) (requestData *getListRequest, err error) {
+	requestData = &getListRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+	requestData.params.Currency = strings.ToUpper(r.FormValue("currency"))
+
+	return
+}
+
+func (h *GetListHandler) validateRequestData(requestData *getListRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,156 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	historyCommand interface {
+		GetProductPriceHistory(ctx context.Context, data pricesDomain.GetProductPriceHistoryDTO) ([]pricesDomain.PriceChange, error)
+	}
+
+	HistoryHandler struct {
+		name           string
+		historyCommand historyCommand
+	}
+
+	historyRequest struct {
+		params pricesDomain.GetProductPriceHistoryDTO
+	}
+)
+
+func NewProductPriceHistoryHandler(command historyCommand, name string) *HistoryHandler {
+	return &HistoryHandler{
+		name:           name,
+		historyCommand: command,
+	}
+}
+
+// @Summary		Get product price history
+// @Description	Get every change made to the prices of a product, newest first
+// @Tags			Prices
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id			path		string						true	"Product ID"
+// @Param			currency	query		string						false	"ISO 4217 currency"
+// @Param			limit		query		int							false	"List limit"	default(50)	max(50)
+// @Param			offset		query		int							false	"List offset"	default(0)
+// @Success		200			{array}		pricesDomain.PriceChange	"Price changes"
+// @Failure		400			{string}	string						"Bad Request"
+// @Failure		404			{string}	string						"Not Found"
+// @Failure		406			{string}	string						"Not Acceptable"
+// @Failure		500			{string}	string						"Internal Server Error"
+// @Router			/api/products/{id}/prices/history [get]
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *historyRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.historyCommand.GetProductPriceHistory(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *HistoryHandler) getRequestData(r *http.Request) (requestData *historyRequest, err error) {
+	requestData = &historyRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+	requestData.params.Currency = strings.ToUpper(r.FormValue("currency"))
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit == 0 || limit > 50 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	offset, err := strconv.Atoi(r.FormValue("offset"))
+	if err != nil {
+		offset = 0
+		err = nil
+	}
+	requestData.params.Offset = int64(offset)
+
+	return
+}
+
+func (h *HistoryHandler) validateRequestData(requestData *historyRequest) error {
+	return validator.New().Struct(requestData.params)
+}
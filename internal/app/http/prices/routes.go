@@ -0,0 +1,63 @@
+package prices
+
+import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/prices"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	repo *dbRepo.Repository,
+) {
+	// Get product prices
+	mux.Handle(
+		"GET /api/products/{id}/prices",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductPricesGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/prices",
+				),
+			),
+		),
+	)
+
+	// Get product price history
+	mux.Handle(
+		"GET /api/products/{id}/prices/history",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductPriceHistoryHandler(
+					command.New(repo),
+					"GET /api/products/{id}/prices/history",
+				),
+			),
+		),
+	)
+
+	// Set product price
+	mux.Handle(
+		"POST /api/products/{id}/prices",
+		middlewaresHttp.Negotiate(
+			NewProductPriceSetHandler(
+				command.New(repo),
+				"POST /api/products/{id}/prices",
+			),
+		),
+	)
+
+	// Delete product price
+	mux.Handle(
+		"DELETE /api/products/{id}/prices/{price_id}",
+		middlewaresHttp.Negotiate(
+			NewProductPriceDeleteHandler(
+				command.New(repo),
+				"DELETE /api/products/{id}/prices/{price_id}",
+			),
+		),
+	)
+}
@@ -0,0 +1,176 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type (
+	setCommand interface {
+		SetProductPrice(ctx context.Context, data pricesDomain.SetProductPriceDTO) (*pricesDomain.Price, error)
+	}
+
+	SetHandler struct {
+		name       string
+		setCommand setCommand
+	}
+
+	setRequest struct {
+		body pricesDomain.SetProductPriceDTO
+	}
+)
+
+func NewProductPriceSetHandler(command setCommand, name string) *SetHandler {
+	return &SetHandler{
+		name:       name,
+		setCommand: command,
+	}
+}
+
+// @Summary		Set product price
+// @Description	Schedule a price for [valid_from, valid_to), overlapping prices in the same currency are trimmed, split or removed. valid_from defaults to now, a missing valid_to keeps the price until it is replaced.
+// @Tags			Prices
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string							true	"Product ID"
+// @Param			body	body		pricesDomain.SetProductPriceDTO	true	"Price"
+// @Success		201		{object}	pricesDomain.Price				"Created price"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		404		{string}	string							"Not Found"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		409		{string}	string							"Conflicting concurrent change"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/{id}/prices [post]
+func (h *SetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *setRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.setCommand.SetProductPrice(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, pricesDomain.ErrInvalidPriceRange):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, pricesDomain.ErrPriceConflict):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *SetHandler) getRequestData(r *http.Request) (requestData *setRequest, err error) {
+	requestData = &setRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &pricesDomain.SetProductPriceDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+	requestData.body.Currency = strings.ToUpper(requestData.body.Currency)
+
+	return
+}
+
+func (h *SetHandler) validateRequestData(requestData *setRequest) error {
+	return validator.New().Struct(requestData.body)
+}
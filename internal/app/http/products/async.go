@@ -0,0 +1,45 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"net/http"
+	"strconv"
+)
+
+// isAsync reports whether the client asked to run the operation as a
+// background job.
+func isAsync(r *http.Request) (bool, error) {
+	async := r.URL.Query().Get("async")
+	if async == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(async)
+}
+
+// writeJobAccepted answers an async request with 202 and points the client
+// to the job status endpoint.
+func writeJobAccepted(ctx context.Context, w http.ResponseWriter, name string, job *jobsDomain.Job) {
+	responseBody, err := httpResponses.Marshal(ctx, job)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/jobs/%s", job.ID))
+	httpResponses.GetResponse(
+		w,
+		name,
+		nil,
+		http.StatusAccepted,
+		&responseBody,
+	)
+}
@@ -0,0 +1,143 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	batchGetCommand interface {
+		BatchGetProducts(
+			ctx context.Context,
+			data productsDomain.GetProductsByIDsDTO,
+		) (*productsDomain.BatchGetProductsResult, error)
+	}
+
+	BatchGetHandler struct {
+		name            string
+		batchGetCommand batchGetCommand
+	}
+
+	batchGetRequest struct {
+		body productsDomain.GetProductsByIDsDTO
+	}
+)
+
+func NewProductsBatchGetHandler(command batchGetCommand, name string) *BatchGetHandler {
+	return &BatchGetHandler{
+		name:            name,
+		batchGetCommand: command,
+	}
+}
+
+// @Summary		Batch get products
+// @Description	Get up to 100 products by ids in one request. Found products keep the request order, ids of unknown or unpublished products are listed in missing
+// @Tags			Products
+// @Accept			json
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			request			body		productsDomain.GetProductsByIDsDTO		true	"Product ids"
+// @Param			locale			query		string									false	"Locale to translate names and titles to, overrides Accept-Language"
+// @Param			Accept-Language	header		string									false	"Preferred locales to translate names and titles to"
+// @Success		200				{object}	productsDomain.BatchGetProductsResult	"Products"
+// @Failure		400				{string}	string									"Bad Request"
+// @Failure		406				{string}	string									"Not Acceptable"
+// @Failure		500				{string}	string									"Internal Server Error"
+// @Router			/api/products/batch-get [post]
+func (h *BatchGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *batchGetRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.batchGetCommand.BatchGetProducts(ctx, requestData.body)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *BatchGetHandler) getRequestData(r *http.Request) (requestData *batchGetRequest, err error) {
+	requestData = &batchGetRequest{}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &productsDomain.GetProductsByIDsDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.Locales = httpResponses.Locales(r.Context())
+	requestData.body.PublishedOnly = httpResponses.PublishedOnly(r.Context())
+
+	return
+}
+
+func (h *BatchGetHandler) validateRequestData(requestData *batchGetRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
 	productsDomain "go_template_project/internal/domain/products"
 	"io"
 	"log"
@@ -15,6 +16,7 @@ import (
 type (
 	bulkCreateCommand interface {
 		BulkCreateProducts(ctx context.Context, data []productsDomain.Product) ([]productsDomain.Product, error)
+		EnqueueBulkCreateProducts(ctx context.Context, data []productsDomain.Product) (*jobsDomain.Job, error)
 	}
 
 	BulkCreateHandler struct {
@@ -23,7 +25,8 @@ type (
 	}
 
 	bulkCreateRequest struct {
-		body []productsDomain.Product
+		body  []productsDomain.Product
+		async bool
 	}
 )
 
@@ -37,10 +40,13 @@ func NewProductBulkCreateHandler(command bulkCreateCommand, name string) *BulkCr
 // @Summary		Bulk create products
 // @Description	Bulk create products
 // @Tags			Products
-// @Produce		json
-// @Success		201	array		productsDomain.Product	"Products"
-// @Failure		400	{string}	string					"Bad Request"
-// @Failure		500	{string}	string					"Internal Server Error"
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			async	query		bool					false	"Run as a background job"
+// @Success		201		array		productsDomain.Product	"Products"
+// @Success		202		{object}	jobsDomain.Job			"Job accepted"
+// @Failure		400		{string}	string					"Bad Request"
+// @Failure		406		{string}	string					"Not Acceptable"
+// @Failure		500		{string}	string					"Internal Server Error"
 // @Router			/api/products [post]
 func (h *BulkCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -71,6 +77,22 @@ func (h *BulkCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if requestData.async {
+		job, err := h.bulkCreateCommand.EnqueueBulkCreateProducts(ctx, requestData.body)
+		if err != nil {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+			return
+		}
+		writeJobAccepted(ctx, w, h.name, job)
+		return
+	}
+
 	responseRawBody, err := h.bulkCreateCommand.BulkCreateProducts(ctx, requestData.body)
 	if err != nil {
 		httpResponses.GetResponse(
@@ -83,12 +105,12 @@ func (h *BulkCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseBody, err := json.Marshal(responseRawBody)
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
 	if err != nil {
 		httpResponses.GetResponse(
 			w,
 			h.name,
-			fmt.Errorf("json marshalling failed: %w", err),
+			fmt.Errorf("response marshalling failed: %w", err),
 			http.StatusInternalServerError,
 			nil,
 		)
@@ -106,6 +128,9 @@ func (h *BulkCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 func (h *BulkCreateHandler) getRequestData(r *http.Request) (requestData *bulkCreateRequest, err error) {
 	requestData = &bulkCreateRequest{}
+	if requestData.async, err = isAsync(r); err != nil {
+		return
+	}
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Println(err)
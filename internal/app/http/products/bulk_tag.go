@@ -0,0 +1,181 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	bulkTagCommand interface {
+		BulkTagProducts(ctx context.Context, data tagsDomain.TagProductsDTO) (*tagsDomain.TagProductsResult, error)
+		EnqueueBulkTagProducts(ctx context.Context, data tagsDomain.TagProductsDTO) (*jobsDomain.Job, error)
+	}
+
+	BulkTagHandler struct {
+		name           string
+		bulkTagCommand bulkTagCommand
+	}
+
+	bulkTagRequest struct {
+		body  tagsDomain.TagProductsDTO
+		async bool
+	}
+)
+
+func NewProductBulkTagHandler(command bulkTagCommand, name string) *BulkTagHandler {
+	return &BulkTagHandler{
+		name:           name,
+		bulkTagCommand: command,
+	}
+}
+
+// @Summary		Bulk tag products
+// @Description	Add tags to many products at once. Tags are normalized to lower case with whitespace collapsed and created on first use. Products that do not exist or are deleted are reported as missing.
+// @Tags			Products
+// @Accept			json
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			async	query		bool							false	"Run as a background job"
+// @Param			request	body		tagsDomain.TagProductsDTO		true	"Products and tags"
+// @Success		200		{object}	tagsDomain.TagProductsResult	"Result"
+// @Success		202		{object}	jobsDomain.Job					"Job accepted"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/tag [post]
+func (h *BulkTagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *bulkTagRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if requestData.async {
+		job, err := h.bulkTagCommand.EnqueueBulkTagProducts(ctx, requestData.body)
+		if err != nil {
+			if errors.Is(err, tagsDomain.ErrInvalidTag) {
+				httpResponses.GetResponse(
+					w,
+					h.name,
+					err,
+					http.StatusBadRequest,
+					nil,
+				)
+				return
+			}
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+			return
+		}
+		writeJobAccepted(ctx, w, h.name, job)
+		return
+	}
+
+	responseRawBody, err := h.bulkTagCommand.BulkTagProducts(ctx, requestData.body)
+	if err != nil {
+		if errors.Is(err, tagsDomain.ErrInvalidTag) {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+			return
+		}
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *BulkTagHandler) getRequestData(r *http.Request) (requestData *bulkTagRequest, err error) {
+	requestData = &bulkTagRequest{}
+	if requestData.async, err = isAsync(r); err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &tagsDomain.TagProductsDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+
+	return
+}
+
+func (h *BulkTagHandler) validateRequestData(requestData *bulkTagRequest) error {
+	return validator.New().Struct(requestData.body)
+}
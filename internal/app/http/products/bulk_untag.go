@@ -0,0 +1,181 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	bulkUntagCommand interface {
+		BulkUntagProducts(ctx context.Context, data tagsDomain.TagProductsDTO) (*tagsDomain.TagProductsResult, error)
+		EnqueueBulkUntagProducts(ctx context.Context, data tagsDomain.TagProductsDTO) (*jobsDomain.Job, error)
+	}
+
+	BulkUntagHandler struct {
+		name             string
+		bulkUntagCommand bulkUntagCommand
+	}
+
+	bulkUntagRequest struct {
+		body  tagsDomain.TagProductsDTO
+		async bool
+	}
+)
+
+func NewProductBulkUntagHandler(command bulkUntagCommand, name string) *BulkUntagHandler {
+	return &BulkUntagHandler{
+		name:             name,
+		bulkUntagCommand: command,
+	}
+}
+
+// @Summary		Bulk untag products
+// @Description	Remove tags from many products at once. Tags are matched in their normalized form, unknown ones are ignored. Products that do not exist or are deleted are reported as missing.
+// @Tags			Products
+// @Accept			json
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			async	query		bool							false	"Run as a background job"
+// @Param			request	body		tagsDomain.TagProductsDTO		true	"Products and tags"
+// @Success		200		{object}	tagsDomain.TagProductsResult	"Result"
+// @Success		202		{object}	jobsDomain.Job					"Job accepted"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/untag [post]
+func (h *BulkUntagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *bulkUntagRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if requestData.async {
+		job, err := h.bulkUntagCommand.EnqueueBulkUntagProducts(ctx, requestData.body)
+		if err != nil {
+			if errors.Is(err, tagsDomain.ErrInvalidTag) {
+				httpResponses.GetResponse(
+					w,
+					h.name,
+					err,
+					http.StatusBadRequest,
+					nil,
+				)
+				return
+			}
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+			return
+		}
+		writeJobAccepted(ctx, w, h.name, job)
+		return
+	}
+
+	responseRawBody, err := h.bulkUntagCommand.BulkUntagProducts(ctx, requestData.body)
+	if err != nil {
+		if errors.Is(err, tagsDomain.ErrInvalidTag) {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+			return
+		}
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *BulkUntagHandler) getRequestData(r *http.Request) (requestData *bulkUntagRequest, err error) {
+	requestData = &bulkUntagRequest{}
+	if requestData.async, err = isAsync(r); err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &tagsDomain.TagProductsDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+
+	return
+}
+
+func (h *BulkUntagHandler) validateRequestData(requestData *bulkUntagRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -3,9 +3,11 @@ package products
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	httpResponses "go_template_project/internal/app/http/responses"
+	categoriesDomain "go_template_project/internal/domain/categories"
 	productsDomain "go_template_project/internal/domain/products"
 	"io"
 	"log"
@@ -37,9 +39,10 @@ func NewProductCreateHandler(command createCommand, name string) *CreateHandler
 // @Summary		Create product
 // @Description	Create product by id
 // @Tags			Products
-// @Produce		json
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
 // @Success		201	{object}	productsDomain.Product	"Product"
 // @Failure		400	{string}	string					"Bad Request"
+// @Failure		406	{string}	string					"Not Acceptable"
 // @Failure		500	{string}	string					"Internal Server Error"
 // @Router			/api/product [post]
 func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -74,6 +77,16 @@ func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	responseRawBody, err := h.createCommand.CreateProduct(ctx, requestData.body)
 
 	if err != nil {
+		if errors.Is(err, productsDomain.ErrInvalidAttributes) || errors.Is(err, categoriesDomain.ErrCategoryNotFound) {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+			return
+		}
 		httpResponses.GetResponse(
 			w,
 			h.name,
@@ -84,12 +97,12 @@ func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseBody, err := json.Marshal(responseRawBody)
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
 	if err != nil {
 		httpResponses.GetResponse(
 			w,
 			h.name,
-			fmt.Errorf("json marshalling failed: %w", err),
+			fmt.Errorf("response marshalling failed: %w", err),
 			http.StatusInternalServerError,
 			nil,
 		)
@@ -130,5 +143,5 @@ func (h *CreateHandler) getRequestData(r *http.Request) (requestData *createRequ
 }
 
 func (h *CreateHandler) validateRequestData(requestData *createRequest) error {
-	return validator.New().Struct(requestData)
+	return validator.New().Struct(requestData.body)
 }
@@ -2,7 +2,6 @@ package products
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -35,12 +34,13 @@ func NewProductDeleteHandler(command deleteCommand, name string) *DeleteHandler
 }
 
 // @Summary		Delete product
-// @Description	Delete product by id
+// @Description	Soft delete product by id, it is removed for good by purge-deleted
 // @Tags			Products
-// @Produce		json
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
 // @Success		204	{object}	string	"No content"
 // @Failure		400	{string}	string	"Bad Request"
 // @Failure		404	{string}	string	"Not Found"
+// @Failure		406	{string}	string	"Not Acceptable"
 // @Failure		500	{string}	string	"Internal Server Error"
 // @Router			/api/products/{id} [delete]
 func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -95,12 +95,12 @@ func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseBody, err := json.Marshal(responseRawBody)
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
 	if err != nil {
 		httpResponses.GetResponse(
 			w,
 			h.name,
-			fmt.Errorf("json marshalling failed: %w", err),
+			fmt.Errorf("response marshalling failed: %w", err),
 			http.StatusInternalServerError,
 			nil,
 		)
@@ -0,0 +1,176 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type (
+	exportCommand interface {
+		ExportProducts(ctx context.Context, data productsDomain.ExportProductsDTO, w io.Writer) error
+		EnqueueExportProducts(ctx context.Context, data productsDomain.ExportProductsDTO) (*jobsDomain.Job, error)
+	}
+
+	ExportHandler struct {
+		name          string
+		exportCommand exportCommand
+	}
+
+	exportRequest struct {
+		params productsDomain.ExportProductsDTO
+		async  bool
+	}
+
+	// exportResponseWriter remembers whether the stream has started, after
+	// which errors can no longer be reported with a status code.
+	exportResponseWriter struct {
+		http.ResponseWriter
+		written bool
+	}
+)
+
+func NewProductsExportHandler(command exportCommand, name string) *ExportHandler {
+	return &ExportHandler{
+		name:          name,
+		exportCommand: command,
+	}
+}
+
+// @Summary		Export products
+// @Description	Stream products matching the list filters as CSV, NDJSON or XLSX. An XLSX export continues on another sheet past the Excel limit of 1048576 rows per sheet
+// @Tags			Products
+// @Produce		text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param			format			query		string			true	"Export format"	Enums(csv, ndjson, xlsx)
+// @Param			columns			query		string			false	"Comma separated columns (id,name,title,created_at,updated_at,deleted_at)"
+// @Param			name			query		string			false	"Filter by name"
+// @Param			title			query		string			false	"Filter by title"
+// @Param			q				query		string			false	"Full-text search in names and titles, translated ones included"
+// @Param			category		query		string			false	"Category ID, includes its descendants"
+// @Param			currency		query		string			false	"ISO 4217 currency of the price filters"
+// @Param			price_min		query		int				false	"Lowest current price in minor units, needs currency"
+// @Param			price_max		query		int				false	"Highest current price in minor units, needs currency"
+// @Param			attr.{name}		query		string			false	"Attribute filter, for example attr.color=red"
+// @Param			tags_all		query		string			false	"Comma separated tags a product has to carry all of"
+// @Param			tags_any		query		string			false	"Comma separated tags a product has to carry one of"
+// @Param			status			query		string			false	"Comma separated statuses (draft, in_review, published, archived), only published products are exported outside /api/admin"
+// @Param			locale			query		string			false	"Locale to search translated names and titles in, overrides Accept-Language"
+// @Param			Accept-Language	header		string			false	"Preferred locales to search translated names and titles in"
+// @Param			async			query		bool			false	"Run as a background job, the file is then served as the job artifact"
+// @Success		200				{file}		file			"Products export"
+// @Success		202				{object}	jobsDomain.Job	"Job accepted"
+// @Failure		400				{string}	string			"Bad Request"
+// @Failure		500				{string}	string			"Internal Server Error"
+// @Router			/api/products/export [get]
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *exportRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if requestData.async {
+		job, err := h.exportCommand.EnqueueExportProducts(ctx, requestData.params)
+		if err != nil {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+			return
+		}
+		writeJobAccepted(ctx, w, h.name, job)
+		return
+	}
+
+	format := requestData.params.Format
+	w.Header().Set("Content-Type", productsDomain.ExportContentTypes[format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+
+	ew := &exportResponseWriter{ResponseWriter: w}
+	err = h.exportCommand.ExportProducts(ctx, requestData.params, ew)
+	if err != nil {
+		if ew.written {
+			log.Printf("%s: export aborted: %v", h.name, err)
+			return
+		}
+		w.Header().Del("Content-Disposition")
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+}
+
+func (h *ExportHandler) getRequestData(r *http.Request) (requestData *exportRequest, err error) {
+	requestData = &exportRequest{}
+	if requestData.async, err = isAsync(r); err != nil {
+		return
+	}
+
+	requestData.params.Format = r.FormValue("format")
+	if columns := r.FormValue("columns"); columns != "" {
+		for _, column := range strings.Split(columns, ",") {
+			requestData.params.Columns = append(requestData.params.Columns, strings.TrimSpace(column))
+		}
+	}
+
+	if err = parseListFilters(r, &requestData.params.Filter); err != nil {
+		return
+	}
+	requestData.params.Filter.PublishedOnly = httpResponses.PublishedOnly(r.Context())
+
+	return
+}
+
+func (h *ExportHandler) validateRequestData(requestData *exportRequest) error {
+	if err := validator.New().Struct(requestData.params); err != nil {
+		return err
+	}
+	return productsDomain.CheckPriceFilter(requestData.params.Filter)
+}
+
+func (w *exportResponseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *exportResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
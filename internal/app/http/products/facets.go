@@ -0,0 +1,172 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strconv"
+)
+
+type (
+	facetsCommand interface {
+		GetProductsFacets(ctx context.Context, data productsDomain.GetProductsFacetsDTO) (*productsDomain.ProductsFacets, error)
+	}
+
+	FacetsHandler struct {
+		name          string
+		facetsCommand facetsCommand
+	}
+
+	facetsRequest struct {
+		params productsDomain.GetProductsFacetsDTO
+	}
+)
+
+func NewProductsFacetsHandler(command facetsCommand, name string) *FacetsHandler {
+	return &FacetsHandler{
+		name:          name,
+		facetsCommand: command,
+	}
+}
+
+// @Summary		Get product facets
+// @Description	Count the products matched by the list filters per category, tag, price bucket and status in one query. Each facet ignores its own filter (category, tags_any, price_min and price_max, status), so values that are not selected yet keep their counts. Without facets all are computed, price only with a currency and price buckets. The status facet counts publication statuses, outside /api/admin it only ever has published.
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			facets			query		string							false	"Comma separated facets, category, tag, price or status"
+// @Param			price_buckets	query		string							false	"Comma separated ascending lower bounds of the price buckets after the first one, in minor units of currency"
+// @Param			limit			query		int								false	"Number of category and tag values"	default(20)	max(100)
+// @Param			q				query		string							false	"Full-text search in names and titles, translated ones included"
+// @Param			category		query		string							false	"Category ID, includes its descendants"
+// @Param			currency		query		string							false	"ISO 4217 currency of the price facet and filters"
+// @Param			price_min		query		int								false	"Lowest current price in minor units, needs currency"
+// @Param			price_max		query		int								false	"Highest current price in minor units, needs currency"
+// @Param			attr.{name}		query		string							false	"Attribute filter, for example attr.color=red"
+// @Param			tags_all		query		string							false	"Comma separated tags a product has to carry all of"
+// @Param			tags_any		query		string							false	"Comma separated tags a product has to carry one of"
+// @Param			status			query		string							false	"Comma separated statuses (draft, in_review, published, archived), only published products are counted outside /api/admin"
+// @Param			locale			query		string							false	"Locale to search translated names and titles in, overrides Accept-Language"
+// @Param			Accept-Language	header		string							false	"Preferred locales to search translated names and titles in"
+// @Success		200				{object}	productsDomain.ProductsFacets	"Facets"
+// @Failure		400				{string}	string							"Bad Request"
+// @Failure		406				{string}	string							"Not Acceptable"
+// @Failure		500				{string}	string							"Internal Server Error"
+// @Router			/api/products/facets [get]
+func (h *FacetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *facetsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.facetsCommand.GetProductsFacets(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrCurrencyRequired):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, productsDomain.ErrInvalidPriceBuckets):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *FacetsHandler) getRequestData(r *http.Request) (requestData *facetsRequest, err error) {
+	requestData = &facetsRequest{}
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 20
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	requestData.params.Limit = int64(limit)
+
+	if err = parseListFilters(r, &requestData.params.Filter); err != nil {
+		return
+	}
+	requestData.params.Filter.PublishedOnly = httpResponses.PublishedOnly(r.Context())
+	requestData.params.Facets = splitQueryList(r.FormValue("facets"))
+	for _, bound := range splitQueryList(r.FormValue("price_buckets")) {
+		var amount int64
+		if amount, err = strconv.ParseInt(bound, 10, 64); err != nil {
+			return
+		}
+		requestData.params.PriceBuckets = append(requestData.params.PriceBuckets, amount)
+	}
+
+	return
+}
+
+func (h *FacetsHandler) validateRequestData(requestData *facetsRequest) error {
+	if err := validator.New().Struct(requestData.params); err != nil {
+		return err
+	}
+	return productsDomain.CheckPriceFilter(requestData.params.Filter)
+}
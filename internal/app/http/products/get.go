@@ -2,7 +2,6 @@ package products
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -10,6 +9,7 @@ import (
 	httpResponses "go_template_project/internal/app/http/responses"
 	productsDomain "go_template_project/internal/domain/products"
 	"net/http"
+	"time"
 )
 
 type (
@@ -35,13 +35,22 @@ func NewProductGetHandler(command getCommand, name string) *GetHandler {
 }
 
 // @Summary		Get product
-// @Description	Get product by id
+// @Description	Get a published product by id, other products are not found. Under /api/admin/products/{id} products in every status are found
 // @Tags			Products
-// @Produce		json
-// @Success		200	{object}	productsDomain.Product	"Product"
-// @Failure		400	{string}	string					"Bad Request"
-// @Failure		404	{string}	string					"Not Found"
-// @Failure		500	{string}	string					"Internal Server Error"
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id					path		string					true	"Product ID"
+// @Param			fields				query		string					false	"Comma separated fields to return (id,name,title,attributes,created_at,updated_at,deleted_at,status,publish_at,unpublish_at)"
+// @Param			expand				query		string					false	"Comma separated related resources to embed (categories,prices,tags,media,variants)"
+// @Param			locale				query		string					false	"Locale to translate name and title to, overrides Accept-Language"
+// @Param			Accept-Language		header		string					false	"Preferred locales to translate name and title to"
+// @Param			If-None-Match		header		string					false	"ETag of a cached representation"
+// @Param			If-Modified-Since	header		string					false	"HTTP date of a cached representation"
+// @Success		200					{object}	productsDomain.Product	"Product"
+// @Success		304					{string}	string					"Not Modified"
+// @Failure		400					{string}	string					"Bad Request"
+// @Failure		404					{string}	string					"Not Found"
+// @Failure		406					{string}	string					"Not Acceptable"
+// @Failure		500					{string}	string					"Internal Server Error"
 // @Router			/api/products/{id} [get]
 func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -95,18 +104,45 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseBody, err := json.Marshal(responseRawBody)
+	selected, err := httpResponses.SelectFields(
+		responseRawBody,
+		responseFields(requestData.params.Fields, requestData.params.Expand),
+	)
 	if err != nil {
 		httpResponses.GetResponse(
 			w,
 			h.name,
-			fmt.Errorf("json marshalling failed: %w", err),
+			fmt.Errorf("field selection failed: %w", err),
 			http.StatusInternalServerError,
 			nil,
 		)
 		return
 	}
 
+	responseBody, err := httpResponses.Marshal(ctx, selected)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	// Related resources change without touching the product, expanded
+	// responses are only validated by their ETag.
+	lastModified := responseRawBody.UpdatedAt
+	if len(requestData.params.Expand) > 0 {
+		lastModified = time.Time{}
+	}
+	httpResponses.SetValidators(w, httpResponses.StrongETag(responseBody), lastModified)
+	if httpResponses.NotModified(r, w.Header().Get("ETag"), lastModified) {
+		httpResponses.WriteNotModified(w)
+		return
+	}
+
 	httpResponses.GetResponse(
 		w,
 		h.name,
@@ -124,9 +160,19 @@ func (h *GetHandler) getRequestData(r *http.Request) (requestData *getRequest, e
 	}
 
 	requestData.params.ID = id
+	requestData.params.Fields = splitQueryList(r.FormValue("fields"))
+	requestData.params.Expand = splitQueryList(r.FormValue("expand"))
+	requestData.params.Locales = httpResponses.Locales(r.Context())
+	requestData.params.PublishedOnly = httpResponses.PublishedOnly(r.Context())
 	return
 }
 
 func (h *GetHandler) validateRequestData(requestData *getRequest) error {
-	return validator.New().Struct(requestData)
+	if err := validator.New().Struct(requestData.params); err != nil {
+		return err
+	}
+	if err := productsDomain.CheckFields(requestData.params.Fields); err != nil {
+		return err
+	}
+	return productsDomain.CheckExpand(requestData.params.Expand)
 }
@@ -2,18 +2,26 @@ package products
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	httpResponses "go_template_project/internal/app/http/responses"
 	productsDomain "go_template_project/internal/domain/products"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type (
 	getListCommand interface {
 		GetProducts(ctx context.Context, data productsDomain.GetProductsDTO) ([]productsDomain.Product, error)
+		GetProductsStats(ctx context.Context, data productsDomain.GetProductsDTO) (*productsDomain.ProductsStats, error)
 	}
 
 	GetListHandler struct {
@@ -34,14 +42,32 @@ func NewProductsGetHandler(command getListCommand, name string) *GetListHandler
 }
 
 // @Summary		Get products
-// @Description	Get products list by limit and offset
+// @Description	Get published products list by limit and offset, under /api/admin/products/ products in every status
 // @Tags			Products
-// @Produce		json
-// @Param			limit	query		int						false	"List limit"	default(50)	max(50)
-// @Param			offset	query		int						false	"List offset"	default(0)
-// @Success		200		{array}		productsDomain.Product	"Product"
-// @Failure		400		{string}	string					"Bad Request"
-// @Failure		500		{string}	string					"Internal Server Error"
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			limit				query		int						false	"List limit"	default(50)	max(50)
+// @Param			offset				query		int						false	"List offset"	default(0)
+// @Param			q					query		string					false	"Full-text search in names and titles, translated ones included"
+// @Param			category			query		string					false	"Category ID, includes its descendants"
+// @Param			currency			query		string					false	"ISO 4217 currency, adds the current price to each product"
+// @Param			price_min			query		int						false	"Lowest current price in minor units, needs currency"
+// @Param			price_max			query		int						false	"Highest current price in minor units, needs currency"
+// @Param			sort				query		string					false	"Sort order, price sorts need currency"	Enums(name, -name, created_at, -created_at, price, -price)
+// @Param			attr.{name}			query		string					false	"Attribute filter, for example attr.color=red"
+// @Param			tags_all			query		string					false	"Comma separated tags a product has to carry all of"
+// @Param			tags_any			query		string					false	"Comma separated tags a product has to carry one of"
+// @Param			status				query		string					false	"Comma separated statuses (draft, in_review, published, archived), only published products are listed outside /api/admin"
+// @Param			fields				query		string					false	"Comma separated fields to return (id,name,title,attributes,created_at,updated_at,deleted_at,status,publish_at,unpublish_at)"
+// @Param			expand				query		string					false	"Comma separated related resources to embed (categories,prices,tags,media,variants)"
+// @Param			locale				query		string					false	"Locale to translate names and titles to, overrides Accept-Language"
+// @Param			Accept-Language		header		string					false	"Preferred locales to translate names and titles to"
+// @Param			If-None-Match		header		string					false	"ETag of a cached page"
+// @Param			If-Modified-Since	header		string					false	"HTTP date of a cached page"
+// @Success		200					{array}		productsDomain.Product	"Product"
+// @Success		304					{string}	string					"Not Modified"
+// @Failure		400					{string}	string					"Bad Request"
+// @Failure		406					{string}	string					"Not Acceptable"
+// @Failure		500					{string}	string					"Internal Server Error"
 // @Router			/api/products/ [get]
 func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -72,6 +98,44 @@ func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The weak ETag only depends on the filtered set and the request, a
+	// matching client is answered without loading the page at all. Prices
+	// change on schedule without touching products, and expanded resources
+	// change on their own, pages carrying either get no validators.
+	if requestData.params.Currency == "" && len(requestData.params.Expand) == 0 {
+		stats, err := h.getListCommand.GetProductsStats(ctx, requestData.params)
+		if err != nil {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+			return
+		}
+		var lastModified time.Time
+		if stats.LastUpdatedAt != nil {
+			lastModified = *stats.LastUpdatedAt
+		}
+		etag, err := listETag(ctx, requestData.params, stats.Count, lastModified)
+		if err != nil {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("etag failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+			return
+		}
+		httpResponses.SetValidators(w, etag, lastModified)
+		if httpResponses.NotModified(r, etag, lastModified) {
+			httpResponses.WriteNotModified(w)
+			return
+		}
+	}
+
 	responseRawBody, err := h.getListCommand.GetProducts(ctx, requestData.params)
 
 	if err != nil {
@@ -85,12 +149,27 @@ func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseBody, err := json.Marshal(responseRawBody)
+	selected, err := httpResponses.SelectFields(
+		responseRawBody,
+		responseFields(requestData.params.Fields, requestData.params.Expand),
+	)
 	if err != nil {
 		httpResponses.GetResponse(
 			w,
 			h.name,
-			fmt.Errorf("json marshalling failed: %w", err),
+			fmt.Errorf("field selection failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, selected)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
 			http.StatusInternalServerError,
 			nil,
 		)
@@ -121,21 +200,114 @@ func (h *GetListHandler) getRequestData(r *http.Request) (requestData *getListRe
 	offset, err := strconv.Atoi(r.FormValue("offset"))
 	if err != nil {
 		offset = 0
+		err = nil
 	}
 	requestData.params.Offset = int64(offset)
 
-	name := r.FormValue("name")
-	if name != "" {
-		requestData.params.Name = name
-	}
-	title := r.FormValue("title")
-	if title != "" {
-		requestData.params.Title = title
+	if err = parseListFilters(r, &requestData.params); err != nil {
+		return
 	}
+	requestData.params.PublishedOnly = httpResponses.PublishedOnly(r.Context())
+	requestData.params.Sort = r.FormValue("sort")
+
+	requestData.params.Fields = splitQueryList(r.FormValue("fields"))
+	requestData.params.Expand = splitQueryList(r.FormValue("expand"))
 
 	return
 }
 
 func (h *GetListHandler) validateRequestData(requestData *getListRequest) error {
-	return validator.New().Struct(requestData)
+	if err := validator.New().Struct(requestData.params); err != nil {
+		return err
+	}
+	if err := productsDomain.CheckPriceFilter(requestData.params); err != nil {
+		return err
+	}
+	if err := productsDomain.CheckFields(requestData.params.Fields); err != nil {
+		return err
+	}
+	return productsDomain.CheckExpand(requestData.params.Expand)
+}
+
+// listETag tags a page by the count and last update of the filtered set
+// and a digest of everything else the body depends on: the page, filters
+// and fields, the locale chain and the negotiated media type. Responses
+// vary on Accept and Accept-Language, so none of them may share a tag.
+func listETag(
+	ctx context.Context,
+	params productsDomain.GetProductsDTO,
+	count int64,
+	lastModified time.Time,
+) (string, error) {
+	key, err := json.Marshal(struct {
+		MediaType string                        `json:"media_type"`
+		Params    productsDomain.GetProductsDTO `json:"params"`
+	}{httpResponses.MediaType(ctx), params})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(key)
+	return httpResponses.WeakETag(lastModified.UnixMicro(), count, hex.EncodeToString(sum[:8])), nil
+}
+
+// parseListFilters reads the filters shared by everything computed over a
+// filtered product list.
+func parseListFilters(r *http.Request, params *productsDomain.GetProductsDTO) error {
+	params.Name = r.FormValue("name")
+	params.Title = r.FormValue("title")
+	params.Query = strings.TrimSpace(r.FormValue("q"))
+	params.Locales = httpResponses.Locales(r.Context())
+	if category := r.FormValue("category"); category != "" {
+		id, err := uuid.Parse(category)
+		if err != nil {
+			return err
+		}
+		params.Category = &id
+	}
+	params.Currency = strings.ToUpper(r.FormValue("currency"))
+	var err error
+	if params.PriceMin, err = parseAmount(r.FormValue("price_min")); err != nil {
+		return err
+	}
+	if params.PriceMax, err = parseAmount(r.FormValue("price_max")); err != nil {
+		return err
+	}
+	if params.Attributes, err = parseAttributeFilters(r.Form); err != nil {
+		return err
+	}
+	params.TagsAll = splitQueryList(r.FormValue("tags_all"))
+	params.TagsAny = splitQueryList(r.FormValue("tags_any"))
+	params.Statuses = splitQueryList(r.FormValue("status"))
+	return nil
+}
+
+// parseAttributeFilters collects attr.<name>=<value> query parameters.
+func parseAttributeFilters(form url.Values) (map[string]string, error) {
+	var filters map[string]string
+	for key, values := range form {
+		name, ok := strings.CutPrefix(key, "attr.")
+		if !ok {
+			continue
+		}
+		if name == "" {
+			return nil, errors.New("attribute filter without a name")
+		}
+		if filters == nil {
+			filters = map[string]string{}
+		}
+		filters[name] = values[0]
+	}
+	return filters, nil
+}
+
+// parseAmount reads an optional amount in minor units.
+func parseAmount(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
 }
@@ -0,0 +1,32 @@
+package products
+
+import (
+	"slices"
+	"strings"
+)
+
+// splitQueryList parses a comma separated query value like "id,name",
+// dropping blanks and duplicates.
+func splitQueryList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || slices.Contains(items, item) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// responseFields are the fields a narrowed response keeps, the expanded
+// resources stay next to the fields asked for.
+func responseFields(fields, expand []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	return append(slices.Clip(fields), expand...)
+}
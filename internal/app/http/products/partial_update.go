@@ -42,10 +42,11 @@ func NewProductPartialUpdateHandler(command partialUpdateCommand, name string) *
 // @Summary		PartialUpdate product
 // @Description	PartialUpdate product by id
 // @Tags			Products
-// @Produce		json
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
 // @Success		200	{object}	productsDomain.Product	"Product"
 // @Failure		400	{string}	string					"Bad Request"
 // @Failure		404	{string}	string					"Not found"
+// @Failure		406	{string}	string					"Not Acceptable"
 // @Failure		500	{string}	string					"Internal Server Error"
 // @Router			/api/products/{id} [patch]
 func (h *PartialUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -90,6 +91,16 @@ func (h *PartialUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			)
 			return
 		}
+		if errors.Is(err, productsDomain.ErrInvalidAttributes) {
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+			return
+		}
 		httpResponses.GetResponse(
 			w,
 			h.name,
@@ -100,12 +111,12 @@ func (h *PartialUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	responseBody, err := json.Marshal(responseRawBody)
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
 	if err != nil {
 		httpResponses.GetResponse(
 			w,
 			h.name,
-			fmt.Errorf("json marshalling failed: %w", err),
+			fmt.Errorf("response marshalling failed: %w", err),
 			http.StatusInternalServerError,
 			nil,
 		)
@@ -154,5 +165,5 @@ func (h *PartialUpdateHandler) getRequestData(r *http.Request) (requestData *par
 }
 
 func (h *PartialUpdateHandler) validateRequestData(requestData *partialUpdateRequest) error {
-	return validator.New().Struct(requestData)
+	return validator.New().Struct(requestData.data)
 }
@@ -1,6 +1,8 @@
 package products
 
 import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	"go_template_project/internal/config"
 	dbRepo "go_template_project/internal/repository"
 	command "go_template_project/internal/services/http/products"
 	"net/http"
@@ -8,67 +10,232 @@ import (
 
 func RegisterRoutes(
 	mux *http.ServeMux,
+	config config.Config,
 	repo *dbRepo.Repository,
 ) {
 	// Get products
 	mux.Handle(
 		"GET /api/products/",
-		NewProductsGetHandler(
-			command.New(repo),
-			"GET /api/products/",
+		middlewaresHttp.Negotiate(
+			middlewaresHttp.Localize(
+				config.I18n.Locales,
+				middlewaresHttp.CacheControl(
+					config.CacheControl.Products,
+					NewProductsGetHandler(
+						command.New(repo),
+						"GET /api/products/",
+					),
+				),
+			),
+		),
+	)
+
+	// Get facets of filtered products
+	mux.Handle(
+		"GET /api/products/facets",
+		middlewaresHttp.Negotiate(
+			middlewaresHttp.Localize(
+				config.I18n.Locales,
+				NewProductsFacetsHandler(
+					command.New(repo),
+					"GET /api/products/facets",
+				),
+			),
+		),
+	)
+
+	// Get tag counts over filtered products
+	mux.Handle(
+		"GET /api/products/tags/counts",
+		middlewaresHttp.Negotiate(
+			middlewaresHttp.Localize(
+				config.I18n.Locales,
+				NewProductsTagCountsHandler(
+					command.New(repo),
+					"GET /api/products/tags/counts",
+				),
+			),
+		),
+	)
+
+	// Export products (the format is chosen by the query, not negotiated)
+	mux.Handle(
+		"GET /api/products/export",
+		middlewaresHttp.Localize(
+			config.I18n.Locales,
+			NewProductsExportHandler(
+				command.New(repo),
+				"GET /api/products/export",
+			),
 		),
 	)
 
 	// Get product
 	mux.Handle(
 		"GET /api/products/{id}",
-		NewProductGetHandler(
-			command.New(repo),
-			"GET /api/products/{id}",
+		middlewaresHttp.Negotiate(
+			middlewaresHttp.Localize(
+				config.I18n.Locales,
+				middlewaresHttp.CacheControl(
+					config.CacheControl.Product,
+					NewProductGetHandler(
+						command.New(repo),
+						"GET /api/products/{id}",
+					),
+				),
+			),
+		),
+	)
+
+	// Get product translations
+	mux.Handle(
+		"GET /api/products/{id}/translations",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductTranslationsGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/translations",
+				),
+			),
+		),
+	)
+
+	// Get products missing translations
+	mux.Handle(
+		"GET /api/products/translations/missing",
+		middlewaresHttp.Negotiate(
+			NewMissingTranslationsHandler(
+				command.New(repo),
+				config.I18n.Locales,
+				"GET /api/products/translations/missing",
+			),
+		),
+	)
+
+	// Batch get products by ids
+	mux.Handle(
+		"POST /api/products/batch-get",
+		middlewaresHttp.Negotiate(
+			middlewaresHttp.Localize(
+				config.I18n.Locales,
+				NewProductsBatchGetHandler(
+					command.New(repo),
+					"POST /api/products/batch-get",
+				),
+			),
 		),
 	)
 
 	// Create product
 	mux.Handle(
 		"POST /api/product",
-		NewProductCreateHandler(
-			command.New(repo),
-			"POST /api/product",
+		middlewaresHttp.Negotiate(
+			NewProductCreateHandler(
+				command.New(repo),
+				"POST /api/product",
+			),
 		),
 	)
 
 	// Bulk create products
 	mux.Handle(
 		"POST /api/products",
-		NewProductBulkCreateHandler(
-			command.New(repo),
-			"POST /api/products",
+		middlewaresHttp.Negotiate(
+			NewProductBulkCreateHandler(
+				command.New(repo),
+				"POST /api/products",
+			),
 		),
 	)
 
 	// Bulk update products
 	mux.Handle(
 		"PATCH /api/products",
-		NewProductBulkUpdateHandler(
-			command.New(repo),
-			"PATCH /api/products",
+		middlewaresHttp.Negotiate(
+			NewProductBulkUpdateHandler(
+				command.New(repo),
+				"PATCH /api/products",
+			),
+		),
+	)
+
+	// Bulk tag products
+	mux.Handle(
+		"POST /api/products/tag",
+		middlewaresHttp.Negotiate(
+			NewProductBulkTagHandler(
+				command.New(repo),
+				"POST /api/products/tag",
+			),
+		),
+	)
+
+	// Bulk untag products
+	mux.Handle(
+		"POST /api/products/untag",
+		middlewaresHttp.Negotiate(
+			NewProductBulkUntagHandler(
+				command.New(repo),
+				"POST /api/products/untag",
+			),
+		),
+	)
+
+	// Move product along the publication workflow
+	mux.Handle(
+		"POST /api/products/{id}/transitions",
+		middlewaresHttp.Negotiate(
+			NewProductTransitionHandler(
+				command.New(repo),
+				"POST /api/products/{id}/transitions",
+			),
+		),
+	)
+
+	// Get product transitions
+	mux.Handle(
+		"GET /api/products/{id}/transitions",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewProductTransitionsGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/transitions",
+				),
+			),
+		),
+	)
+
+	// Schedule product publication
+	mux.Handle(
+		"PUT /api/products/{id}/schedule",
+		middlewaresHttp.Negotiate(
+			NewProductScheduleHandler(
+				command.New(repo),
+				"PUT /api/products/{id}/schedule",
+			),
 		),
 	)
 
 	// Partial update product
 	mux.Handle(
 		"PATCH /api/products/{id}",
-		NewProductPartialUpdateHandler(
-			command.New(repo),
-			"PATCH /api/products/{id}",
+		middlewaresHttp.Negotiate(
+			NewProductPartialUpdateHandler(
+				command.New(repo),
+				"PATCH /api/products/{id}",
+			),
 		),
 	)
 	// Delete product
 	mux.Handle(
 		"DELETE /api/products/{id}",
-		NewProductDeleteHandler(
-			command.New(repo),
-			"DELETE /api/products/{id}",
+		middlewaresHttp.Negotiate(
+			NewProductDeleteHandler(
+				command.New(repo),
+				"DELETE /api/products/{id}",
+			),
 		),
 	)
 }
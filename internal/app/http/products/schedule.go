@@ -0,0 +1,164 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	scheduleCommand interface {
+		SetProductSchedule(ctx context.Context, data productsDomain.SetProductScheduleDTO) (*productsDomain.ProductSchedule, error)
+	}
+
+	ScheduleHandler struct {
+		name            string
+		scheduleCommand scheduleCommand
+	}
+
+	scheduleRequest struct {
+		body productsDomain.SetProductScheduleDTO
+	}
+)
+
+func NewProductScheduleHandler(command scheduleCommand, name string) *ScheduleHandler {
+	return &ScheduleHandler{
+		name:            name,
+		scheduleCommand: command,
+	}
+}
+
+// @Summary		Schedule product
+// @Description	Set when a product is published and unpublished, a null time clears it. Once publish_at has come a product in review is published, a draft waits for its review. Once unpublish_at has come a published product is archived. Both moves are recorded as made by the scheduler.
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string									true	"Product ID"
+// @Param			body	body		productsDomain.SetProductScheduleDTO	true	"Schedule"
+// @Success		200		{object}	productsDomain.ProductSchedule			"Schedule"
+// @Failure		400		{string}	string									"Bad Request"
+// @Failure		404		{string}	string									"Not Found"
+// @Failure		406		{string}	string									"Not Acceptable"
+// @Failure		500		{string}	string									"Internal Server Error"
+// @Router			/api/products/{id}/schedule [put]
+func (h *ScheduleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *scheduleRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.scheduleCommand.SetProductSchedule(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, productsDomain.ErrInvalidSchedule):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *ScheduleHandler) getRequestData(r *http.Request) (requestData *scheduleRequest, err error) {
+	requestData = &scheduleRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &productsDomain.SetProductScheduleDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *ScheduleHandler) validateRequestData(requestData *scheduleRequest) error {
+	return validator.New().Struct(requestData.body)
+}
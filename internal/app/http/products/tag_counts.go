@@ -0,0 +1,143 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"net/http"
+	"strconv"
+)
+
+type (
+	tagCountsCommand interface {
+		GetTagCounts(ctx context.Context, data tagsDomain.GetTagCountsDTO) ([]tagsDomain.TagCount, error)
+	}
+
+	TagCountsHandler struct {
+		name             string
+		tagCountsCommand tagCountsCommand
+	}
+
+	tagCountsRequest struct {
+		params tagsDomain.GetTagCountsDTO
+	}
+)
+
+func NewProductsTagCountsHandler(command tagCountsCommand, name string) *TagCountsHandler {
+	return &TagCountsHandler{
+		name:             name,
+		tagCountsCommand: command,
+	}
+}
+
+// @Summary		Get tag counts
+// @Description	Count the tags of the products matched by the list filters, the most used first, for facet displays
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			limit			query		int					false	"Number of tags"	default(50)	max(100)
+// @Param			q				query		string				false	"Full-text search in names and titles, translated ones included"
+// @Param			category		query		string				false	"Category ID, includes its descendants"
+// @Param			currency		query		string				false	"ISO 4217 currency of the price filters"
+// @Param			price_min		query		int					false	"Lowest current price in minor units, needs currency"
+// @Param			price_max		query		int					false	"Highest current price in minor units, needs currency"
+// @Param			attr.{name}		query		string				false	"Attribute filter, for example attr.color=red"
+// @Param			tags_all		query		string				false	"Comma separated tags a product has to carry all of"
+// @Param			tags_any		query		string				false	"Comma separated tags a product has to carry one of"
+// @Param			status			query		string				false	"Comma separated statuses (draft, in_review, published, archived), only published products are counted outside /api/admin"
+// @Param			locale			query		string				false	"Locale to search translated names and titles in, overrides Accept-Language"
+// @Param			Accept-Language	header		string				false	"Preferred locales to search translated names and titles in"
+// @Success		200				{array}		tagsDomain.TagCount	"Tag counts"
+// @Failure		400				{string}	string				"Bad Request"
+// @Failure		406				{string}	string				"Not Acceptable"
+// @Failure		500				{string}	string				"Internal Server Error"
+// @Router			/api/products/tags/counts [get]
+func (h *TagCountsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *tagCountsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.tagCountsCommand.GetTagCounts(ctx, requestData.params)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *TagCountsHandler) getRequestData(r *http.Request) (requestData *tagCountsRequest, err error) {
+	requestData = &tagCountsRequest{}
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	if err = parseListFilters(r, &requestData.params.Filter); err != nil {
+		return
+	}
+	requestData.params.Filter.PublishedOnly = httpResponses.PublishedOnly(r.Context())
+
+	return
+}
+
+func (h *TagCountsHandler) validateRequestData(requestData *tagCountsRequest) error {
+	if err := validator.New().Struct(requestData.params); err != nil {
+		return err
+	}
+	return productsDomain.CheckPriceFilter(requestData.params.Filter)
+}
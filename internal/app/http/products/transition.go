@@ -0,0 +1,165 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	transitionCommand interface {
+		TransitionProduct(ctx context.Context, data productsDomain.TransitionProductDTO) (*productsDomain.ProductTransition, error)
+	}
+
+	TransitionHandler struct {
+		name              string
+		transitionCommand transitionCommand
+	}
+
+	transitionRequest struct {
+		body productsDomain.TransitionProductDTO
+	}
+)
+
+func NewProductTransitionHandler(command transitionCommand, name string) *TransitionHandler {
+	return &TransitionHandler{
+		name:              name,
+		transitionCommand: command,
+	}
+}
+
+// @Summary		Transition product
+// @Description	Move a product along the publication workflow and record who moved it and why. Drafts go to review, reviewed products are published or sent back, published products are archived or reworked as drafts, archived products are reworked as drafts. Other moves are rejected. The actor is recorded as sent, it is not checked against any identity.
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string								true	"Product ID"
+// @Param			body	body		productsDomain.TransitionProductDTO	true	"Transition"
+// @Success		201		{object}	productsDomain.ProductTransition	"Recorded transition"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		404		{string}	string								"Not Found"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		409		{string}	string								"Illegal transition"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/products/{id}/transitions [post]
+func (h *TransitionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *transitionRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.transitionCommand.TransitionProduct(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, productsDomain.ErrIllegalTransition):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *TransitionHandler) getRequestData(r *http.Request) (requestData *transitionRequest, err error) {
+	requestData = &transitionRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &productsDomain.TransitionProductDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *TransitionHandler) validateRequestData(requestData *transitionRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,152 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strconv"
+)
+
+type (
+	getTransitionsCommand interface {
+		GetProductTransitions(ctx context.Context, data productsDomain.GetProductTransitionsDTO) ([]productsDomain.ProductTransition, error)
+	}
+
+	GetTransitionsHandler struct {
+		name                  string
+		getTransitionsCommand getTransitionsCommand
+	}
+
+	getTransitionsRequest struct {
+		params productsDomain.GetProductTransitionsDTO
+	}
+)
+
+func NewProductTransitionsGetHandler(command getTransitionsCommand, name string) *GetTransitionsHandler {
+	return &GetTransitionsHandler{
+		name:                  name,
+		getTransitionsCommand: command,
+	}
+}
+
+// @Summary		Get product transitions
+// @Description	Get the status changes of a product, the latest first
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id		path		string								true	"Product ID"
+// @Param			limit	query		int									false	"Number of transitions"	default(50)	max(50)
+// @Param			offset	query		int									false	"Number of transitions to skip"
+// @Success		200		{array}		productsDomain.ProductTransition	"Transitions"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		404		{string}	string								"Not Found"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/products/{id}/transitions [get]
+func (h *GetTransitionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getTransitionsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getTransitionsCommand.GetProductTransitions(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetTransitionsHandler) getRequestData(r *http.Request) (requestData *getTransitionsRequest, err error) {
+	requestData = &getTransitionsRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit <= 0 || limit > 50 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	offset, err := strconv.Atoi(r.FormValue("offset"))
+	if err != nil {
+		offset = 0
+		err = nil
+	}
+	requestData.params.Offset = int64(offset)
+
+	return
+}
+
+func (h *GetTransitionsHandler) validateRequestData(requestData *getTransitionsRequest) error {
+	return validator.New().Struct(requestData.params)
+}
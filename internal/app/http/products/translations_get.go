@@ -0,0 +1,134 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+)
+
+type (
+	getTranslationsCommand interface {
+		GetProductTranslations(ctx context.Context, data productsDomain.GetProductTranslationsDTO) ([]productsDomain.ProductTranslation, error)
+	}
+
+	GetTranslationsHandler struct {
+		name                   string
+		getTranslationsCommand getTranslationsCommand
+	}
+
+	getTranslationsRequest struct {
+		params productsDomain.GetProductTranslationsDTO
+	}
+)
+
+func NewProductTranslationsGetHandler(command getTranslationsCommand, name string) *GetTranslationsHandler {
+	return &GetTranslationsHandler{
+		name:                   name,
+		getTranslationsCommand: command,
+	}
+}
+
+// @Summary		Get product translations
+// @Description	Get the stored translations of a product ordered by locale
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string								true	"Product ID"
+// @Success		200	{array}		productsDomain.ProductTranslation	"Translations"
+// @Failure		400	{string}	string								"Bad Request"
+// @Failure		404	{string}	string								"Not Found"
+// @Failure		406	{string}	string								"Not Acceptable"
+// @Failure		500	{string}	string								"Internal Server Error"
+// @Router			/api/products/{id}/translations [get]
+func (h *GetTranslationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getTranslationsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getTranslationsCommand.GetProductTranslations(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetTranslationsHandler) getRequestData(r *http.Request) (requestData *getTranslationsRequest, err error) {
+	requestData = &getTranslationsRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = id
+
+	return
+}
+
+func (h *GetTranslationsHandler) validateRequestData(requestData *getTranslationsRequest) error {
+	return validator.New().Struct(requestData.params)
+}
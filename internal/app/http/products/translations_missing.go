@@ -0,0 +1,141 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	"net/http"
+	"strconv"
+)
+
+type (
+	missingTranslationsCommand interface {
+		GetMissingTranslations(ctx context.Context, data productsDomain.GetMissingTranslationsDTO) ([]productsDomain.MissingTranslation, error)
+	}
+
+	// MissingTranslationsHandler reports the configured locales unless the
+	// request names others.
+	MissingTranslationsHandler struct {
+		name                       string
+		missingTranslationsCommand missingTranslationsCommand
+		locales                    []string
+	}
+
+	missingTranslationsRequest struct {
+		params productsDomain.GetMissingTranslationsDTO
+	}
+)
+
+func NewMissingTranslationsHandler(command missingTranslationsCommand, locales []string, name string) *MissingTranslationsHandler {
+	return &MissingTranslationsHandler{
+		name:                       name,
+		missingTranslationsCommand: command,
+		locales:                    locales,
+	}
+}
+
+// @Summary		Get missing translations
+// @Description	List products without a translation in at least one of the locales, in creation order. Soft-deleted products are left out.
+// @Tags			Products
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			locale	query		string								false	"Comma separated locales, defaults to the configured ones"
+// @Param			limit	query		int									false	"List limit"	default(50)	max(50)
+// @Param			offset	query		int									false	"List offset"	default(0)
+// @Success		200		{array}		productsDomain.MissingTranslation	"Products missing translations"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/products/translations/missing [get]
+func (h *MissingTranslationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *missingTranslationsRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.missingTranslationsCommand.GetMissingTranslations(ctx, requestData.params)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("command handler failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *MissingTranslationsHandler) getRequestData(r *http.Request) (requestData *missingTranslationsRequest, err error) {
+	requestData = &missingTranslationsRequest{}
+	requestData.params.Locales = splitQueryList(r.FormValue("locale"))
+	if len(requestData.params.Locales) == 0 {
+		requestData.params.Locales = h.locales
+	}
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit == 0 || limit > 50 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	offset, err := strconv.Atoi(r.FormValue("offset"))
+	if err != nil {
+		offset = 0
+		err = nil
+	}
+	requestData.params.Offset = int64(offset)
+
+	return
+}
+
+func (h *MissingTranslationsHandler) validateRequestData(requestData *missingTranslationsRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,81 @@
+package responses
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StrongETag derives an entity tag from the exact response body, so it
+// changes with any representation change.
+func StrongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// WeakETag builds a weak entity tag from values identifying a semantically
+// equivalent response.
+func WeakETag(parts ...any) string {
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		values = append(values, fmt.Sprint(part))
+	}
+	return `W/"` + strings.Join(values, "-") + `"`
+}
+
+// SetValidators writes ETag and Last-Modified, a zero lastModified is skipped.
+func SetValidators(w http.ResponseWriter, etag string, lastModified time.Time) {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// NotModified evaluates If-None-Match and If-Modified-Since as RFC 9110
+// prescribes for GET and HEAD: If-None-Match wins when present and is
+// compared weakly, If-Modified-Since works at the one second precision of
+// HTTP dates.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakMatch(candidate, etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// WriteNotModified answers with 304 keeping the validators already set.
+func WriteNotModified(w http.ResponseWriter) {
+	w.Header().Del("Content-Type")
+	w.Header().Del("Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+}
+
+func weakMatch(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
@@ -0,0 +1,46 @@
+package responses
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// SelectFields narrows the JSON form of v, an object or a list of objects,
+// to the given top level fields. No fields keep v as is.
+func SelectFields(v any, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic any
+	if err = decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	switch value := generic.(type) {
+	case map[string]any:
+		selectObjectFields(value, fields)
+	case []any:
+		for _, item := range value {
+			if object, ok := item.(map[string]any); ok {
+				selectObjectFields(object, fields)
+			}
+		}
+	}
+	return generic, nil
+}
+
+func selectObjectFields(object map[string]any, fields []string) {
+	for key := range object {
+		if !slices.Contains(fields, key) {
+			delete(object, key)
+		}
+	}
+}
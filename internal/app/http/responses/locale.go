@@ -0,0 +1,57 @@
+package responses
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// maxLocales bounds the fallback chain, every locale in it is looked up.
+const maxLocales = 10
+
+type localesKey struct{}
+
+func WithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey{}, locales)
+}
+
+// Locales returns the negotiated fallback chain, nil when reads should not
+// be translated.
+func Locales(ctx context.Context) []string {
+	locales, _ := ctx.Value(localesKey{}).([]string)
+	return locales
+}
+
+// NegotiateLocales builds the fallback chain for a request. An explicit
+// locale wins over the Accept-Language header and has to be valid, a
+// malformed header is ignored. With supported locales only those are kept.
+func NegotiateLocales(locale, acceptLanguage string, supported []string) ([]string, error) {
+	var preferred []string
+	if locale != "" {
+		if _, err := productsDomain.NormalizeLocale(locale); err != nil {
+			return nil, err
+		}
+		preferred = []string{locale}
+	} else if acceptLanguage != "" {
+		tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+		for _, tag := range tags {
+			preferred = append(preferred, tag.String())
+		}
+	}
+
+	locales := productsDomain.LocaleFallbacks(preferred)
+	if len(supported) > 0 {
+		locales = slices.DeleteFunc(locales, func(locale string) bool {
+			return !slices.Contains(supported, locale)
+		})
+	}
+	if len(locales) > maxLocales {
+		locales = locales[:maxLocales]
+	}
+	if len(locales) == 0 {
+		return nil, nil
+	}
+	return locales, nil
+}
@@ -0,0 +1,179 @@
+package responses
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeNDJSON  = "application/x-ndjson"
+	MediaTypeMsgPack = "application/msgpack"
+	MediaTypeCBOR    = "application/cbor"
+)
+
+type mediaTypeKey struct{}
+
+// MediaTypes lists the representations Marshal can produce, in server
+// preference order.
+var MediaTypes = []string{MediaTypeJSON, MediaTypeNDJSON, MediaTypeMsgPack, MediaTypeCBOR}
+
+func WithMediaType(ctx context.Context, mediaType string) context.Context {
+	return context.WithValue(ctx, mediaTypeKey{}, mediaType)
+}
+
+// MediaType returns the negotiated media type, JSON when none was negotiated.
+func MediaType(ctx context.Context) string {
+	if mediaType, ok := ctx.Value(mediaTypeKey{}).(string); ok {
+		return mediaType
+	}
+	return MediaTypeJSON
+}
+
+// NegotiateMediaType picks the best supported type for an Accept header,
+// an empty result means none is acceptable. Every type takes the quality of
+// the most specific range matching it, ties keep the MediaTypes order.
+func NegotiateMediaType(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return MediaTypeJSON
+	}
+
+	type weight struct {
+		q           float64
+		specificity int
+	}
+	weights := make([]weight, len(MediaTypes))
+	for i := range weights {
+		weights[i].specificity = -1
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		for i, mediaType := range MediaTypes {
+			specificity := matchMediaRange(mediaRange, mediaType)
+			if specificity > weights[i].specificity {
+				weights[i] = weight{q: q, specificity: specificity}
+			}
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for i, mediaType := range MediaTypes {
+		if weights[i].specificity >= 0 && weights[i].q > bestQ {
+			best, bestQ = mediaType, weights[i].q
+		}
+	}
+	return best
+}
+
+// Marshal encodes v in the media type negotiated for ctx. Binary formats
+// are produced from the JSON form, so field names and value formats (ids,
+// timestamps) are the same for every representation. NDJSON writes every
+// element of a slice on its own line.
+func Marshal(ctx context.Context, v any) ([]byte, error) {
+	mediaType := MediaType(ctx)
+	if mediaType == MediaTypeNDJSON {
+		return marshalNDJSON(v)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil || mediaType == MediaTypeJSON {
+		return raw, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic any
+	if err = decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	generic = normalizeNumbers(generic)
+
+	switch mediaType {
+	case MediaTypeMsgPack:
+		return msgpack.Marshal(generic)
+	case MediaTypeCBOR:
+		return cbor.Marshal(generic)
+	}
+	return nil, fmt.Errorf("unsupported media type %q", mediaType)
+}
+
+func marshalNDJSON(v any) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(raw, '\n'), nil
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for i := 0; i < value.Len(); i++ {
+		if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// normalizeNumbers turns json.Number into int64 or float64, so binary
+// encoders write numbers instead of strings.
+func normalizeNumbers(v any) any {
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]any:
+		for k, item := range value {
+			value[k] = normalizeNumbers(item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = normalizeNumbers(item)
+		}
+	}
+	return v
+}
+
+// matchMediaRange reports how specifically mediaRange covers mediaType:
+// 2 for an exact match, 1 for type/*, 0 for */* and -1 for no match.
+func matchMediaRange(mediaRange, mediaType string) int {
+	if mediaRange == mediaType {
+		return 2
+	}
+	rangeType, rangeSubtype, _ := strings.Cut(mediaRange, "/")
+	typ, _, _ := strings.Cut(mediaType, "/")
+	switch {
+	case rangeType == "*" && rangeSubtype == "*":
+		return 0
+	case rangeType == typ && rangeSubtype == "*":
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,30 @@
+package responses
+
+import "testing"
+
+func TestNegotiateMediaType(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: MediaTypeJSON},
+		{accept: "*/*", want: MediaTypeJSON},
+		{accept: "application/*", want: MediaTypeJSON},
+		{accept: "application/cbor", want: MediaTypeCBOR},
+		{accept: "application/msgpack, application/json;q=0.9", want: MediaTypeMsgPack},
+		{accept: "application/json;q=0.5, application/x-ndjson", want: MediaTypeNDJSON},
+		{accept: "application/*;q=0.5, application/cbor;q=0.8", want: MediaTypeCBOR},
+		{accept: "*/*;q=0.1, application/json;q=0", want: MediaTypeNDJSON},
+		{accept: "text/html, application/xhtml+xml", want: ""},
+		{accept: "application/json;q=0", want: ""},
+		{accept: "not a media type, application/cbor", want: MediaTypeCBOR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := NegotiateMediaType(tt.accept); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,8 +12,11 @@ func GetResponse(
 	statusCode int,
 	body *[]byte,
 ) {
+	// Negotiated handlers have the content type set already
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
 	w.WriteHeader(statusCode)
-	w.Header().Set("Content-Type", "application/json")
 	if body != nil {
 		_, _ = w.Write(*body)
 	}
@@ -0,0 +1,18 @@
+package responses
+
+import "context"
+
+type allStatusesKey struct{}
+
+// WithAllStatuses lets product reads in ctx see products in every
+// publication status.
+func WithAllStatuses(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allStatusesKey{}, true)
+}
+
+// PublishedOnly reports whether product reads in ctx are limited to
+// published products, true unless ctx was marked with WithAllStatuses.
+func PublishedOnly(ctx context.Context) bool {
+	all, _ := ctx.Value(allStatusesKey{}).(bool)
+	return !all
+}
@@ -4,16 +4,28 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 	_ "go_template_project/api"
+	categoriesRoutes "go_template_project/internal/app/http/categories"
+	graphqlRoutes "go_template_project/internal/app/http/graphql"
+	inventoryRoutes "go_template_project/internal/app/http/inventory"
+	jobsRoutes "go_template_project/internal/app/http/jobs"
+	mediaRoutes "go_template_project/internal/app/http/media"
 	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	pricesRoutes "go_template_project/internal/app/http/prices"
 	productsRoutes "go_template_project/internal/app/http/products"
+	tagsRoutes "go_template_project/internal/app/http/tags"
+	variantsRoutes "go_template_project/internal/app/http/variants"
 	"go_template_project/internal/config"
 	dbRepo "go_template_project/internal/repository"
+	"go_template_project/internal/storage"
 	"net/http"
 )
 
 func RegisterRoutes(
 	config config.Config,
 	repo *dbRepo.Repository,
+	store storage.Storage,
+	cors *middlewaresHttp.Cors,
+	rateLimiter *middlewaresHttp.RateLimiter,
 ) http.Handler {
 	mux := http.NewServeMux()
 
@@ -22,17 +34,43 @@ func RegisterRoutes(
 		mux.Handle("GET /docs/", httpSwagger.WrapHandler)
 	}
 
+	// add compression middleware
+	var httpHandler http.Handler = mux
+	if config.Server.Compression {
+		httpHandler = middlewaresHttp.Compress(config.Server.CompressionMinSize, httpHandler)
+	}
+
+	// pin reads to the primary right after a client's writes
+	if len(config.Repository.Replicas) > 0 && config.Server.ReadYourWritesWindow > 0 {
+		httpHandler = middlewaresHttp.ReadYourWrites(config.Server.ReadYourWritesWindow, httpHandler)
+	}
+
 	// add logging middleware
-	httpHandler := middlewaresHttp.LoggingMiddlewareHandler(mux)
+	httpHandler = middlewaresHttp.LoggingMiddlewareHandler(httpHandler)
+
+	// add rate limit middleware
+	httpHandler = rateLimiter.Handler(httpHandler)
 
 	// add cors middleware
 	if config.Server.AllowCors {
-		httpHandler = middlewaresHttp.AllowCors(httpHandler)
+		httpHandler = cors.Handler(httpHandler)
 	}
 
 	// Prometheus exporter
 	mux.Handle("GET /metrics/", promhttp.Handler())
-	productsRoutes.RegisterRoutes(mux, repo)
+	productsRoutes.RegisterRoutes(mux, config, repo)
+	categoriesRoutes.RegisterRoutes(mux, repo)
+	tagsRoutes.RegisterRoutes(mux, repo)
+	pricesRoutes.RegisterRoutes(mux, repo)
+	variantsRoutes.RegisterRoutes(mux, repo)
+	mediaRoutes.RegisterRoutes(mux, config, repo, store)
+	inventoryRoutes.RegisterRoutes(mux, config, repo)
+	jobsRoutes.RegisterRoutes(mux, repo, store)
+	graphqlRoutes.RegisterRoutes(mux, config, repo)
+	// the same API with unpublished products visible (if enabled in config)
+	if config.Server.AdminToken != "" {
+		mux.Handle(middlewaresHttp.AdminPrefix, middlewaresHttp.Admin(config.Server.AdminToken, mux))
+	}
 
-	return mux
+	return httpHandler
 }
@@ -0,0 +1,138 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	httpResponses "go_template_project/internal/app/http/responses"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"net/http"
+	"strconv"
+)
+
+type (
+	autocompleteCommand interface {
+		AutocompleteTags(ctx context.Context, data tagsDomain.AutocompleteTagsDTO) ([]tagsDomain.TagCount, error)
+	}
+
+	AutocompleteHandler struct {
+		name                string
+		autocompleteCommand autocompleteCommand
+	}
+
+	autocompleteRequest struct {
+		params tagsDomain.AutocompleteTagsDTO
+	}
+)
+
+func NewTagsAutocompleteHandler(command autocompleteCommand, name string) *AutocompleteHandler {
+	return &AutocompleteHandler{
+		name:                name,
+		autocompleteCommand: command,
+	}
+}
+
+// @Summary		Autocomplete tags
+// @Description	Find tags starting with a prefix, the ones on most published products first. The prefix is matched in the normalized form tags are stored in.
+// @Tags			Tags
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			prefix	query		string				true	"Beginning of the tag"
+// @Param			limit	query		int					false	"Number of tags"	default(10)	max(50)
+// @Success		200		{array}		tagsDomain.TagCount	"Tags with product counts"
+// @Failure		400		{string}	string				"Bad Request"
+// @Failure		406		{string}	string				"Not Acceptable"
+// @Failure		500		{string}	string				"Internal Server Error"
+// @Router			/api/tags [get]
+func (h *AutocompleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *autocompleteRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.autocompleteCommand.AutocompleteTags(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, tagsDomain.ErrInvalidTag):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *AutocompleteHandler) getRequestData(r *http.Request) (requestData *autocompleteRequest, err error) {
+	requestData = &autocompleteRequest{}
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 10
+		err = nil
+	}
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+	requestData.params.Limit = int64(limit)
+	requestData.params.Prefix = r.FormValue("prefix")
+
+	return
+}
+
+func (h *AutocompleteHandler) validateRequestData(requestData *autocompleteRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,24 @@
+package tags
+
+import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/tags"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	repo *dbRepo.Repository,
+) {
+	// Autocomplete tags
+	mux.Handle(
+		"GET /api/tags",
+		middlewaresHttp.Negotiate(
+			NewTagsAutocompleteHandler(
+				command.New(repo),
+				"GET /api/tags",
+			),
+		),
+	)
+}
@@ -0,0 +1,182 @@
+package variants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	createCommand interface {
+		CreateVariant(ctx context.Context, data variantsDomain.CreateVariantDTO) (*variantsDomain.Variant, error)
+	}
+
+	CreateHandler struct {
+		name          string
+		createCommand createCommand
+	}
+
+	createRequest struct {
+		body variantsDomain.CreateVariantDTO
+	}
+)
+
+func NewVariantCreateHandler(command createCommand, name string) *CreateHandler {
+	return &CreateHandler{
+		name:          name,
+		createCommand: command,
+	}
+}
+
+// @Summary		Create product variant
+// @Description	Create a variant with a unique SKU. options must pick one allowed value of every option type of the product, attributes are free form.
+// @Tags			Variants
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string							true	"Product ID"
+// @Param			body	body		variantsDomain.CreateVariantDTO	true	"Variant"
+// @Success		201		{object}	variantsDomain.Variant			"Created variant"
+// @Failure		400		{string}	string							"Bad Request"
+// @Failure		404		{string}	string							"Not Found"
+// @Failure		406		{string}	string							"Not Acceptable"
+// @Failure		409		{string}	string							"SKU or options already taken"
+// @Failure		500		{string}	string							"Internal Server Error"
+// @Router			/api/products/{id}/variants [post]
+func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *createRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.createCommand.CreateVariant(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, variantsDomain.ErrInvalidOptions):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		case errors.Is(err, variantsDomain.ErrSKUTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		case errors.Is(err, variantsDomain.ErrOptionsTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *CreateHandler) getRequestData(r *http.Request) (requestData *createRequest, err error) {
+	requestData = &createRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &variantsDomain.CreateVariantDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *CreateHandler) validateRequestData(requestData *createRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,140 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"net/http"
+)
+
+type (
+	deleteCommand interface {
+		DeleteVariant(ctx context.Context, data variantsDomain.DeleteVariantDTO) (*variantsDomain.Variant, error)
+	}
+
+	DeleteHandler struct {
+		name          string
+		deleteCommand deleteCommand
+	}
+
+	deleteRequest struct {
+		params variantsDomain.DeleteVariantDTO
+	}
+)
+
+func NewVariantDeleteHandler(command deleteCommand, name string) *DeleteHandler {
+	return &DeleteHandler{
+		name:          name,
+		deleteCommand: command,
+	}
+}
+
+// @Summary		Delete product variant
+// @Description	Soft-delete a variant, its SKU and options can be used again
+// @Tags			Variants
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id			path		string					true	"Product ID"
+// @Param			variant_id	path		string					true	"Variant ID"
+// @Success		200			{object}	variantsDomain.Variant	"Deleted variant"
+// @Failure		400			{string}	string					"Bad Request"
+// @Failure		404			{string}	string					"Not Found"
+// @Failure		406			{string}	string					"Not Acceptable"
+// @Failure		500			{string}	string					"Internal Server Error"
+// @Router			/api/products/{id}/variants/{variant_id} [delete]
+func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *deleteRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.deleteCommand.DeleteVariant(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, variantsDomain.ErrVariantNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *DeleteHandler) getRequestData(r *http.Request) (requestData *deleteRequest, err error) {
+	requestData = &deleteRequest{}
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	id, err := uuid.Parse(r.PathValue("variant_id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = productID
+	requestData.params.ID = id
+
+	return
+}
+
+func (h *DeleteHandler) validateRequestData(requestData *deleteRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,140 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"net/http"
+)
+
+type (
+	getCommand interface {
+		GetVariant(ctx context.Context, data variantsDomain.GetVariantDTO) (*variantsDomain.Variant, error)
+	}
+
+	GetHandler struct {
+		name       string
+		getCommand getCommand
+	}
+
+	getRequest struct {
+		params variantsDomain.GetVariantDTO
+	}
+)
+
+func NewVariantGetHandler(command getCommand, name string) *GetHandler {
+	return &GetHandler{
+		name:       name,
+		getCommand: command,
+	}
+}
+
+// @Summary		Get product variant
+// @Description	Get a variant of a product by id
+// @Tags			Variants
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id			path		string					true	"Product ID"
+// @Param			variant_id	path		string					true	"Variant ID"
+// @Success		200			{object}	variantsDomain.Variant	"Variant"
+// @Failure		400			{string}	string					"Bad Request"
+// @Failure		404			{string}	string					"Not Found"
+// @Failure		406			{string}	string					"Not Acceptable"
+// @Failure		500			{string}	string					"Internal Server Error"
+// @Router			/api/products/{id}/variants/{variant_id} [get]
+func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getCommand.GetVariant(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, variantsDomain.ErrVariantNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetHandler) getRequestData(r *http.Request) (requestData *getRequest, err error) {
+	requestData = &getRequest{}
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	id, err := uuid.Parse(r.PathValue("variant_id"))
+	if err != nil {
+		return
+	}
+
+	requestData.params.ProductID = productID
+	requestData.params.ID = id
+
+	return
+}
+
+func (h *GetHandler) validateRequestData(requestData *getRequest) error {
+	return validator.New().Struct(requestData.params)
+}
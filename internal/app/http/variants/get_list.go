@@ -0,0 +1,153 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"net/http"
+	"strconv"
+)
+
+type (
+	getListCommand interface {
+		GetVariants(ctx context.Context, data variantsDomain.GetVariantsDTO) ([]variantsDomain.Variant, error)
+	}
+
+	GetListHandler struct {
+		name           string
+		getListCommand getListCommand
+	}
+
+	getListRequest struct {
+		params variantsDomain.GetVariantsDTO
+	}
+)
+
+func NewVariantsGetHandler(command getListCommand, name string) *GetListHandler {
+	return &GetListHandler{
+		name:           name,
+		getListCommand: command,
+	}
+}
+
+// @Summary		Get product variants
+// @Description	Get the variants of a product in creation order, soft-deleted variants are left out
+// @Tags			Variants
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id		path		string					true	"Product ID"
+// @Param			limit	query		int						false	"List limit"	default(50)	max(50)
+// @Param			offset	query		int						false	"List offset"	default(0)
+// @Success		200		{array}		variantsDomain.Variant	"Variants"
+// @Failure		400		{string}	string					"Bad Request"
+// @Failure		404		{string}	string					"Not Found"
+// @Failure		406		{string}	string					"Not Acceptable"
+// @Failure		500		{string}	string					"Internal Server Error"
+// @Router			/api/products/{id}/variants [get]
+func (h *GetListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getListRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getListCommand.GetVariants(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetListHandler) getRequestData(r *http.Request) (requestData *getListRequest, err error) {
+	requestData = &getListRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil {
+		limit = 50
+	}
+	if limit == 0 || limit > 50 {
+		limit = 50
+	}
+	requestData.params.Limit = int64(limit)
+
+	offset, err := strconv.Atoi(r.FormValue("offset"))
+	if err != nil {
+		offset = 0
+		err = nil
+	}
+	requestData.params.Offset = int64(offset)
+
+	return
+}
+
+func (h *GetListHandler) validateRequestData(requestData *getListRequest) error {
+	return validator.New().Struct(requestData.params)
+}
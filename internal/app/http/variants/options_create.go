@@ -0,0 +1,174 @@
+package variants
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"io"
+	"log"
+	"net/http"
+)
+
+type (
+	createOptionTypeCommand interface {
+		CreateOptionType(ctx context.Context, data variantsDomain.CreateOptionTypeDTO) (*variantsDomain.OptionType, error)
+	}
+
+	CreateOptionTypeHandler struct {
+		name                    string
+		createOptionTypeCommand createOptionTypeCommand
+	}
+
+	createOptionTypeRequest struct {
+		body variantsDomain.CreateOptionTypeDTO
+	}
+)
+
+func NewOptionTypeCreateHandler(command createOptionTypeCommand, name string) *CreateOptionTypeHandler {
+	return &CreateOptionTypeHandler{
+		name:                    name,
+		createOptionTypeCommand: command,
+	}
+}
+
+// @Summary		Create product option type
+// @Description	Add an option type such as size or colour with its allowed values. Option types can only be added while the product has no variants.
+// @Tags			Variants
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Accept			json
+// @Param			id		path		string								true	"Product ID"
+// @Param			body	body		variantsDomain.CreateOptionTypeDTO	true	"Option type"
+// @Success		201		{object}	variantsDomain.OptionType			"Created option type"
+// @Failure		400		{string}	string								"Bad Request"
+// @Failure		404		{string}	string								"Not Found"
+// @Failure		406		{string}	string								"Not Acceptable"
+// @Failure		409		{string}	string								"Name taken or product has variants"
+// @Failure		500		{string}	string								"Internal Server Error"
+// @Router			/api/products/{id}/options [post]
+func (h *CreateOptionTypeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *createOptionTypeRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.createOptionTypeCommand.CreateOptionType(ctx, requestData.body)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		case errors.Is(err, variantsDomain.ErrOptionTypeTaken):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		case errors.Is(err, variantsDomain.ErrOptionTypesInUse):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusConflict,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusCreated,
+		&responseBody,
+	)
+}
+
+func (h *CreateOptionTypeHandler) getRequestData(r *http.Request) (requestData *createOptionTypeRequest, err error) {
+	requestData = &createOptionTypeRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println(err)
+		}
+	}(r.Body)
+	bodyData := &variantsDomain.CreateOptionTypeDTO{}
+	err = json.Unmarshal(body, bodyData)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	requestData.body = *bodyData
+	requestData.body.ProductID = id
+
+	return
+}
+
+func (h *CreateOptionTypeHandler) validateRequestData(requestData *createOptionTypeRequest) error {
+	return validator.New().Struct(requestData.body)
+}
@@ -0,0 +1,134 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	httpResponses "go_template_project/internal/app/http/responses"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"net/http"
+)
+
+type (
+	getOptionTypesCommand interface {
+		GetOptionTypes(ctx context.Context, data variantsDomain.GetOptionTypesDTO) ([]variantsDomain.OptionType, error)
+	}
+
+	GetOptionTypesHandler struct {
+		name                  string
+		getOptionTypesCommand getOptionTypesCommand
+	}
+
+	getOptionTypesRequest struct {
+		params variantsDomain.GetOptionTypesDTO
+	}
+)
+
+func NewOptionTypesGetHandler(command getOptionTypesCommand, name string) *GetOptionTypesHandler {
+	return &GetOptionTypesHandler{
+		name:                  name,
+		getOptionTypesCommand: command,
+	}
+}
+
+// @Summary		Get product option types
+// @Description	Get the option types of a product in display order
+// @Tags			Variants
+// @Produce		json,application/x-ndjson,application/msgpack,application/cbor
+// @Param			id	path		string						true	"Product ID"
+// @Success		200	{array}		variantsDomain.OptionType	"Option types"
+// @Failure		400	{string}	string						"Bad Request"
+// @Failure		404	{string}	string						"Not Found"
+// @Failure		406	{string}	string						"Not Acceptable"
+// @Failure		500	{string}	string						"Internal Server Error"
+// @Router			/api/products/{id}/options [get]
+func (h *GetOptionTypesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		requestData *getOptionTypesRequest
+		err         error
+	)
+
+	if requestData, err = h.getRequestData(r); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	if err = h.validateRequestData(requestData); err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+		return
+	}
+
+	responseRawBody, err := h.getOptionTypesCommand.GetOptionTypes(ctx, requestData.params)
+	if err != nil {
+		switch {
+		case errors.Is(err, productsDomain.ErrProductNotFound):
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		default:
+			httpResponses.GetResponse(
+				w,
+				h.name,
+				fmt.Errorf("command handler failed: %w", err),
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		return
+	}
+
+	responseBody, err := httpResponses.Marshal(ctx, responseRawBody)
+	if err != nil {
+		httpResponses.GetResponse(
+			w,
+			h.name,
+			fmt.Errorf("response marshalling failed: %w", err),
+			http.StatusInternalServerError,
+			nil,
+		)
+		return
+	}
+
+	httpResponses.GetResponse(
+		w,
+		h.name,
+		nil,
+		http.StatusOK,
+		&responseBody,
+	)
+}
+
+func (h *GetOptionTypesHandler) getRequestData(r *http.Request) (requestData *getOptionTypesRequest, err error) {
+	requestData = &getOptionTypesRequest{}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		return
+	}
+	requestData.params.ProductID = id
+
+	return
+}
+
+func (h *GetOptionTypesHandler) validateRequestData(requestData *getOptionTypesRequest) error {
+	return validator.New().Struct(requestData.params)
+}
@@ -0,0 +1,88 @@
+package variants
+
+import (
+	middlewaresHttp "go_template_project/internal/app/http/middlewares"
+	dbRepo "go_template_project/internal/repository"
+	command "go_template_project/internal/services/http/variants"
+	"net/http"
+)
+
+func RegisterRoutes(
+	mux *http.ServeMux,
+	repo *dbRepo.Repository,
+) {
+	// Get product option types
+	mux.Handle(
+		"GET /api/products/{id}/options",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewOptionTypesGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/options",
+				),
+			),
+		),
+	)
+
+	// Create product option type
+	mux.Handle(
+		"POST /api/products/{id}/options",
+		middlewaresHttp.Negotiate(
+			NewOptionTypeCreateHandler(
+				command.New(repo),
+				"POST /api/products/{id}/options",
+			),
+		),
+	)
+
+	// Get product variants
+	mux.Handle(
+		"GET /api/products/{id}/variants",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewVariantsGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/variants",
+				),
+			),
+		),
+	)
+
+	// Get product variant
+	mux.Handle(
+		"GET /api/products/{id}/variants/{variant_id}",
+		middlewaresHttp.VisibleProduct(
+			repo,
+			middlewaresHttp.Negotiate(
+				NewVariantGetHandler(
+					command.New(repo),
+					"GET /api/products/{id}/variants/{variant_id}",
+				),
+			),
+		),
+	)
+
+	// Create product variant
+	mux.Handle(
+		"POST /api/products/{id}/variants",
+		middlewaresHttp.Negotiate(
+			NewVariantCreateHandler(
+				command.New(repo),
+				"POST /api/products/{id}/variants",
+			),
+		),
+	)
+
+	// Delete product variant
+	mux.Handle(
+		"DELETE /api/products/{id}/variants/{variant_id}",
+		middlewaresHttp.Negotiate(
+			NewVariantDeleteHandler(
+				command.New(repo),
+				"DELETE /api/products/{id}/variants/{variant_id}",
+			),
+		),
+	)
+}
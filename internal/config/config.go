@@ -1,50 +1,276 @@
 package config
 
-import dbRepo "go_template_project/internal/repository"
+import (
+	productsDomain "go_template_project/internal/domain/products"
+	"go_template_project/internal/migrator"
+	dbRepo "go_template_project/internal/repository"
+	cacheRepo "go_template_project/internal/repository/cache"
+	inventoryService "go_template_project/internal/services/inventory"
+	jobsService "go_template_project/internal/services/jobs"
+	mediaService "go_template_project/internal/services/media"
+	publicationService "go_template_project/internal/services/publication"
+	"go_template_project/internal/storage"
+	"strconv"
+	"time"
+)
 
 type (
+	// EnvVars is the flat list of settings. The config tag is the key in
+	// config files, the environment variable (upper cased, optionally with
+	// the VP_BACKEND_ prefix) and the command line flag (dashed).
 	EnvVars struct {
-		ServerHost       string `envconfig:"server_host"`
-		ServerPort       int    `envconfig:"server_port"`
-		ServerAllowCors  bool   `envconfig:"server_allow_cors"`
-		ServerDebugMode  bool   `envconfig:"server_debug_mode"`
-		SwaggerDocs      bool   `envconfig:"swagger_docs"`
-		DatabaseHost     string `envconfig:"db_host"`
-		DatabasePort     int    `envconfig:"db_port"`
-		DatabaseName     string `envconfig:"db_name"`
-		DatabaseUsername string `envconfig:"db_username"`
-		DatabasePassword string `envconfig:"db_password"`
+		ServerHost           string   `config:"server_host" default:"0.0.0.0" validate:"required"`
+		ServerPort           int      `config:"server_port" default:"3000" validate:"min=1,max=65535"`
+		ServerAllowCors      bool     `config:"server_allow_cors"`
+		ServerAllowOrigins   []string `config:"server_allow_origin" default:"*" reload:"true"`
+		ServerDebugMode      bool     `config:"server_debug_mode"`
+		LogLevel             string   `config:"log_level" default:"info" validate:"oneof=debug info warn error" reload:"true"`
+		RateLimitRPS         float64  `config:"rate_limit_rps" validate:"min=0" reload:"true"`
+		RateLimitBurst       int      `config:"rate_limit_burst" default:"20" validate:"min=1" reload:"true"`
+		Compression          bool     `config:"compression" default:"true"`
+		CompressionMinSize   int      `config:"compression_min_size" default:"1024" validate:"min=0"`
+		SwaggerDocs          bool     `config:"swagger_docs"`
+		GraphiQL             bool     `config:"graphiql"`
+		AdminToken           string   `config:"admin_token" secret:"true"`
+		CacheControlProduct  string   `config:"cache_control_product" default:"no-cache"`
+		CacheControlProducts string   `config:"cache_control_products" default:"no-cache"`
+		CacheControlMedia    string   `config:"cache_control_media" default:"public, max-age=31536000, immutable"`
+		GraphQLMaxDepth      int      `config:"graphql_max_depth" default:"10" validate:"min=1"`
+		GraphQLMaxComplexity int      `config:"graphql_max_complexity" default:"1000" validate:"min=1"`
+		Locales              []string `config:"locales" validate:"dive,bcp47_language_tag"`
+		DatabaseDSN          string   `config:"db_dsn" secret:"true"`
+		DatabaseHost         string   `config:"db_host" validate:"required_without=DatabaseDSN"`
+		DatabasePort         int      `config:"db_port" default:"5432" validate:"min=1,max=65535"`
+		DatabaseName         string   `config:"db_name" validate:"required_without=DatabaseDSN"`
+		DatabaseUsername     string   `config:"db_username" validate:"required_without=DatabaseDSN"`
+		DatabasePassword     string   `config:"db_password" secret:"true"`
+
+		DatabaseSSLMode          string        `config:"db_sslmode" validate:"omitempty,oneof=disable allow prefer require verify-ca verify-full"`
+		DatabaseSSLRootCert      string        `config:"db_sslrootcert"`
+		DatabaseSSLCert          string        `config:"db_sslcert"`
+		DatabaseSSLKey           string        `config:"db_sslkey"`
+		DatabaseMaxConns         int           `config:"db_max_conns" validate:"min=0"`
+		DatabaseMinConns         int           `config:"db_min_conns" validate:"min=0"`
+		DatabaseMaxConnLifetime  time.Duration `config:"db_max_conn_lifetime" default:"1h" validate:"min=0"`
+		DatabaseMaxConnIdleTime  time.Duration `config:"db_max_conn_idle_time" default:"30m" validate:"min=0"`
+		DatabaseStatementTimeout time.Duration `config:"db_statement_timeout" validate:"min=0"`
+		DatabaseApplicationName  string        `config:"db_application_name" default:"go_template_project"`
+		DatabaseConnectRetries   int           `config:"db_connect_retries" default:"5" validate:"min=0"`
+		DatabaseConnectBackoff   time.Duration `config:"db_connect_backoff" default:"1s" validate:"gt=0"`
+
+		DatabaseReplicas                   []string      `config:"db_replicas" secret:"true"`
+		DatabaseReplicaHealthCheckInterval time.Duration `config:"db_replica_health_check_interval" default:"5s" validate:"gt=0"`
+		ReadYourWritesWindow               time.Duration `config:"read_your_writes_window" default:"5s" validate:"min=0"`
+
+		MigrationsDir  string `config:"migrations_dir"`
+		MigrateOnStart bool   `config:"migrate_on_start" default:"true"`
+
+		CacheEnabled bool          `config:"cache_enabled" default:"true"`
+		CacheSize    int           `config:"cache_size" default:"10000" validate:"min=1"`
+		CacheTTL     time.Duration `config:"cache_ttl" default:"1m" validate:"gt=0"`
+
+		JobsWorkers           int           `config:"jobs_workers" default:"2" validate:"min=0"`
+		JobsPollInterval      time.Duration `config:"jobs_poll_interval" default:"1s" validate:"gt=0"`
+		JobsHeartbeatInterval time.Duration `config:"jobs_heartbeat_interval" default:"5s" validate:"gt=0"`
+		JobsLeaseTimeout      time.Duration `config:"jobs_lease_timeout" default:"1m" validate:"gtfield=JobsHeartbeatInterval"`
+		JobsRetryBackoff      time.Duration `config:"jobs_retry_backoff" default:"5s" validate:"gt=0"`
+
+		InventoryReservationTTL time.Duration `config:"inventory_reservation_ttl" default:"15m" validate:"gt=0"`
+		InventorySweepInterval  time.Duration `config:"inventory_sweep_interval" default:"30s" validate:"gt=0"`
+		InventorySweepBatchSize int           `config:"inventory_sweep_batch_size" default:"500" validate:"min=1"`
+
+		StorageDir            string        `config:"storage_dir" default:"./data/media" validate:"required"`
+		MediaMaxFileSize      int64         `config:"media_max_file_size" default:"10485760" validate:"min=1"`
+		MediaThumbnailSizes   []string      `config:"media_thumbnail_sizes" default:"160,480,1024" validate:"dive,number"`
+		MediaCleanupInterval  time.Duration `config:"media_cleanup_interval" default:"1m" validate:"gt=0"`
+		MediaCleanupBatchSize int           `config:"media_cleanup_batch_size" default:"100" validate:"min=1"`
+
+		PublicationScheduleInterval  time.Duration `config:"publication_schedule_interval" default:"30s" validate:"gt=0"`
+		PublicationScheduleBatchSize int           `config:"publication_schedule_batch_size" default:"500" validate:"min=1"`
 	}
 
 	serverConfig struct {
-		Host        string
-		Port        int
-		AllowCors   bool
-		DebugMode   bool
-		SwaggerDocs bool
+		Host         string
+		Port         int
+		AllowCors    bool
+		AllowOrigins []string
+		DebugMode    bool
+		SwaggerDocs  bool
+		GraphiQL     bool
+		// /api/admin is only served to clients sending AdminToken as a
+		// bearer token, empty disables it
+		AdminToken string
+		// Reads go to the primary for ReadYourWritesWindow after a client's
+		// last mutation, zero disables it
+		ReadYourWritesWindow time.Duration
+		// Responses shorter than CompressionMinSize bytes are sent as is
+		Compression        bool
+		CompressionMinSize int
+	}
+
+	logConfig struct {
+		Level string
+	}
+
+	// rateLimitConfig limits requests per client IP, a zero RPS disables it.
+	rateLimitConfig struct {
+		RPS   float64
+		Burst int
+	}
+
+	// cacheControlConfig holds Cache-Control directives per route, an empty
+	// value leaves the header out. Media files never change under the same
+	// URL.
+	cacheControlConfig struct {
+		Product  string
+		Products string
+		Media    string
+	}
+
+	graphQLConfig struct {
+		MaxDepth      int
+		MaxComplexity int
+	}
+
+	// i18nConfig lists the locales products are translated to. Reads only
+	// look these up and the missing translations report covers them, empty
+	// allows any locale.
+	i18nConfig struct {
+		Locales []string
 	}
 
 	Config struct {
-		Server     serverConfig
-		Repository dbRepo.Config
+		Server       serverConfig
+		Log          logConfig
+		RateLimit    rateLimitConfig
+		CacheControl cacheControlConfig
+		GraphQL      graphQLConfig
+		I18n         i18nConfig
+		Repository   dbRepo.Config
+		Migrations   migrator.Config
+		Cache        cacheRepo.Config
+		Jobs         jobsService.Config
+		Inventory    inventoryService.Config
+		Storage      storage.Config
+		Media        mediaService.Config
+		Publication  publicationService.Config
 	}
 )
 
 func NewConfig(f EnvVars) Config {
 	return Config{
 		Server: serverConfig{
-			Host:        f.ServerHost,
-			Port:        f.ServerPort,
-			AllowCors:   f.ServerAllowCors,
-			DebugMode:   f.ServerDebugMode,
-			SwaggerDocs: f.SwaggerDocs,
+			Host:         f.ServerHost,
+			Port:         f.ServerPort,
+			AllowCors:    f.ServerAllowCors,
+			AllowOrigins: f.ServerAllowOrigins,
+			DebugMode:    f.ServerDebugMode,
+			SwaggerDocs:  f.SwaggerDocs,
+			GraphiQL:     f.GraphiQL,
+			AdminToken:   f.AdminToken,
+
+			ReadYourWritesWindow: f.ReadYourWritesWindow,
+			Compression:          f.Compression,
+			CompressionMinSize:   f.CompressionMinSize,
+		},
+		Log: logConfig{
+			Level: f.LogLevel,
+		},
+		RateLimit: rateLimitConfig{
+			RPS:   f.RateLimitRPS,
+			Burst: f.RateLimitBurst,
+		},
+		CacheControl: cacheControlConfig{
+			Product:  f.CacheControlProduct,
+			Products: f.CacheControlProducts,
+			Media:    f.CacheControlMedia,
+		},
+		GraphQL: graphQLConfig{
+			MaxDepth:      f.GraphQLMaxDepth,
+			MaxComplexity: f.GraphQLMaxComplexity,
+		},
+		I18n: i18nConfig{
+			Locales: normalizeLocales(f.Locales),
 		},
 		Repository: dbRepo.Config{
-			Host:     f.DatabaseHost,
-			Port:     f.DatabasePort,
-			Name:     f.DatabaseName,
-			Username: f.DatabaseUsername,
-			Password: f.DatabasePassword,
+			DSN:              f.DatabaseDSN,
+			Host:             f.DatabaseHost,
+			Port:             f.DatabasePort,
+			Name:             f.DatabaseName,
+			Username:         f.DatabaseUsername,
+			Password:         f.DatabasePassword,
+			SSLMode:          f.DatabaseSSLMode,
+			SSLRootCert:      f.DatabaseSSLRootCert,
+			SSLCert:          f.DatabaseSSLCert,
+			SSLKey:           f.DatabaseSSLKey,
+			MaxConns:         f.DatabaseMaxConns,
+			MinConns:         f.DatabaseMinConns,
+			MaxConnLifetime:  f.DatabaseMaxConnLifetime,
+			MaxConnIdleTime:  f.DatabaseMaxConnIdleTime,
+			StatementTimeout: f.DatabaseStatementTimeout,
+			ApplicationName:  f.DatabaseApplicationName,
+			ConnectRetries:   f.DatabaseConnectRetries,
+			ConnectBackoff:   f.DatabaseConnectBackoff,
+
+			Replicas:                   f.DatabaseReplicas,
+			ReplicaHealthCheckInterval: f.DatabaseReplicaHealthCheckInterval,
+		},
+		Migrations: migrator.Config{
+			Dir:         f.MigrationsDir,
+			AutoMigrate: f.MigrateOnStart,
+		},
+		Cache: cacheRepo.Config{
+			Enabled: f.CacheEnabled,
+			Size:    f.CacheSize,
+			TTL:     f.CacheTTL,
+		},
+		Jobs: jobsService.Config{
+			Workers:           f.JobsWorkers,
+			PollInterval:      f.JobsPollInterval,
+			HeartbeatInterval: f.JobsHeartbeatInterval,
+			LeaseTimeout:      f.JobsLeaseTimeout,
+			RetryBackoff:      f.JobsRetryBackoff,
+		},
+		Inventory: inventoryService.Config{
+			ReservationTTL: f.InventoryReservationTTL,
+			SweepInterval:  f.InventorySweepInterval,
+			SweepBatchSize: f.InventorySweepBatchSize,
 		},
+		Storage: storage.Config{
+			Dir: f.StorageDir,
+		},
+		Media: mediaService.Config{
+			MaxFileSize:      f.MediaMaxFileSize,
+			ThumbnailSizes:   thumbnailSizes(f.MediaThumbnailSizes),
+			CleanupInterval:  f.MediaCleanupInterval,
+			CleanupBatchSize: f.MediaCleanupBatchSize,
+		},
+		Publication: publicationService.Config{
+			ScheduleInterval:  f.PublicationScheduleInterval,
+			ScheduleBatchSize: f.PublicationScheduleBatchSize,
+		},
+	}
+}
+
+// normalizeLocales brings validated locales to the form translations are
+// stored in.
+func normalizeLocales(locales []string) []string {
+	normalized := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		if locale, err := productsDomain.NormalizeLocale(locale); err == nil {
+			normalized = append(normalized, locale)
+		}
+	}
+	return normalized
+}
+
+// thumbnailSizes parses validated thumbnail sizes, leaving out zeros.
+func thumbnailSizes(sizes []string) []int {
+	parsed := make([]int, 0, len(sizes))
+	for _, size := range sizes {
+		if size, err := strconv.Atoi(size); err == nil && size > 0 {
+			parsed = append(parsed, size)
+		}
 	}
+	return parsed
 }
@@ -0,0 +1,345 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the optional prefix of environment variables, VP_BACKEND_DB_HOST
+// takes precedence over DB_HOST.
+const EnvPrefix = "VP_BACKEND"
+
+const defaultEnvFile = ".env"
+
+type (
+	// Loader merges settings from, in increasing precedence: struct defaults,
+	// a YAML or TOML config file, a .env file, the process environment and
+	// command line flags. Load may be called again to pick up changes.
+	Loader struct {
+		configPath string
+		envFile    string
+		values     map[string]*flagValue
+	}
+
+	// setting describes one EnvVars field.
+	setting struct {
+		key    string
+		index  int
+		field  reflect.StructField
+		secret bool
+		reload bool
+	}
+
+	flagValue struct {
+		value string
+		kind  string
+		set   bool
+	}
+
+	// boolFlagValue lets --flag stand for --flag=true.
+	boolFlagValue struct {
+		*flagValue
+	}
+)
+
+// NewLoader registers the --config and --env-file flags plus one flag per
+// setting (server_port becomes --server-port) on fs. The caller parses fs,
+// directly or through a CLI library wrapping it.
+func NewLoader(fs *flag.FlagSet) *Loader {
+	l := &Loader{
+		values: make(map[string]*flagValue),
+	}
+
+	fs.StringVar(&l.configPath, "config", "", "path to a YAML or TOML config file")
+	fs.StringVar(&l.envFile, "env-file", defaultEnvFile, "path to a .env file")
+
+	for _, s := range settings() {
+		// the default is only shown in help, Load applies it separately
+		value := &flagValue{
+			value: s.field.Tag.Get("default"),
+			kind:  kindName(s.field.Type),
+		}
+		l.values[s.key] = value
+
+		name := strings.ReplaceAll(s.key, "_", "-")
+		if s.field.Type.Kind() == reflect.Bool {
+			if value.value == "" {
+				value.value = "false"
+			}
+			fs.Var(boolFlagValue{value}, name, s.key)
+		} else {
+			fs.Var(value, name, s.key)
+		}
+	}
+
+	return l
+}
+
+// Load reads every source and returns the merged settings. Values are not
+// validated, call Validate for that.
+func (l *Loader) Load() (EnvVars, error) {
+	var (
+		envVars = EnvVars{}
+		values  = make(map[string]string)
+		sources = make(map[string]string)
+	)
+
+	set := func(source string, from map[string]string) {
+		for key, value := range from {
+			values[key] = value
+			sources[key] = source
+		}
+	}
+
+	defaults := make(map[string]string)
+	for _, s := range settings() {
+		if def, ok := s.field.Tag.Lookup("default"); ok {
+			defaults[s.key] = def
+		}
+	}
+	set("default", defaults)
+
+	if l.configPath != "" {
+		fromFile, err := readConfigFile(l.configPath)
+		if err != nil {
+			return envVars, err
+		}
+		set(l.configPath, fromFile)
+	}
+
+	fromEnvFile, err := readEnvFile(l.envFile, l.envFile != defaultEnvFile)
+	if err != nil {
+		return envVars, err
+	}
+	set(l.envFile, fromEnvFile)
+
+	set("environment", readEnv(os.LookupEnv))
+
+	fromFlags := make(map[string]string)
+	for key, value := range l.values {
+		if value.set {
+			fromFlags[key] = value.value
+		}
+	}
+	set("flags", fromFlags)
+
+	target := reflect.ValueOf(&envVars).Elem()
+	var errs []error
+	for _, s := range settings() {
+		value, ok := values[s.key]
+		if !ok {
+			continue
+		}
+		if err := setValue(target.Field(s.index), value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q from %s: %w", s.key, value, sources[s.key], err))
+		}
+	}
+
+	return envVars, errors.Join(errs...)
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.value
+}
+
+func (v *flagValue) Set(value string) error {
+	v.value = value
+	v.set = true
+	return nil
+}
+
+func (v boolFlagValue) IsBoolFlag() bool {
+	return true
+}
+
+// Type names the value in help output of CLI libraries such as pflag.
+func (v *flagValue) Type() string {
+	return v.kind
+}
+
+func kindName(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		return "strings"
+	case reflect.Float64:
+		return "float"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// settings lists EnvVars fields in declaration order.
+func settings() []setting {
+	t := reflect.TypeOf(EnvVars{})
+	list := make([]setting, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("config")
+		if key == "" {
+			continue
+		}
+		list = append(list, setting{
+			key:    key,
+			index:  i,
+			field:  field,
+			secret: field.Tag.Get("secret") == "true",
+			reload: field.Tag.Get("reload") == "true",
+		})
+	}
+	return list
+}
+
+// readConfigFile reads a YAML or TOML file, picked by extension. Nested
+// tables are flattened with "_", so {db: {host: x}} sets db_host.
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	raw := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q, use .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten(values, "", raw)
+
+	known := make(map[string]bool)
+	for _, s := range settings() {
+		known[s.key] = true
+	}
+	var unknown []string
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("config file %s: unknown settings %s", path, strings.Join(unknown, ", "))
+	}
+	return values, nil
+}
+
+func flatten(values map[string]string, prefix string, raw map[string]any) {
+	for key, value := range raw {
+		key = strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch value := value.(type) {
+		case map[string]any:
+			flatten(values, key, value)
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// readEnvFile reads KEY=value pairs without exporting them to the process.
+// A missing file is only an error when it was asked for explicitly.
+func readEnvFile(path string, required bool) (map[string]string, error) {
+	env, err := godotenv.Read(path)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read env file: %w", err)
+	}
+	return readEnv(func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}), nil
+}
+
+func readEnv(lookup func(string) (string, bool)) map[string]string {
+	values := make(map[string]string)
+	for _, s := range settings() {
+		name := strings.ToUpper(s.key)
+		if value, ok := lookup(EnvPrefix + "_" + name); ok {
+			values[s.key] = value
+		} else if value, ok := lookup(name); ok {
+			values[s.key] = value
+		}
+	}
+	return values
+}
+
+func setValue(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		items := make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testLoad loads settings from a config file, an env file and flags, the
+// process environment is set by the caller.
+func testLoad(t *testing.T, configFile, envFile string, args ...string) (EnvVars, error) {
+	t.Helper()
+	dir := t.TempDir()
+	if configFile != "" {
+		path := filepath.Join(dir, "config.yaml")
+		if err := os.WriteFile(path, []byte(configFile), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, "--config", path)
+	}
+	path := filepath.Join(dir, ".env")
+	if err := os.WriteFile(path, []byte(envFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	args = append(args, "--env-file", path)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := NewLoader(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return loader.Load()
+}
+
+func TestLoaderPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		envFile    string
+		env        map[string]string
+		args       []string
+		want       int
+	}{
+		{
+			name: "default",
+			want: 3000,
+		},
+		{
+			name:       "config file over default",
+			configFile: "server_port: 3001\n",
+			want:       3001,
+		},
+		{
+			name:       "nested config file keys",
+			configFile: "server:\n  port: 3001\n",
+			want:       3001,
+		},
+		{
+			name:       "env file over config file",
+			configFile: "server_port: 3001\n",
+			envFile:    "SERVER_PORT=3002\n",
+			want:       3002,
+		},
+		{
+			name:       "environment over env file",
+			configFile: "server_port: 3001\n",
+			envFile:    "SERVER_PORT=3002\n",
+			env:        map[string]string{"SERVER_PORT": "3003"},
+			want:       3003,
+		},
+		{
+			name: "prefixed environment over unprefixed",
+			env:  map[string]string{"SERVER_PORT": "3003", EnvPrefix + "_SERVER_PORT": "3004"},
+			want: 3004,
+		},
+		{
+			name:       "flags over everything",
+			configFile: "server_port: 3001\n",
+			envFile:    "SERVER_PORT=3002\n",
+			env:        map[string]string{"SERVER_PORT": "3003"},
+			args:       []string{"--server-port", "3005"},
+			want:       3005,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// t.Setenv restores the variables after the test
+			for _, key := range []string{"SERVER_PORT", EnvPrefix + "_SERVER_PORT"} {
+				t.Setenv(key, "")
+				_ = os.Unsetenv(key)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			envVars, err := testLoad(t, tt.configFile, tt.envFile, tt.args...)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if envVars.ServerPort != tt.want {
+				t.Errorf("got server_port %d, want %d", envVars.ServerPort, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		envFile    string
+		wantErr    string
+	}{
+		{
+			name:       "unknown config file setting",
+			configFile: "server_prot: 3001\n",
+			wantErr:    "unknown settings server_prot",
+		},
+		{
+			name:    "invalid value names its source",
+			envFile: "CACHE_TTL=soon\n",
+			wantErr: `cache_ttl: invalid value "soon" from`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testLoad(t, tt.configFile, tt.envFile)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
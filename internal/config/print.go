@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const redacted = "******"
+
+// Print writes settings as "key: value" lines, secrets are redacted.
+func Print(w io.Writer, envVars EnvVars) error {
+	value := reflect.ValueOf(envVars)
+	for _, s := range settings() {
+		text := formatValue(value.Field(s.index))
+		if s.secret && text != "" {
+			text = redacted
+		}
+		if _, err := fmt.Fprintf(w, "%s: %q\n", s.key, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatValue(field reflect.Value) string {
+	if field.Kind() == reflect.Slice {
+		items := make([]string, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			items = append(items, fmt.Sprint(field.Index(i).Interface()))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(field.Interface())
+}
@@ -0,0 +1,22 @@
+package config
+
+import "reflect"
+
+// RestartRequired lists keys of settings that differ between old and new but
+// cannot be applied to a running app.
+func RestartRequired(old, new EnvVars) []string {
+	var (
+		oldValue = reflect.ValueOf(old)
+		newValue = reflect.ValueOf(new)
+		keys     []string
+	)
+	for _, s := range settings() {
+		if s.reload {
+			continue
+		}
+		if !reflect.DeepEqual(oldValue.Field(s.index).Interface(), newValue.Field(s.index).Interface()) {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validate checks settings against their validate tags and reports every
+// problem by setting key, e.g. "server_port: must be at least 1".
+func Validate(envVars EnvVars) error {
+	keys := make(map[string]string)
+	for _, s := range settings() {
+		keys[s.field.Name] = s.key
+	}
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("config")
+	})
+
+	err := validate.Struct(envVars)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	errs := make([]error, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		errs = append(errs, fmt.Errorf("%s: %s", fieldErr.Field(), describe(fieldErr, keys)))
+	}
+	return errors.Join(errs...)
+}
+
+func describe(fieldErr validator.FieldError, keys map[string]string) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required unless " + keys[param] + " is set"
+	case "min":
+		return "must be at least " + param
+	case "max":
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "gtfield":
+		if key, ok := keys[param]; ok {
+			param = key
+		}
+		return "must be greater than " + param
+	default:
+		return fmt.Sprintf("failed %q check", fieldErr.Tag())
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(envVars *EnvVars)
+		wantErr string
+	}{
+		{
+			name:   "defaults with a DSN",
+			change: func(envVars *EnvVars) {},
+		},
+		{
+			name: "host settings instead of a DSN",
+			change: func(envVars *EnvVars) {
+				envVars.DatabaseDSN = ""
+				envVars.DatabaseHost = "localhost"
+				envVars.DatabaseName = "postgres"
+				envVars.DatabaseUsername = "postgres"
+			},
+		},
+		{
+			name:    "neither a DSN nor a host",
+			change:  func(envVars *EnvVars) { envVars.DatabaseDSN = "" },
+			wantErr: "db_host: is required unless db_dsn is set",
+		},
+		{
+			name:    "port out of range",
+			change:  func(envVars *EnvVars) { envVars.ServerPort = 70000 },
+			wantErr: "server_port: must be at most 65535",
+		},
+		{
+			name:    "unknown log level",
+			change:  func(envVars *EnvVars) { envVars.LogLevel = "trace" },
+			wantErr: "log_level: must be one of debug, info, warn, error",
+		},
+		{
+			name:    "zero duration",
+			change:  func(envVars *EnvVars) { envVars.CacheTTL = 0 },
+			wantErr: "cache_ttl: must be greater than 0",
+		},
+		{
+			name:    "lease not longer than the heartbeat",
+			change:  func(envVars *EnvVars) { envVars.JobsLeaseTimeout = envVars.JobsHeartbeatInterval },
+			wantErr: "jobs_lease_timeout: must be greater than jobs_heartbeat_interval",
+		},
+		{
+			name: "every problem is reported",
+			change: func(envVars *EnvVars) {
+				envVars.ServerPort = 0
+				envVars.CacheSize = 0
+				envVars.DatabaseConnectBackoff = -time.Second
+			},
+			wantErr: "server_port: must be at least 1\ndb_connect_backoff: must be greater than 0\ncache_size: must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envVars, err := testLoad(t, "", "")
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			envVars.DatabaseDSN = "postgres://postgres@localhost/postgres"
+			tt.change(&envVars)
+
+			err = Validate(envVars)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("got error %q, want none", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("got error %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Package attributes checks custom product attributes against the JSON
+// Schemas of the categories the product is in.
+package attributes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+)
+
+const schemaURL = "attribute_schema.json"
+
+// Compile parses schema as a JSON Schema. Schemas have to be self-contained,
+// references to other documents are refused.
+func Compile(schema map[string]any) (*jsonschema.Schema, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("loading %s is not allowed", url)
+	}
+	if err = compiler.AddResource(schemaURL, bytes.NewReader(raw)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(schemaURL)
+}
+
+// Validate checks attributes against every schema, a product in several
+// categories has to satisfy all of them. Schemas are expected to be valid,
+// they are compiled before they are stored.
+func Validate(schemas []map[string]any, attributes map[string]any) error {
+	if len(schemas) == 0 {
+		return nil
+	}
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	// the validator panics on Go types JSON does not decode to, so the
+	// attributes are decoded the way it expects whatever they came from
+	raw, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	for _, schema := range schemas {
+		compiled, err := Compile(schema)
+		if err != nil {
+			return fmt.Errorf("compile attribute schema error: %w", err)
+		}
+		if err = compiled.Validate(value); err != nil {
+			return fmt.Errorf("%w: %s", productsDomain.ErrInvalidAttributes, err)
+		}
+	}
+	return nil
+}
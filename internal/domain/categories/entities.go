@@ -0,0 +1,92 @@
+package categories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a node of the category tree. AttributeSchema is a JSON Schema
+// the attributes of products in the category and its descendants have to
+// satisfy.
+type Category struct {
+	ID              uuid.UUID      `json:"id"`
+	ParentID        *uuid.UUID     `json:"parent_id"`
+	Name            string         `json:"name"`
+	Slug            string         `json:"slug"`
+	Depth           int            `json:"depth"`
+	Position        int            `json:"position"`
+	AttributeSchema map[string]any `json:"attribute_schema,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// GetCategoriesDTO lists the whole tree ordered by depth and position, or
+// only the children of ParentID.
+type GetCategoriesDTO struct {
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+type GetCategoryDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type CreateCategoryDTO struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+	Name     string     `json:"name" validate:"required,max=250"`
+	Slug     string     `json:"slug" validate:"required,max=250"`
+	// Position defaults to after the last sibling.
+	Position        *int           `json:"position,omitempty" validate:"omitempty,min=0"`
+	AttributeSchema map[string]any `json:"attribute_schema,omitempty"`
+}
+
+// PartialUpdateCategoryDTO replaces the attribute schema when one is given,
+// products already in the category are not checked against it.
+type PartialUpdateCategoryDTO struct {
+	ID              uuid.UUID      `json:"id"`
+	Name            string         `json:"name,omitempty" validate:"max=250"`
+	Slug            string         `json:"slug,omitempty" validate:"max=250"`
+	AttributeSchema map[string]any `json:"attribute_schema,omitempty"`
+}
+
+type DeleteCategoryDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// MoveCategoryDTO re-parents a category with its whole subtree, a nil
+// ParentID makes it a root. Siblings at or after Position are shifted down.
+type MoveCategoryDTO struct {
+	ID       uuid.UUID  `json:"id"`
+	ParentID *uuid.UUID `json:"parent_id"`
+	Position *int       `json:"position,omitempty" validate:"omitempty,min=0"`
+}
+
+// ReorderCategoriesDTO sets the order of the children of ParentID, IDs
+// must all be its children.
+type ReorderCategoriesDTO struct {
+	ParentID *uuid.UUID  `json:"parent_id"`
+	IDs      []uuid.UUID `json:"ids" validate:"required,min=1,max=1000"`
+}
+
+type GetCategoryProductsDTO struct {
+	ID     uuid.UUID `json:"id"`
+	Limit  int64     `json:"limit,omitempty"`
+	Offset int64     `json:"offset,omitempty"`
+	// PublishedOnly leaves out products that are not published.
+	PublishedOnly bool `json:"published_only,omitempty"`
+}
+
+type GetProductCategoriesDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// GetProductsCategoriesDTO gets the categories of many products at once.
+type GetProductsCategoriesDTO struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
+
+// SetProductCategoriesDTO replaces the categories a product is assigned to.
+type SetProductCategoriesDTO struct {
+	ProductID   uuid.UUID   `json:"product_id"`
+	CategoryIDs []uuid.UUID `json:"category_ids" validate:"max=100"`
+}
@@ -0,0 +1,13 @@
+package categories
+
+import "errors"
+
+var (
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrCategorySlugTaken      = errors.New("category slug already used by a sibling")
+	ErrCategoryCycle          = errors.New("category cannot be moved under itself or its descendants")
+	ErrCategoryHasChildren    = errors.New("category has child categories")
+	ErrCategoryNotChild       = errors.New("category is not a child of the given parent")
+	ErrInvalidAttributeSchema = errors.New("invalid attribute schema")
+)
@@ -0,0 +1,116 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservation statuses, only active reservations hold stock.
+const (
+	ReservationActive    = "active"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// ReasonSale is recorded for stock leaving through committed reservations,
+// the other reasons are given with manual adjustments.
+const ReasonSale = "sale"
+
+type Warehouse struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StockLevel is the stock of a product in a warehouse. Reserved units are
+// still on hand but can not be reserved again, Available is what is left.
+type StockLevel struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	OnHand      int64     `json:"on_hand"`
+	Reserved    int64     `json:"reserved"`
+	Available   int64     `json:"available"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type Reservation struct {
+	ID          uuid.UUID `json:"id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    int64     `json:"quantity"`
+	Status      string    `json:"status"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Adjustment is an entry of the stock ledger, ReservationID is set for
+// stock leaving through a committed reservation.
+type Adjustment struct {
+	ID            int64      `json:"id"`
+	ProductID     uuid.UUID  `json:"product_id"`
+	WarehouseID   uuid.UUID  `json:"warehouse_id"`
+	Delta         int64      `json:"delta"`
+	Reason        string     `json:"reason"`
+	Note          string     `json:"note"`
+	ReservationID *uuid.UUID `json:"reservation_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type GetWarehousesDTO struct{}
+
+type CreateWarehouseDTO struct {
+	Code string `json:"code" validate:"required,max=50"`
+	Name string `json:"name" validate:"required,max=250"`
+}
+
+type GetProductStockDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// AdjustStockDTO changes the units on hand by Delta, the first adjustment
+// of a product in a warehouse creates its stock level.
+type AdjustStockDTO struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id" validate:"required"`
+	Delta       int64     `json:"delta" validate:"required"`
+	Reason      string    `json:"reason" validate:"required,oneof=received returned damaged lost recount correction"`
+	Note        string    `json:"note,omitempty" validate:"max=1000"`
+}
+
+type GetStockAdjustmentsDTO struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty"`
+	Limit       int64      `json:"limit,omitempty"`
+	Offset      int64      `json:"offset,omitempty"`
+}
+
+// ReserveStockDTO holds Quantity units until ExpiresAt. ExpiresIn is given
+// by clients in seconds, zero uses the configured default.
+type ReserveStockDTO struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id" validate:"required"`
+	Quantity    int64     `json:"quantity" validate:"min=1"`
+	ExpiresIn   int64     `json:"expires_in,omitempty" validate:"min=0,max=604800"`
+	ExpiresAt   time.Time `json:"-"`
+}
+
+type GetReservationDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type ReleaseReservationDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type CommitReservationDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// ExpireReservationsDTO expires up to Limit overdue reservations.
+type ExpireReservationsDTO struct {
+	Limit int64 `json:"limit"`
+}
@@ -0,0 +1,11 @@
+package inventory
+
+import "errors"
+
+var (
+	ErrWarehouseNotFound    = errors.New("warehouse not found")
+	ErrWarehouseCodeTaken   = errors.New("warehouse code is already taken")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationNotActive = errors.New("reservation is no longer active")
+)
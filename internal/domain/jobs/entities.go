@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCanceled  = "canceled"
+
+	DefaultMaxAttempts = 3
+)
+
+type Job struct {
+	ID                  uuid.UUID       `json:"id"`
+	Kind                string          `json:"kind"`
+	Status              string          `json:"status"`
+	Payload             json.RawMessage `json:"-"`
+	Result              json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Progress            Progress        `json:"progress"`
+	Attempts            int             `json:"attempts"`
+	MaxAttempts         int             `json:"max_attempts"`
+	LastError           *string         `json:"last_error"`
+	CancelRequested     bool            `json:"cancel_requested"`
+	HasArtifact         bool            `json:"has_artifact"`
+	ArtifactName        *string         `json:"artifact_name"`
+	ArtifactContentType *string         `json:"artifact_content_type"`
+	RunAt               time.Time       `json:"run_at"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+	StartedAt           *time.Time      `json:"started_at"`
+	FinishedAt          *time.Time      `json:"finished_at"`
+}
+
+type Progress struct {
+	Done  int64 `json:"done"`
+	Total int64 `json:"total"`
+}
+
+// Artifact is a file produced by a job, kept in the file storage under Key.
+type Artifact struct {
+	Name        string
+	ContentType string
+	Key         string
+}
+
+// ArtifactFile is an artifact opened for download, Body has to be closed by
+// the caller.
+type ArtifactFile struct {
+	Name        string
+	ContentType string
+	Body        io.ReadCloser
+}
+
+// Outcome is what a job handler produces on success.
+type Outcome struct {
+	Result   any
+	Artifact *Artifact
+}
+
+func (j Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed || j.Status == StatusCanceled
+}
+
+type EnqueueJobDTO struct {
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	MaxAttempts int             `json:"max_attempts"`
+}
+
+type GetJobDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type CancelJobDTO struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type ClaimJobDTO struct {
+	WorkerID     string
+	Kinds        []string
+	LeaseTimeout time.Duration
+}
+
+type HeartbeatJobDTO struct {
+	ID       uuid.UUID
+	WorkerID string
+	Progress Progress
+}
+
+type FinishJobDTO struct {
+	ID       uuid.UUID
+	WorkerID string
+	Status   string
+	Progress Progress
+	Result   json.RawMessage
+	Artifact *Artifact
+	Error    string
+	// Retry puts the job back to the queue to run again after RetryAfter
+	// instead of finishing it.
+	Retry      bool
+	RetryAfter time.Duration
+}
+
+// ArtifactKey is where the artifact of a job is stored, attempts of the
+// same job replace each other's.
+func ArtifactKey(id uuid.UUID) string {
+	return "jobs/" + id.String() + "/artifact"
+}
@@ -0,0 +1,10 @@
+package jobs
+
+import "errors"
+
+var (
+	ErrJobNotFound         = errors.New("job not found")
+	ErrJobFinished         = errors.New("job already finished")
+	ErrJobArtifactNotFound = errors.New("job artifact not found")
+	ErrJobLeaseLost        = errors.New("job lease lost")
+)
@@ -0,0 +1,151 @@
+package media
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobKindThumbnails = "media.thumbnails"
+
+	StatusProcessing = "processing"
+	StatusReady      = "ready"
+	StatusFailed     = "failed"
+
+	// Original names the uploaded file among the files of a media, the
+	// thumbnails are named by their size.
+	Original = "original"
+
+	// MaxPixels bounds the decoded size of uploaded images.
+	MaxPixels = 50_000_000
+)
+
+// ContentTypes lists the image types that can be uploaded.
+var ContentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+}
+
+// Media is an image in the gallery of a product. Thumbnails are generated
+// in the background, until then Status is processing.
+type Media struct {
+	ID          uuid.UUID   `json:"id"`
+	ProductID   uuid.UUID   `json:"product_id"`
+	ContentType string      `json:"content_type"`
+	FileSize    int64       `json:"file_size"`
+	Width       int         `json:"width"`
+	Height      int         `json:"height"`
+	Position    int32       `json:"position"`
+	Status      string      `json:"status"`
+	URL         string      `json:"url"`
+	Thumbnails  []Thumbnail `json:"thumbnails"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Thumbnail is a scaled down copy of a media fitting in a Size by Size box.
+type Thumbnail struct {
+	Size        int    `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+	URL         string `json:"url"`
+}
+
+// File is a stored file of a media, Body has to be closed by the caller.
+type File struct {
+	ContentType string
+	FileSize    int64
+	Body        io.ReadCloser
+}
+
+type UploadMediaDTO struct {
+	ProductID uuid.UUID
+	Body      io.Reader
+}
+
+type CreateMediaDTO struct {
+	ID          uuid.UUID
+	ProductID   uuid.UUID
+	ContentType string
+	FileSize    int64
+	Width       int
+	Height      int
+}
+
+type GetMediaDTO struct {
+	ID uuid.UUID `json:"id"`
+	// WithDeletedProduct also finds media of soft-deleted products
+	WithDeletedProduct bool `json:"-"`
+}
+
+type GetProductMediaDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+// GetProductsMediaDTO gets the galleries of many products at once.
+type GetProductsMediaDTO struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
+
+// SetMediaOrderDTO orders the gallery of a product, IDs has to list all of
+// its media.
+type SetMediaOrderDTO struct {
+	ProductID uuid.UUID   `json:"product_id"`
+	IDs       []uuid.UUID `json:"ids" validate:"required,unique"`
+}
+
+type DeleteMediaDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	ID        uuid.UUID `json:"id"`
+}
+
+type GetMediaFileDTO struct {
+	ID uuid.UUID `json:"id"`
+	// Name is Original or the size of a thumbnail
+	Name string `json:"name" validate:"required"`
+}
+
+type GenerateThumbnailsDTO struct {
+	MediaID uuid.UUID `json:"media_id"`
+}
+
+type SetThumbnailsDTO struct {
+	ID         uuid.UUID
+	Status     string
+	Thumbnails []Thumbnail
+}
+
+type GetOrphanedMediaDTO struct {
+	Limit int64
+}
+
+type PurgeMediaDTO struct {
+	IDs []uuid.UUID
+}
+
+// Key is where the file of a media is stored.
+func Key(id uuid.UUID, name string) string {
+	return Prefix(id) + name
+}
+
+// Prefix is shared by the keys of all files of a media.
+func Prefix(id uuid.UUID) string {
+	return id.String() + "/"
+}
+
+// URL is where the file of a media is served.
+func URL(id uuid.UUID, name string) string {
+	return "/api/media/" + id.String() + "/" + name
+}
+
+// ThumbnailName names the thumbnail of size among the files of a media.
+func ThumbnailName(size int) string {
+	return strconv.Itoa(size)
+}
@@ -0,0 +1,11 @@
+package media
+
+import "errors"
+
+var (
+	ErrMediaNotFound        = errors.New("media not found")
+	ErrMediaTooLarge        = errors.New("media is too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type, expected a jpeg, png, gif or webp image")
+	ErrInvalidImage         = errors.New("invalid image")
+	ErrInvalidMediaOrder    = errors.New("media order must list every media of the product once")
+)
@@ -0,0 +1,71 @@
+package prices
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Money is an amount in minor units of the currency, e.g. cents for USD.
+// Prices are never floats.
+type Money struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+// Price is valid from ValidFrom until ValidTo, a nil ValidTo means until it
+// is replaced. Prices of one product and currency never overlap.
+type Price struct {
+	ID        uuid.UUID  `json:"id"`
+	ProductID uuid.UUID  `json:"product_id"`
+	Currency  string     `json:"currency"`
+	Amount    int64      `json:"amount"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+	Current   bool       `json:"current"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PriceChange is an entry of the price history.
+type PriceChange struct {
+	PriceID   uuid.UUID  `json:"price_id"`
+	Currency  string     `json:"currency"`
+	Amount    int64      `json:"amount"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+	Operation string     `json:"operation"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+type GetProductPricesDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Currency  string    `json:"currency,omitempty" validate:"omitempty,iso4217"`
+}
+
+// GetProductsPricesDTO gets the prices of many products at once.
+type GetProductsPricesDTO struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
+
+type GetProductPriceHistoryDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Currency  string    `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Limit     int64     `json:"limit,omitempty"`
+	Offset    int64     `json:"offset,omitempty"`
+}
+
+// SetProductPriceDTO schedules Amount for [ValidFrom, ValidTo). Existing
+// prices in that range are cut back, split or removed to make room. A zero
+// ValidFrom means now.
+type SetProductPriceDTO struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	Currency  string     `json:"currency" validate:"required,iso4217"`
+	Amount    int64      `json:"amount" validate:"min=0"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+}
+
+type DeleteProductPriceDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	ID        uuid.UUID `json:"id"`
+}
@@ -0,0 +1,9 @@
+package prices
+
+import "errors"
+
+var (
+	ErrPriceNotFound     = errors.New("price not found")
+	ErrInvalidPriceRange = errors.New("price valid_to must be after valid_from")
+	ErrPriceConflict     = errors.New("price overlaps a concurrent change, retry")
+)
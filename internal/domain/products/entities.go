@@ -1,7 +1,14 @@
 package products
 
 import (
+	"fmt"
 	"github.com/google/uuid"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	mediaDomain "go_template_project/internal/domain/media"
+	pricesDomain "go_template_project/internal/domain/prices"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -12,30 +19,236 @@ type Product struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
+	// Status is the publication status, PublishAt and UnpublishAt are when
+	// the scheduler publishes and unpublishes the product.
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	// Attributes are custom fields checked against the attribute schemas of
+	// the product's categories.
+	Attributes map[string]any `json:"attributes"`
+	// Price is the current price in the currency a list was asked for.
+	Price *pricesDomain.Money `json:"price,omitempty"`
+	// Locale is the locale Name and Title were translated to, empty when
+	// they are the untranslated ones.
+	Locale string `json:"locale,omitempty"`
+	// Categories, Prices, Tags, Media and Variants are only loaded when
+	// expanded, and left out when the product has none.
+	Categories []categoriesDomain.Category `json:"categories,omitempty"`
+	Prices     []pricesDomain.Price        `json:"prices,omitempty"`
+	Tags       []string                    `json:"tags,omitempty"`
+	Media      []mediaDomain.Media         `json:"media,omitempty"`
+	Variants   []variantsDomain.Variant    `json:"variants,omitempty"`
+}
+
+// Translation is the name and title of a product in one locale.
+type Translation struct {
+	Name  string `json:"name" validate:"required,max=250"`
+	Title string `json:"title" validate:"required,max=250"`
+}
+
+type ProductTranslation struct {
+	Locale    string    `json:"locale"`
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductFields lists the fields product reads can be narrowed to.
+var ProductFields = []string{
+	"id", "name", "title", "created_at", "updated_at", "deleted_at", "status", "publish_at", "unpublish_at", "attributes",
+}
+
+const (
+	ExpandCategories = "categories"
+	ExpandPrices     = "prices"
+	ExpandTags       = "tags"
+	ExpandMedia      = "media"
+	ExpandVariants   = "variants"
+)
+
+// ProductExpansions lists the related resources product reads can embed,
+// each under the product field of the same name.
+var ProductExpansions = []string{ExpandCategories, ExpandPrices, ExpandTags, ExpandMedia, ExpandVariants}
+
+// CheckFields reports the first field that is not in ProductFields.
+func CheckFields(fields []string) error {
+	for _, name := range fields {
+		if !slices.Contains(ProductFields, name) {
+			return fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+	}
+	return nil
+}
+
+// CheckExpand reports the first expansion that is not supported.
+func CheckExpand(expand []string) error {
+	for _, name := range expand {
+		if !slices.Contains(ProductExpansions, name) {
+			return fmt.Errorf("%w: %s", ErrUnknownExpansion, name)
+		}
+	}
+	return nil
 }
 
 type GetProductsDTO struct {
-	Limit  int64  `json:"limit,omitempty"`
-	Offset int64  `json:"offset,omitempty"`
-	Name   string `json:"name,omitempty"`
-	Title  string `json:"title,omitempty"`
+	Limit    int64      `json:"limit,omitempty"`
+	Offset   int64      `json:"offset,omitempty"`
+	Fields   []string   `json:"fields,omitempty"`
+	Expand   []string   `json:"expand,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Title    string     `json:"title,omitempty"`
+	Category *uuid.UUID `json:"category,omitempty"`
+	Currency string     `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	PriceMin *int64     `json:"price_min,omitempty" validate:"omitempty,min=0"`
+	PriceMax *int64     `json:"price_max,omitempty" validate:"omitempty,min=0"`
+	Sort     string     `json:"sort,omitempty" validate:"omitempty,oneof=name -name created_at -created_at price -price"`
+	// Attributes matches products whose attributes have these values, a
+	// value that reads as a number or boolean also matches it typed.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// TagsAll matches products carrying every tag, TagsAny products
+	// carrying at least one. Both hold normalized tag names.
+	TagsAll []string `json:"tags_all,omitempty" validate:"max=20,dive,max=100"`
+	TagsAny []string `json:"tags_any,omitempty" validate:"max=20,dive,max=100"`
+	// Statuses matches products in any of the publication statuses.
+	Statuses []string `json:"statuses,omitempty" validate:"dive,oneof=draft in_review published archived"`
+	// PublishedOnly leaves out products that are not published, public
+	// reads set it and admin reads do not.
+	PublishedOnly bool `json:"published_only,omitempty"`
+	// Query searches names and titles, untranslated and in Locales.
+	Query string `json:"query,omitempty" validate:"max=250"`
+	// Locales is the fallback chain names and titles are translated with,
+	// the first locale a product has a translation in wins.
+	Locales []string `json:"locales,omitempty"`
+}
+
+// CheckPriceFilter reports price filters or sorting used without a currency.
+func CheckPriceFilter(data GetProductsDTO) error {
+	if data.Currency != "" {
+		return nil
+	}
+	if data.PriceMin != nil || data.PriceMax != nil || strings.TrimPrefix(data.Sort, "-") == "price" {
+		return ErrCurrencyRequired
+	}
+	return nil
+}
+
+// ProductsStats summarises the set matched by list filters, it is cheap to
+// compute and changes whenever any product in the set does.
+type ProductsStats struct {
+	Count         int64      `json:"count"`
+	LastUpdatedAt *time.Time `json:"last_updated_at"`
 }
 
 type GetProductDTO struct {
-	ID uuid.UUID `json:"id"`
+	ID      uuid.UUID `json:"id"`
+	Fields  []string  `json:"fields,omitempty"`
+	Expand  []string  `json:"expand,omitempty"`
+	Locales []string  `json:"locales,omitempty"`
+	// PublishedOnly treats products that are not published as not found.
+	PublishedOnly bool `json:"published_only,omitempty"`
+}
+
+type GetProductsByIDsDTO struct {
+	IDs     []uuid.UUID `json:"ids" validate:"required,min=1,max=100"`
+	Locales []string    `json:"-"`
+	// PublishedOnly leaves out products that are not published.
+	PublishedOnly bool `json:"-"`
+}
+
+type BatchGetProductsResult struct {
+	Products []Product   `json:"products"`
+	Missing  []uuid.UUID `json:"missing"`
 }
 
+// CreateProductDTO assigns the product to CategoryIDs and stores its
+// Translations, keyed by locale, in the same statement. Its attributes are
+// checked against the category schemas first.
 type CreateProductDTO struct {
-	Name  string `json:"name"`
-	Title string `json:"title"`
+	Name         string                 `json:"name"`
+	Title        string                 `json:"title"`
+	Attributes   map[string]any         `json:"attributes,omitempty"`
+	CategoryIDs  []uuid.UUID            `json:"category_ids,omitempty" validate:"max=100"`
+	Translations map[string]Translation `json:"translations,omitempty" validate:"max=50,dive,keys,bcp47_language_tag,endkeys,required"`
 }
 
+// PartialUpdateProductDTO replaces all attributes when Attributes is given.
+// Translations are set per locale, a null translation removes the locale.
 type PartialUpdateProductDTO struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name,omitempty"`
-	Title string    `json:"title,omitempty"`
+	ID           uuid.UUID               `json:"id"`
+	Name         string                  `json:"name,omitempty"`
+	Title        string                  `json:"title,omitempty"`
+	Attributes   map[string]any          `json:"attributes,omitempty"`
+	Translations map[string]*Translation `json:"translations,omitempty" validate:"max=50,dive,keys,bcp47_language_tag,endkeys,omitnil"`
+}
+
+type GetProductTranslationsDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
 }
 
+// GetMissingTranslationsDTO lists products lacking a translation in any of
+// Locales, soft-deleted ones are left out.
+type GetMissingTranslationsDTO struct {
+	Locales []string `json:"locales" validate:"required,max=50,dive,bcp47_language_tag"`
+	Limit   int64    `json:"limit"`
+	Offset  int64    `json:"offset"`
+}
+
+type MissingTranslation struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	Name           string    `json:"name"`
+	Title          string    `json:"title"`
+	MissingLocales []string  `json:"missing_locales"`
+}
+
+// DeleteProductDTO soft deletes a product, it disappears from every read
+// and is removed for good by a later purge.
 type DeleteProductDTO struct {
 	ID uuid.UUID `json:"id"`
 }
+
+// PurgeDeletedProductsDTO removes soft-deleted products for good.
+type PurgeDeletedProductsDTO struct {
+	DeletedBefore time.Time `json:"deleted_before" validate:"required"`
+}
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+)
+
+// ExportContentTypes maps export formats to their media types.
+var ExportContentTypes = map[string]string{
+	ExportFormatCSV:    "text/csv; charset=utf-8",
+	ExportFormatNDJSON: "application/x-ndjson",
+	ExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ImportFormats lists the formats products can be imported from, the
+// columns are the same as for export, unknown ones are ignored.
+var ImportFormats = []string{ExportFormatCSV, ExportFormatNDJSON}
+
+type ImportProductsDTO struct {
+	Format string `json:"format" validate:"required,oneof=csv ndjson"`
+}
+
+// ExportColumns lists the columns available for export in their default order.
+var ExportColumns = []string{"id", "name", "title", "created_at", "updated_at", "deleted_at"}
+
+// ExportProductsDTO exports every product matched by the list filters in
+// Filter, its page, sort, fields and expand are not used.
+type ExportProductsDTO struct {
+	Format  string         `json:"format" validate:"required,oneof=csv ndjson xlsx"`
+	Columns []string       `json:"columns" validate:"dive,oneof=id name title created_at updated_at deleted_at"`
+	Filter  GetProductsDTO `json:"filter"`
+}
+
+// Background job kinds for long-running product operations.
+const (
+	JobKindBulkCreate = "products.bulk_create"
+	JobKindBulkUpdate = "products.bulk_update"
+	JobKindBulkTag    = "products.bulk_tag"
+	JobKindBulkUntag  = "products.bulk_untag"
+	JobKindExport     = "products.export"
+)
@@ -3,5 +3,13 @@ package products
 import "errors"
 
 var (
-	ErrProductNotFound = errors.New("product not found")
+	ErrProductNotFound     = errors.New("product not found")
+	ErrUnknownField        = errors.New("unknown field")
+	ErrUnknownExpansion    = errors.New("unknown expansion")
+	ErrCurrencyRequired    = errors.New("currency is required to filter or sort by price")
+	ErrInvalidAttributes   = errors.New("attributes do not match the category schemas")
+	ErrInvalidLocale       = errors.New("invalid locale")
+	ErrInvalidPriceBuckets = errors.New("price facet needs strictly ascending price buckets")
+	ErrIllegalTransition   = errors.New("illegal status transition")
+	ErrInvalidSchedule     = errors.New("unpublish_at must be after publish_at")
 )
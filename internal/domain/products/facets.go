@@ -0,0 +1,92 @@
+package products
+
+import "slices"
+
+const (
+	FacetCategory = "category"
+	FacetTag      = "tag"
+	FacetPrice    = "price"
+	FacetStatus   = "status"
+)
+
+// FacetNames lists the facets in the order they are computed when none are
+// asked for. The price facet is only computed by default when it can be,
+// that is with a currency and price buckets.
+var FacetNames = []string{FacetCategory, FacetTag, FacetPrice, FacetStatus}
+
+// GetProductsFacetsDTO counts the products matched by Filter per value of
+// every facet in Facets. Each facet ignores its own filter, so the counts of
+// the values not selected yet stay visible: the category facet ignores
+// Category, the tag facet TagsAny, the price facet PriceMin and PriceMax and
+// the status facet Statuses. TagsAll narrows the tag facet like any other
+// filter. Paging and sorting of Filter are ignored. The status facet counts
+// publication statuses, public reads only match published products so it is
+// only of use with all statuses visible.
+type GetProductsFacetsDTO struct {
+	Filter GetProductsDTO `json:"filter"`
+	Facets []string       `json:"facets,omitempty" validate:"dive,oneof=category tag price status"`
+	// PriceBuckets are the ascending lower bounds, in minor units of the
+	// filter currency, of the price buckets after the first one.
+	PriceBuckets []int64 `json:"price_buckets,omitempty" validate:"max=20,dive,min=0"`
+	// Limit bounds the values of the category and tag facets.
+	Limit int64 `json:"limit,omitempty"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// PriceBucket counts products whose current price is at least Min and below
+// Max, the last bucket has no Max.
+type PriceBucket struct {
+	Min   int64  `json:"min"`
+	Max   *int64 `json:"max"`
+	Count int64  `json:"count"`
+}
+
+// ProductsFacets holds the facets asked for, the others are null. Values
+// without products are left out. Categories count products in a category or
+// its descendants, like the category filter matches them.
+type ProductsFacets struct {
+	Categories []FacetCount  `json:"categories"`
+	Tags       []FacetCount  `json:"tags"`
+	Prices     []PriceBucket `json:"prices"`
+	Statuses   []FacetCount  `json:"statuses"`
+}
+
+// CheckFacets drops repeated facets, fills in the default ones and reports a
+// price facet that can not be computed.
+func CheckFacets(data *GetProductsFacetsDTO) error {
+	facets := data.Facets[:0]
+	for _, name := range data.Facets {
+		if !slices.Contains(facets, name) {
+			facets = append(facets, name)
+		}
+	}
+	data.Facets = facets
+	for i := 1; i < len(data.PriceBuckets); i++ {
+		if data.PriceBuckets[i] <= data.PriceBuckets[i-1] {
+			return ErrInvalidPriceBuckets
+		}
+	}
+	priceable := data.Filter.Currency != "" && len(data.PriceBuckets) > 0
+	if len(data.Facets) == 0 {
+		for _, name := range FacetNames {
+			if name != FacetPrice || priceable {
+				data.Facets = append(data.Facets, name)
+			}
+		}
+		return nil
+	}
+	if slices.Contains(data.Facets, FacetPrice) {
+		if data.Filter.Currency == "" {
+			return ErrCurrencyRequired
+		}
+		if len(data.PriceBuckets) == 0 {
+			return ErrInvalidPriceBuckets
+		}
+	}
+	return nil
+}
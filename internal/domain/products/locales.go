@@ -0,0 +1,44 @@
+package products
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// NormalizeLocale returns the canonical form of a BCP 47 tag, so "de-ch" and
+// "de-CH" are stored and looked up as the same locale.
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidLocale, locale)
+	}
+	return tag.String(), nil
+}
+
+// LocaleFallbacks expands locales in preference order into the chain
+// translations are looked up in. Every locale is followed by its shorter
+// forms, so "de-CH" falls back to "de" before the next preference. Invalid
+// locales and duplicates are dropped.
+func LocaleFallbacks(locales []string) []string {
+	chain := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		locale, err := NormalizeLocale(locale)
+		if err != nil || locale == language.Und.String() {
+			continue
+		}
+		for {
+			if !slices.Contains(chain, locale) {
+				chain = append(chain, locale)
+			}
+			i := strings.LastIndex(locale, "-")
+			if i < 0 {
+				break
+			}
+			locale = locale[:i]
+		}
+	}
+	return chain
+}
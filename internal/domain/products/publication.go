@@ -0,0 +1,119 @@
+package products
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// Publication statuses. Only published products are visible to public
+// reads.
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Statuses lists the publication statuses in workflow order.
+var Statuses = []string{StatusDraft, StatusInReview, StatusPublished, StatusArchived}
+
+// statusTransitions maps each status to the statuses a product may move to
+// from it. Drafts go through review before they are published, archived
+// products are reworked as drafts.
+var statusTransitions = map[string][]string{
+	StatusDraft:     {StatusInReview, StatusArchived},
+	StatusInReview:  {StatusDraft, StatusPublished, StatusArchived},
+	StatusPublished: {StatusDraft, StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+// CheckTransition reports a move from one status to another that the
+// workflow does not allow.
+func CheckTransition(from, to string) error {
+	if !slices.Contains(statusTransitions[from], to) {
+		return fmt.Errorf("%w: %s to %s", ErrIllegalTransition, from, to)
+	}
+	return nil
+}
+
+// Published reports whether public reads may see the product.
+func (p Product) Published() bool {
+	return p.Status == StatusPublished && p.DeletedAt == nil
+}
+
+// TransitionSources lists the statuses a product may move to status from.
+func TransitionSources(status string) []string {
+	var sources []string
+	for _, from := range Statuses {
+		if slices.Contains(statusTransitions[from], status) {
+			sources = append(sources, from)
+		}
+	}
+	return sources
+}
+
+// ProductTransition records a status change, Actor is who made it as
+// claimed by the client.
+type ProductTransition struct {
+	ID         uuid.UUID `json:"id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TransitionProductDTO moves a product to Status if the workflow allows it.
+// Moving to published drops a pending publish time, moving away from
+// published a pending unpublish time. Actor comes from the request body as
+// is, the app has no notion of users, so it is only as trustworthy as the
+// clients allowed to call the endpoint.
+type TransitionProductDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Status    string    `json:"status" validate:"required,oneof=draft in_review published archived"`
+	Actor     string    `json:"actor" validate:"required,max=250"`
+	Reason    string    `json:"reason" validate:"max=1000"`
+}
+
+type GetProductTransitionsDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Limit     int64     `json:"limit"`
+	Offset    int64     `json:"offset"`
+}
+
+// ProductSchedule holds the times a product is published and unpublished
+// at by the scheduler.
+type ProductSchedule struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// SetProductScheduleDTO replaces both times, a null time is cleared. The
+// scheduler only publishes products that passed review and archives
+// published ones, a draft scheduled for publishing waits for its review.
+type SetProductScheduleDTO struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// CheckSchedule reports an unpublish time that is not after the publish
+// time.
+func CheckSchedule(data SetProductScheduleDTO) error {
+	if data.PublishAt != nil && data.UnpublishAt != nil && !data.UnpublishAt.After(*data.PublishAt) {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
+
+// RunScheduledTransitionsDTO publishes and unpublishes up to Limit products
+// each whose time has come, recording Actor as who moved them.
+type RunScheduledTransitionsDTO struct {
+	Limit int64  `json:"limit"`
+	Actor string `json:"actor"`
+}
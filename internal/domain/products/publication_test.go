@@ -0,0 +1,87 @@
+package products
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{from: StatusDraft, to: StatusInReview, allowed: true},
+		{from: StatusDraft, to: StatusArchived, allowed: true},
+		{from: StatusDraft, to: StatusPublished},
+		{from: StatusDraft, to: StatusDraft},
+		{from: StatusInReview, to: StatusDraft, allowed: true},
+		{from: StatusInReview, to: StatusPublished, allowed: true},
+		{from: StatusInReview, to: StatusArchived, allowed: true},
+		{from: StatusPublished, to: StatusDraft, allowed: true},
+		{from: StatusPublished, to: StatusArchived, allowed: true},
+		{from: StatusPublished, to: StatusInReview},
+		{from: StatusArchived, to: StatusDraft, allowed: true},
+		{from: StatusArchived, to: StatusPublished},
+		{from: "deleted", to: StatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := CheckTransition(tt.from, tt.to)
+			if tt.allowed && err != nil {
+				t.Errorf("got error %v, want none", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrIllegalTransition) {
+				t.Errorf("got error %v, want %v", err, ErrIllegalTransition)
+			}
+		})
+	}
+}
+
+func TestTransitionSources(t *testing.T) {
+	tests := []struct {
+		status string
+		want   []string
+	}{
+		{status: StatusDraft, want: []string{StatusInReview, StatusPublished, StatusArchived}},
+		{status: StatusInReview, want: []string{StatusDraft}},
+		{status: StatusPublished, want: []string{StatusInReview}},
+		{status: StatusArchived, want: []string{StatusDraft, StatusInReview, StatusPublished}},
+		{status: "deleted", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := TransitionSources(tt.status); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSchedule(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	tests := []struct {
+		name    string
+		data    SetProductScheduleDTO
+		wantErr error
+	}{
+		{name: "no times", data: SetProductScheduleDTO{}},
+		{name: "publish only", data: SetProductScheduleDTO{PublishAt: &now}},
+		{name: "unpublish only", data: SetProductScheduleDTO{UnpublishAt: &now}},
+		{name: "unpublish after publish", data: SetProductScheduleDTO{PublishAt: &now, UnpublishAt: &later}},
+		{name: "unpublish at publish", data: SetProductScheduleDTO{PublishAt: &now, UnpublishAt: &now}, wantErr: ErrInvalidSchedule},
+		{name: "unpublish before publish", data: SetProductScheduleDTO{PublishAt: &later, UnpublishAt: &now}, wantErr: ErrInvalidSchedule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckSchedule(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
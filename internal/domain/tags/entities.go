@@ -0,0 +1,56 @@
+package tags
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TagCount is a tag with the number of products carrying it.
+type TagCount struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Count int64     `json:"count"`
+}
+
+// TagProductsDTO adds Tags to, or removes them from, every product in
+// ProductIDs. Tags are normalized first, unknown ones are created when
+// tagging.
+type TagProductsDTO struct {
+	ProductIDs []uuid.UUID `json:"product_ids" validate:"required,min=1,max=1000,unique"`
+	Tags       []string    `json:"tags" validate:"required,min=1,max=50,dive,required,max=100"`
+}
+
+// TagProductsResult reports the tags applied, how many products actually
+// changed and which of the products do not exist.
+type TagProductsResult struct {
+	Tags    []Tag       `json:"tags"`
+	Changed int64       `json:"changed"`
+	Missing []uuid.UUID `json:"missing"`
+}
+
+// AutocompleteTagsDTO finds tags starting with Prefix, the ones on most
+// published products first.
+type AutocompleteTagsDTO struct {
+	Prefix string `json:"prefix" validate:"required,max=100"`
+	Limit  int64  `json:"limit,omitempty"`
+}
+
+// GetTagCountsDTO counts tags over the products matched by Filter, paging
+// and sorting of the filter are ignored. Limit bounds the number of tags.
+type GetTagCountsDTO struct {
+	Filter productsDomain.GetProductsDTO `json:"filter"`
+	Limit  int64                         `json:"limit,omitempty"`
+}
+
+// GetProductsTagsDTO gets the tag names of many products at once.
+type GetProductsTagsDTO struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
@@ -0,0 +1,7 @@
+package tags
+
+import "errors"
+
+var (
+	ErrInvalidTag = errors.New("tag must not be blank")
+)
@@ -0,0 +1,25 @@
+package tags
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizeTag returns the stored form of a tag name: lower case with runs
+// of whitespace collapsed, so "Summer  Sale" and "summer sale" are one tag.
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// NormalizeTags normalizes names, dropping blanks and duplicates.
+func NormalizeTags(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name == "" || slices.Contains(normalized, name) {
+			continue
+		}
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
@@ -0,0 +1,85 @@
+package variants
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OptionType is a dimension a product varies in, e.g. size or colour, with
+// the values its variants can pick.
+type OptionType struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name"`
+	Values    []string  `json:"values"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Variant is a sellable version of a product. Options holds one value per
+// option type of the product, Attributes anything else specific to the
+// variant, e.g. weight or barcode.
+type Variant struct {
+	ID         uuid.UUID         `json:"id"`
+	ProductID  uuid.UUID         `json:"product_id"`
+	SKU        string            `json:"sku"`
+	Options    map[string]string `json:"options"`
+	Attributes map[string]any    `json:"attributes"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+type GetOptionTypesDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+type CreateOptionTypeDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name" validate:"required,max=100"`
+	Values    []string  `json:"values" validate:"required,unique,dive,required,max=100"`
+}
+
+type GetVariantsDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Limit     int64     `json:"limit,omitempty"`
+	Offset    int64     `json:"offset,omitempty"`
+}
+
+// GetProductsVariantsDTO gets all variants of many products at once.
+type GetProductsVariantsDTO struct {
+	ProductIDs []uuid.UUID `json:"product_ids"`
+}
+
+type GetVariantDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	ID        uuid.UUID `json:"id"`
+}
+
+type CreateVariantDTO struct {
+	ProductID  uuid.UUID         `json:"product_id"`
+	SKU        string            `json:"sku" validate:"required,max=100"`
+	Options    map[string]string `json:"options"`
+	Attributes map[string]any    `json:"attributes,omitempty"`
+}
+
+type DeleteVariantDTO struct {
+	ProductID uuid.UUID `json:"product_id"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// CheckOptions reports whether options pick an allowed value of every option
+// type and nothing else.
+func CheckOptions(types []OptionType, options map[string]string) error {
+	if len(options) != len(types) {
+		return ErrInvalidOptions
+	}
+	for _, optionType := range types {
+		value, ok := options[optionType.Name]
+		if !ok || !slices.Contains(optionType.Values, value) {
+			return ErrInvalidOptions
+		}
+	}
+	return nil
+}
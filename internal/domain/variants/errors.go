@@ -0,0 +1,12 @@
+package variants
+
+import "errors"
+
+var (
+	ErrVariantNotFound  = errors.New("variant not found")
+	ErrSKUTaken         = errors.New("sku is already taken")
+	ErrOptionsTaken     = errors.New("a variant with these options already exists")
+	ErrInvalidOptions   = errors.New("options must pick one allowed value of every option type")
+	ErrOptionTypeTaken  = errors.New("option type name is already taken")
+	ErrOptionTypesInUse = errors.New("option types can not change once the product has variants")
+)
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// level is shared by the default handler so it can be changed at runtime.
+var level = new(slog.LevelVar)
+
+// Setup installs a text handler as the default logger. The standard log
+// package writes through it at info level, so log.Println output is hidden
+// when the level is warn or error.
+func Setup(name string) error {
+	if err := SetLevel(name); err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	return nil
+}
+
+// SetLevel changes the level of the default logger.
+func SetLevel(name string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(name))); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", name, err)
+	}
+	level.Set(l)
+	return nil
+}
@@ -0,0 +1,118 @@
+// Package backfill runs large data migrations in small resumable batches
+// outside of goose, keeping a checkpoint per backfill in
+// backfill_checkpoints.
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusPaused  = "paused"
+	StatusDone    = "done"
+)
+
+const (
+	DefaultBatchSize = 1000
+	DefaultThrottle  = 100 * time.Millisecond
+)
+
+type (
+	// BatchFunc processes at most limit rows after cursor inside tx and
+	// returns the cursor of the last row and how many rows it processed.
+	// Zero rows processed finishes the backfill.
+	BatchFunc func(ctx context.Context, tx *sql.Tx, cursor string, limit int) (next string, processed int64, err error)
+
+	// RemainingFunc estimates rows left to process, for status output.
+	RemainingFunc func(ctx context.Context, db *sql.DB) (int64, error)
+
+	Backfill struct {
+		Name        string
+		Description string
+		Batch       BatchFunc
+		Remaining   RemainingFunc
+		// BatchSize and Throttle, the pause between batches, default to
+		// DefaultBatchSize and DefaultThrottle.
+		BatchSize int
+		Throttle  time.Duration
+	}
+
+	Checkpoint struct {
+		Name       string
+		Status     string
+		Cursor     string
+		Processed  int64
+		Remaining  *int64
+		LastError  *string
+		UpdatedAt  *time.Time
+		StartedAt  *time.Time
+		FinishedAt *time.Time
+	}
+)
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Backfill)
+)
+
+// Register adds a backfill, usually from an init function next to the
+// migration that needs it.
+func Register(b Backfill) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := registry[b.Name]; ok {
+		panic(fmt.Sprintf("backfill %q registered twice", b.Name))
+	}
+	if b.BatchSize <= 0 {
+		b.BatchSize = DefaultBatchSize
+	}
+	if b.Throttle <= 0 {
+		b.Throttle = DefaultThrottle
+	}
+	registry[b.Name] = b
+}
+
+// Get returns a registered backfill by name.
+func Get(name string) (Backfill, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	b, ok := registry[name]
+	return b, ok
+}
+
+// All returns registered backfills sorted by name.
+func All() []Backfill {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]Backfill, 0, len(registry))
+	for _, b := range registry {
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Enqueue creates a pending checkpoint, meant to be called from the Go
+// migration that introduces the backfill so deploys show it as pending.
+func Enqueue(ctx context.Context, tx *sql.Tx, name string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO backfill_checkpoints (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, name)
+	return err
+}
+
+// Remove drops the checkpoint, for the down side of that migration.
+func Remove(ctx context.Context, tx *sql.Tx, name string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM backfill_checkpoints WHERE name = $1`, name)
+	return err
+}
@@ -0,0 +1,9 @@
+package backfill
+
+import "errors"
+
+var (
+	ErrUnknownBackfill = errors.New("unknown backfill")
+	ErrPaused          = errors.New("backfill paused")
+	ErrDone            = errors.New("backfill already done")
+)
@@ -0,0 +1,197 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type (
+	Runner struct {
+		db *sql.DB
+	}
+
+	// RunOptions override the backfill batch size and throttle when set.
+	RunOptions struct {
+		BatchSize int
+		Throttle  time.Duration
+	}
+)
+
+func NewRunner(db *sql.DB) *Runner {
+	return &Runner{db: db}
+}
+
+// Status lists checkpoints of all registered backfills, ones that never ran
+// are reported as pending.
+func (r *Runner) Status(ctx context.Context) ([]Checkpoint, error) {
+	checkpoints := make([]Checkpoint, 0)
+	for _, b := range All() {
+		checkpoint := Checkpoint{Name: b.Name, Status: StatusPending}
+		err := r.db.QueryRowContext(ctx, `
+			SELECT status, cursor, processed, last_error, updated_at, started_at, finished_at
+			FROM backfill_checkpoints WHERE name = $1`, b.Name,
+		).Scan(
+			&checkpoint.Status,
+			&checkpoint.Cursor,
+			&checkpoint.Processed,
+			&checkpoint.LastError,
+			&checkpoint.UpdatedAt,
+			&checkpoint.StartedAt,
+			&checkpoint.FinishedAt,
+		)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get checkpoint %s: %w", b.Name, err)
+		}
+
+		if b.Remaining != nil && checkpoint.Status != StatusDone {
+			remaining, err := b.Remaining(ctx, r.db)
+			if err != nil {
+				return nil, fmt.Errorf("count remaining for %s: %w", b.Name, err)
+			}
+			checkpoint.Remaining = &remaining
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	return checkpoints, nil
+}
+
+// Run processes batches from the last checkpoint until the backfill is done,
+// paused or ctx is cancelled. Each batch commits together with its
+// checkpoint, so an interrupted run resumes where it stopped.
+func (r *Runner) Run(ctx context.Context, name string, opts RunOptions) error {
+	b, ok := Get(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownBackfill, name)
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = b.BatchSize
+	}
+	if opts.Throttle <= 0 {
+		opts.Throttle = b.Throttle
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO backfill_checkpoints (name, status, started_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			status = EXCLUDED.status,
+			last_error = NULL,
+			started_at = COALESCE(backfill_checkpoints.started_at, NOW()),
+			updated_at = NOW()
+		WHERE backfill_checkpoints.status <> $3`,
+		name, StatusRunning, StatusDone,
+	)
+	if err != nil {
+		return fmt.Errorf("start backfill %s: %w", name, err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("%w: %s", ErrDone, name)
+	}
+
+	for {
+		done, err := r.batch(ctx, b, opts.BatchSize)
+		if err != nil {
+			if !errors.Is(err, ErrPaused) && !errors.Is(err, context.Canceled) {
+				r.recordError(name, err)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.Throttle):
+		}
+	}
+}
+
+// Pause stops a running backfill after its current batch.
+func (r *Runner) Pause(ctx context.Context, name string) error {
+	if _, ok := Get(name); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownBackfill, name)
+	}
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO backfill_checkpoints (name, status) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET status = EXCLUDED.status, updated_at = NOW()
+		WHERE backfill_checkpoints.status <> $3`,
+		name, StatusPaused, StatusDone,
+	)
+	if err != nil {
+		return fmt.Errorf("pause backfill %s: %w", name, err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("%w: %s", ErrDone, name)
+	}
+	return nil
+}
+
+func (r *Runner) batch(ctx context.Context, b Backfill, limit int) (done bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// the row lock serialises runners of the same backfill and pauses
+	var status, cursor string
+	err = tx.QueryRowContext(ctx, `SELECT status, cursor FROM backfill_checkpoints WHERE name = $1 FOR UPDATE`, b.Name).
+		Scan(&status, &cursor)
+	if err != nil {
+		return false, fmt.Errorf("lock checkpoint: %w", err)
+	}
+	if status == StatusPaused {
+		return false, fmt.Errorf("%w: %s", ErrPaused, b.Name)
+	}
+
+	next, processed, err := b.Batch(ctx, tx, cursor, limit)
+	if err != nil {
+		return false, fmt.Errorf("batch after %q: %w", cursor, err)
+	}
+
+	if processed == 0 {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE backfill_checkpoints SET status = $2, finished_at = NOW(), updated_at = NOW()
+			WHERE name = $1`, b.Name, StatusDone)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE backfill_checkpoints SET cursor = $2, processed = processed + $3, updated_at = NOW()
+			WHERE name = $1`, b.Name, next, processed)
+	}
+	if err != nil {
+		return false, fmt.Errorf("save checkpoint: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+
+	if processed == 0 {
+		log.Printf("backfill %s done", b.Name)
+	} else {
+		log.Printf("backfill %s: %d rows, cursor %s", b.Name, processed, next)
+	}
+	return processed == 0, nil
+}
+
+func (r *Runner) recordError(name string, runErr error) {
+	// ctx may be cancelled already, the error is still worth keeping
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE backfill_checkpoints SET last_error = $2, updated_at = NOW() WHERE name = $1`,
+		name, runErr.Error(),
+	)
+	if err != nil {
+		log.Printf("record backfill %s error: %v", name, err)
+	}
+}
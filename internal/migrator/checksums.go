@@ -0,0 +1,201 @@
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/pressly/goose/v3"
+)
+
+const checksumsTable = "goose_migration_checksums"
+
+type (
+	// Problem is a migration whose applied state does not match this binary.
+	Problem struct {
+		Version int64
+		Path    string
+		Reason  string
+	}
+
+	checksumRow struct {
+		checksum  string
+		appliedAt time.Time
+	}
+)
+
+// Verify compares checksums recorded when migrations were applied with the
+// migration files known to this binary.
+func (m *Migrator) Verify(ctx context.Context) ([]Problem, error) {
+	if err := m.ensureChecksumsTable(ctx); err != nil {
+		return nil, err
+	}
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get migration status: %w", err)
+	}
+	recorded, err := m.loadChecksums(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := m.untrackedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	for _, version := range untracked {
+		problems = append(problems, Problem{Version: version, Reason: "applied but missing from this binary"})
+	}
+	for _, status := range statuses {
+		if status.State == goose.StatePending {
+			continue
+		}
+		problem := Problem{Version: status.Source.Version, Path: status.Source.Path}
+		row, ok := recorded[status.Source.Version]
+		if !ok {
+			problem.Reason = "no checksum recorded"
+			problems = append(problems, problem)
+			continue
+		}
+		checksum, err := m.checksum(status.Source.Path)
+		if err != nil {
+			return nil, err
+		}
+		if checksum != row.checksum {
+			problem.Reason = "edited after it was applied"
+			problems = append(problems, problem)
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems, ErrChecksumMismatch
+	}
+	return nil, nil
+}
+
+// syncChecksums records checksums of applied migrations, re-recording ones
+// applied again since (redo, down and up), and forgets rolled back ones.
+func (m *Migrator) syncChecksums(ctx context.Context) error {
+	if err := m.ensureChecksumsTable(ctx); err != nil {
+		return err
+	}
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("get migration status: %w", err)
+	}
+	recorded, err := m.loadChecksums(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		version := status.Source.Version
+		if status.State != goose.StateApplied {
+			if _, ok := recorded[version]; ok {
+				if _, err := m.db.ExecContext(ctx, "DELETE FROM "+checksumsTable+" WHERE version_id = $1", version); err != nil {
+					return fmt.Errorf("delete migration checksum: %w", err)
+				}
+			}
+			continue
+		}
+
+		if row, ok := recorded[version]; ok && row.appliedAt.Equal(status.AppliedAt) {
+			continue
+		}
+		checksum, err := m.checksum(status.Source.Path)
+		if err != nil {
+			return err
+		}
+		_, err = m.db.ExecContext(ctx, `
+			INSERT INTO `+checksumsTable+` (version_id, path, checksum, applied_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (version_id) DO UPDATE SET path = EXCLUDED.path, checksum = EXCLUDED.checksum, applied_at = EXCLUDED.applied_at`,
+			version, status.Source.Path, checksum, status.AppliedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("record migration checksum: %w", err)
+		}
+	}
+	return nil
+}
+
+// untrackedVersions lists versions applied to the database that have no
+// migration source.
+func (m *Migrator) untrackedVersions(ctx context.Context) ([]int64, error) {
+	known := make(map[int64]bool)
+	for _, source := range m.provider.ListSources() {
+		known[source.Version] = true
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT DISTINCT version_id FROM "+goose.TableName()+" WHERE version_id > 0 ORDER BY version_id")
+	if err != nil {
+		return nil, fmt.Errorf("load applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var untracked []int64
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("load applied migrations: %w", err)
+		}
+		if !known[version] {
+			untracked = append(untracked, version)
+		}
+	}
+	return untracked, rows.Err()
+}
+
+func (m *Migrator) ensureChecksumsTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS `+checksumsTable+` (
+			version_id bigint PRIMARY KEY,
+			path       text      NOT NULL,
+			checksum   text      NOT NULL,
+			applied_at timestamp NOT NULL
+		)`)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", checksumsTable, err)
+	}
+	return nil
+}
+
+func (m *Migrator) loadChecksums(ctx context.Context) (map[int64]checksumRow, error) {
+	rows, err := m.db.QueryContext(ctx, "SELECT version_id, checksum, applied_at FROM "+checksumsTable)
+	if err != nil {
+		return nil, fmt.Errorf("load migration checksums: %w", err)
+	}
+	defer rows.Close()
+
+	recorded := make(map[int64]checksumRow)
+	for rows.Next() {
+		var (
+			version int64
+			row     checksumRow
+		)
+		if err := rows.Scan(&version, &row.checksum, &row.appliedAt); err != nil {
+			return nil, fmt.Errorf("load migration checksums: %w", err)
+		}
+		recorded[version] = row
+	}
+	return recorded, rows.Err()
+}
+
+// checksum hashes a migration file, Go migrations registered without a file
+// in the source hash to an empty string.
+func (m *Migrator) checksum(path string) (string, error) {
+	content, err := fs.ReadFile(m.fsys, path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read migration %s: %w", path, err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
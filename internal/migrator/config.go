@@ -0,0 +1,9 @@
+package migrator
+
+type Config struct {
+	// Dir overrides the migrations embedded in the binary.
+	Dir string
+	// AutoMigrate applies pending migrations when the app starts, otherwise
+	// the app only checks that the database is at the expected version.
+	AutoMigrate bool
+}
@@ -0,0 +1,10 @@
+package migrator
+
+import "errors"
+
+var (
+	ErrPendingMigrations = errors.New("database has pending migrations")
+	ErrDatabaseAhead     = errors.New("database is ahead of the migrations known to this binary")
+	ErrNeedsDir          = errors.New("command needs a migrations directory, pass --dir")
+	ErrChecksumMismatch  = errors.New("applied migrations differ from this binary")
+)
@@ -0,0 +1,216 @@
+package migrator
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"go_template_project/migrations"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+const dialect = "postgres"
+
+// lockedCommands change the schema and run under the advisory lock.
+var lockedCommands = []string{"up", "up-by-one", "up-to", "down", "down-to", "redo", "reset"}
+
+// fileCommands edit migration files instead of the database.
+var fileCommands = []string{"create", "fix"}
+
+// Migrator applies goose migrations while holding a Postgres advisory lock,
+// so replicas starting together do not race. It records a checksum of every
+// applied migration to detect later edits.
+type Migrator struct {
+	db       *sql.DB
+	dir      string
+	fsys     fs.FS
+	locker   lock.SessionLocker
+	provider *goose.Provider
+}
+
+// New loads migrations from dir, or from the ones embedded in the binary
+// when dir is empty.
+func New(db *sql.DB, dir string) (*Migrator, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+
+	var fsys fs.FS = migrations.FS
+	if dir != "" {
+		fsys = os.DirFS(dir)
+	}
+	// the provider does not lock by itself, Up locks around the checksums
+	// as well
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("load migrations: %w", err)
+	}
+
+	return &Migrator{
+		db:       db,
+		dir:      dir,
+		fsys:     fsys,
+		locker:   locker,
+		provider: provider,
+	}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	var results []*goose.MigrationResult
+	err := m.locked(ctx, func() error {
+		var err error
+		if results, err = m.provider.Up(ctx); err != nil {
+			return err
+		}
+		return m.syncChecksums(ctx)
+	})
+	return results, err
+}
+
+// Check fails unless the database is exactly at the latest known migration.
+func (m *Migrator) Check(ctx context.Context) error {
+	current, target, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("get migration versions: %w", err)
+	}
+	if current > target {
+		return fmt.Errorf("%w: database version %d, latest migration %d", ErrDatabaseAhead, current, target)
+	}
+
+	pending, err := m.provider.HasPending(ctx)
+	if err != nil {
+		return fmt.Errorf("check pending migrations: %w", err)
+	}
+	if pending {
+		return fmt.Errorf("%w: database version %d, latest migration %d", ErrPendingMigrations, current, target)
+	}
+	return nil
+}
+
+// Plan writes the Up section of every pending migration without applying
+// anything.
+func (m *Migrator) Plan(ctx context.Context, w io.Writer) error {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("get migration status: %w", err)
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.State != goose.StatePending {
+			continue
+		}
+		pending++
+
+		if status.Source.Type != goose.TypeSQL {
+			if _, err := fmt.Fprintf(w, "-- %s (Go migration, code not shown)\n\n", status.Source.Path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		statements, err := m.upSection(status.Source.Path)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "-- %s\n%s\n", status.Source.Path, statements); err != nil {
+			return err
+		}
+	}
+
+	if pending == 0 {
+		_, err = fmt.Fprintln(w, "-- no pending migrations")
+	}
+	return err
+}
+
+// Run executes a goose command for the CLI, taking the advisory lock for
+// commands that change the schema.
+func (m *Migrator) Run(ctx context.Context, command string, args ...string) error {
+	if err := goose.SetDialect(dialect); err != nil {
+		return err
+	}
+
+	// create and fix write files, so they need a real directory
+	if slices.Contains(fileCommands, command) {
+		if m.dir == "" {
+			return fmt.Errorf("%w: %s", ErrNeedsDir, command)
+		}
+		goose.SetBaseFS(nil)
+		return goose.RunContext(ctx, command, m.db, m.dir, args...)
+	}
+
+	goose.SetBaseFS(m.fsys)
+	if !slices.Contains(lockedCommands, command) {
+		return goose.RunContext(ctx, command, m.db, ".", args...)
+	}
+
+	return m.locked(ctx, func() error {
+		if err := goose.RunContext(ctx, command, m.db, ".", args...); err != nil {
+			return err
+		}
+		return m.syncChecksums(ctx)
+	})
+}
+
+// locked runs fn holding the advisory lock on a connection of its own, so
+// no other migrator changes the schema or the checksums in between.
+func (m *Migrator) locked(ctx context.Context, fn func() error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.locker.SessionLock(ctx, conn); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	runErr := fn()
+	if err := m.locker.SessionUnlock(ctx, conn); err != nil {
+		return errors.Join(runErr, fmt.Errorf("release migration lock: %w", err))
+	}
+	return runErr
+}
+
+func (m *Migrator) upSection(path string) (string, error) {
+	file, err := m.fsys.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var (
+		b       strings.Builder
+		inUp    bool
+		scanner = bufio.NewScanner(file)
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch strings.TrimSpace(line) {
+		case "-- +goose Up":
+			inUp = true
+			continue
+		case "-- +goose Down":
+			inUp = false
+			continue
+		case "-- +goose StatementBegin", "-- +goose StatementEnd":
+			continue
+		}
+		if inUp {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	return b.String(), scanner.Err()
+}
@@ -0,0 +1,15 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores encoded values by key. Implementations must be safe for
+// concurrent use, values are opaque bytes so a shared store (e.g. Redis)
+// can replace the in-process one without touching the callers.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	categoriesDomain "go_template_project/internal/domain/categories"
+)
+
+// CategoriesRepository drops cached product lists whenever a write can
+// change which products a category filter matches. Categories themselves
+// are not cached.
+type CategoriesRepository struct {
+	categoriesRepository
+	products *ProductsRepository
+}
+
+func NewCategoriesRepository(repo categoriesRepository, products *ProductsRepository) *CategoriesRepository {
+	return &CategoriesRepository{
+		categoriesRepository: repo,
+		products:             products,
+	}
+}
+
+func (r *CategoriesRepository) DeleteCategory(
+	ctx context.Context,
+	data categoriesDomain.DeleteCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	category, err := r.categoriesRepository.DeleteCategory(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx)
+	return category, nil
+}
+
+func (r *CategoriesRepository) MoveCategory(
+	ctx context.Context,
+	data categoriesDomain.MoveCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	category, err := r.categoriesRepository.MoveCategory(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx)
+	return category, nil
+}
+
+func (r *CategoriesRepository) SetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.SetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	categories, err := r.categoriesRepository.SetProductCategories(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx)
+	return categories, nil
+}
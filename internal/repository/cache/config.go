@@ -0,0 +1,10 @@
+package cache
+
+import "time"
+
+type Config struct {
+	Enabled bool
+	// Size is the maximum number of entries kept by the in-process cache.
+	Size int
+	TTL  time.Duration
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// LRU is an in-process Cache evicting the least recently used entries
+	// once Size is reached. Expired entries are dropped lazily on access.
+	LRU struct {
+		mu      sync.Mutex
+		size    int
+		items   map[string]*list.Element
+		entries *list.List
+	}
+
+	lruEntry struct {
+		key       string
+		value     []byte
+		expiresAt time.Time
+	}
+)
+
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:    size,
+		items:   make(map[string]*list.Element),
+		entries: list.New(),
+	}
+}
+
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.remove(element)
+		return nil, false, nil
+	}
+	c.entries.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.entries.MoveToFront(element)
+		return nil
+	}
+
+	c.items[key] = c.entries.PushFront(&lruEntry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+	for c.size > 0 && c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+	}
+	return nil
+}
+
+func (c *LRU) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.items[key]; ok {
+			c.remove(element)
+		}
+	}
+	return nil
+}
+
+func (c *LRU) remove(element *list.Element) {
+	c.entries.Remove(element)
+	delete(c.items, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	resourceProduct        = "product"
+	resourceProducts       = "products"
+	resourceProductsStats  = "products_stats"
+	resourceProductsFacets = "products_facets"
+	resourceTagCounts      = "tag_counts"
+)
+
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "repository_cache_hits_total",
+		Help: "Number of repository reads served from the cache.",
+	}, []string{"resource"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "repository_cache_misses_total",
+		Help: "Number of repository reads that went to the database.",
+	}, []string{"resource"})
+)
@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"context"
+	"github.com/google/uuid"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+)
+
+type productsRepository interface {
+	GetProducts(
+		ctx context.Context,
+		data productsDomain.GetProductsDTO,
+	) ([]productsDomain.Product, error)
+	GetProductsStats(
+		ctx context.Context,
+		data productsDomain.GetProductsDTO,
+	) (*productsDomain.ProductsStats, error)
+	GetProductsFacets(
+		ctx context.Context,
+		data productsDomain.GetProductsFacetsDTO,
+	) (*productsDomain.ProductsFacets, error)
+	GetProduct(
+		ctx context.Context,
+		data productsDomain.GetProductDTO,
+	) (*productsDomain.Product, error)
+	GetProductsByIDs(
+		ctx context.Context,
+		data productsDomain.GetProductsByIDsDTO,
+	) ([]productsDomain.Product, error)
+	ExportProducts(
+		ctx context.Context,
+		data productsDomain.ExportProductsDTO,
+		fn func(productsDomain.Product) error,
+	) error
+	CreateProduct(
+		ctx context.Context,
+		data productsDomain.CreateProductDTO,
+	) (*productsDomain.Product, error)
+	PartialUpdateProduct(
+		ctx context.Context,
+		data productsDomain.PartialUpdateProductDTO,
+	) (*productsDomain.Product, error)
+	DeleteProduct(
+		ctx context.Context,
+		data productsDomain.DeleteProductDTO,
+	) (*productsDomain.Product, error)
+	PurgeDeletedProducts(
+		ctx context.Context,
+		data productsDomain.PurgeDeletedProductsDTO,
+	) ([]uuid.UUID, error)
+	BulkCreateProducts(
+		ctx context.Context,
+		data []productsDomain.Product,
+	) ([]productsDomain.Product, error)
+	BulkUpdateProducts(
+		ctx context.Context,
+		updateFields []string,
+		data []productsDomain.Product,
+	) ([]productsDomain.Product, error)
+	GetProductTranslations(
+		ctx context.Context,
+		data productsDomain.GetProductTranslationsDTO,
+	) ([]productsDomain.ProductTranslation, error)
+	GetMissingTranslations(
+		ctx context.Context,
+		data productsDomain.GetMissingTranslationsDTO,
+	) ([]productsDomain.MissingTranslation, error)
+	GetTagCounts(
+		ctx context.Context,
+		data tagsDomain.GetTagCountsDTO,
+	) ([]tagsDomain.TagCount, error)
+}
+
+type categoriesRepository interface {
+	GetCategories(
+		ctx context.Context,
+		data categoriesDomain.GetCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	GetCategory(
+		ctx context.Context,
+		data categoriesDomain.GetCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	GetCategoryBreadcrumbs(
+		ctx context.Context,
+		data categoriesDomain.GetCategoryDTO,
+	) ([]categoriesDomain.Category, error)
+	CreateCategory(
+		ctx context.Context,
+		data categoriesDomain.CreateCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	PartialUpdateCategory(
+		ctx context.Context,
+		data categoriesDomain.PartialUpdateCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	DeleteCategory(
+		ctx context.Context,
+		data categoriesDomain.DeleteCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	MoveCategory(
+		ctx context.Context,
+		data categoriesDomain.MoveCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	ReorderCategories(
+		ctx context.Context,
+		data categoriesDomain.ReorderCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	GetProductCategories(
+		ctx context.Context,
+		data categoriesDomain.GetProductCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	GetProductsCategories(
+		ctx context.Context,
+		data categoriesDomain.GetProductsCategoriesDTO,
+	) (map[uuid.UUID][]categoriesDomain.Category, error)
+	SetProductCategories(
+		ctx context.Context,
+		data categoriesDomain.SetProductCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+}
+
+type pricesRepository interface {
+	GetProductPrices(
+		ctx context.Context,
+		data pricesDomain.GetProductPricesDTO,
+	) ([]pricesDomain.Price, error)
+	GetProductsPrices(
+		ctx context.Context,
+		data pricesDomain.GetProductsPricesDTO,
+	) (map[uuid.UUID][]pricesDomain.Price, error)
+	GetProductPriceHistory(
+		ctx context.Context,
+		data pricesDomain.GetProductPriceHistoryDTO,
+	) ([]pricesDomain.PriceChange, error)
+	SetProductPrice(
+		ctx context.Context,
+		data pricesDomain.SetProductPriceDTO,
+	) (*pricesDomain.Price, error)
+	DeleteProductPrice(
+		ctx context.Context,
+		data pricesDomain.DeleteProductPriceDTO,
+	) (*pricesDomain.Price, error)
+}
+
+type tagsRepository interface {
+	TagProducts(
+		ctx context.Context,
+		data tagsDomain.TagProductsDTO,
+	) (*tagsDomain.TagProductsResult, error)
+	UntagProducts(
+		ctx context.Context,
+		data tagsDomain.TagProductsDTO,
+	) (*tagsDomain.TagProductsResult, error)
+	AutocompleteTags(
+		ctx context.Context,
+		data tagsDomain.AutocompleteTagsDTO,
+	) ([]tagsDomain.TagCount, error)
+	GetProductsTags(
+		ctx context.Context,
+		data tagsDomain.GetProductsTagsDTO,
+	) (map[uuid.UUID][]string, error)
+}
+
+type publicationRepository interface {
+	TransitionProduct(
+		ctx context.Context,
+		data productsDomain.TransitionProductDTO,
+	) (*productsDomain.ProductTransition, error)
+	GetProductTransitions(
+		ctx context.Context,
+		data productsDomain.GetProductTransitionsDTO,
+	) ([]productsDomain.ProductTransition, error)
+	SetProductSchedule(
+		ctx context.Context,
+		data productsDomain.SetProductScheduleDTO,
+	) (*productsDomain.ProductSchedule, error)
+	RunScheduledTransitions(
+		ctx context.Context,
+		data productsDomain.RunScheduledTransitionsDTO,
+	) ([]productsDomain.ProductTransition, error)
+}
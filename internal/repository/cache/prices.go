@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	pricesDomain "go_template_project/internal/domain/prices"
+)
+
+// PricesRepository drops cached product lists after price writes, lists
+// asked for in a currency carry current prices. Prices themselves are not
+// cached, and a scheduled price taking effect is only picked up once the
+// cached pages expire.
+type PricesRepository struct {
+	pricesRepository
+	products *ProductsRepository
+}
+
+func NewPricesRepository(repo pricesRepository, products *ProductsRepository) *PricesRepository {
+	return &PricesRepository{
+		pricesRepository: repo,
+		products:         products,
+	}
+}
+
+func (r *PricesRepository) SetProductPrice(
+	ctx context.Context,
+	data pricesDomain.SetProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	price, err := r.pricesRepository.SetProductPrice(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx)
+	return price, nil
+}
+
+func (r *PricesRepository) DeleteProductPrice(
+	ctx context.Context,
+	data pricesDomain.DeleteProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	price, err := r.pricesRepository.DeleteProductPrice(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx)
+	return price, nil
+}
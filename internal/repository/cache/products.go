@@ -0,0 +1,463 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"log"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	productKeyPrefix          = "products:item:"
+	localizedProductKeyPrefix = "products:localized:"
+	productsKeyPrefix         = "products:list:"
+	statsKeyPrefix            = "products:stats:"
+	facetsKeyPrefix           = "products:facets:"
+	tagCountsKeyPrefix        = "products:tags:"
+	productsVersionKey        = "products:list:version"
+)
+
+// ProductsRepository is a read-through cache in front of a products
+// repository. Single products are cached by id and dropped on every write
+// touching them. List pages, stats and translated products are keyed by a
+// version token which is replaced on every write, so all of them become
+// unreachable at once and expire by TTL. A fill is only cached if the token
+// did not move while it was loaded, so a write racing a read is never
+// undone. Cache failures are logged and reads fall back to the database.
+// Reads for which readPrimary reports true skip the cache altogether, a
+// client reading its own write must not get a page cached before it or
+// filled from a lagging replica.
+type ProductsRepository struct {
+	productsRepository
+	cache       Cache
+	ttl         time.Duration
+	group       singleflight.Group
+	readPrimary func(ctx context.Context) bool
+}
+
+func NewProductsRepository(
+	repo productsRepository,
+	cache Cache,
+	ttl time.Duration,
+	readPrimary func(ctx context.Context) bool,
+) *ProductsRepository {
+	return &ProductsRepository{
+		productsRepository: repo,
+		cache:              cache,
+		ttl:                ttl,
+		readPrimary:        readPrimary,
+	}
+}
+
+func (r *ProductsRepository) GetProduct(
+	ctx context.Context,
+	data productsDomain.GetProductDTO,
+) (*productsDomain.Product, error) {
+	if r.readPrimary(ctx) {
+		return r.productsRepository.GetProduct(ctx, data)
+	}
+	if data.PublishedOnly {
+		// Rows are cached whatever their status, visibility is checked on
+		// the cached row.
+		data.PublishedOnly = false
+		product, err := r.GetProduct(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		if !product.Published() {
+			return nil, productsDomain.ErrProductNotFound
+		}
+		return product, nil
+	}
+	// Whole rows are cached, narrowing to the requested fields is left to
+	// the caller.
+	data.Fields = nil
+	key := productKey(data.ID)
+	if len(data.Locales) > 0 {
+		var err error
+		if key, err = r.productsKey(ctx, localizedProductKeyPrefix, data); err != nil {
+			log.Println(err)
+			return r.productsRepository.GetProduct(ctx, data)
+		}
+	}
+	product := &productsDomain.Product{}
+	if r.get(ctx, key, product) {
+		cacheHits.WithLabelValues(resourceProduct).Inc()
+		return product, nil
+	}
+	cacheMisses.WithLabelValues(resourceProduct).Inc()
+
+	value, err, _ := r.group.Do(key, func() (interface{}, error) {
+		version := r.version(ctx)
+		product, err := r.productsRepository.GetProduct(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		r.setIfCurrent(ctx, version, key, product)
+		return product, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Callers sharing a flight must not share the pointer
+	product = new(productsDomain.Product)
+	*product = *value.(*productsDomain.Product)
+	return product, nil
+}
+
+func (r *ProductsRepository) GetProducts(
+	ctx context.Context,
+	data productsDomain.GetProductsDTO,
+) ([]productsDomain.Product, error) {
+	if r.readPrimary(ctx) {
+		return r.productsRepository.GetProducts(ctx, data)
+	}
+	// Expansions are loaded on top of the rows, they do not change them
+	data.Expand = nil
+	key, err := r.productsKey(ctx, productsKeyPrefix, data)
+	if err != nil {
+		log.Println(err)
+		return r.productsRepository.GetProducts(ctx, data)
+	}
+	var products []productsDomain.Product
+	if r.get(ctx, key, &products) {
+		cacheHits.WithLabelValues(resourceProducts).Inc()
+		return products, nil
+	}
+	cacheMisses.WithLabelValues(resourceProducts).Inc()
+
+	value, err, _ := r.group.Do(key, func() (interface{}, error) {
+		version := r.version(ctx)
+		products, err := r.productsRepository.GetProducts(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		r.setIfCurrent(ctx, version, key, products)
+		return products, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]productsDomain.Product(nil), value.([]productsDomain.Product)...), nil
+}
+
+func (r *ProductsRepository) GetProductsStats(
+	ctx context.Context,
+	data productsDomain.GetProductsDTO,
+) (*productsDomain.ProductsStats, error) {
+	if r.readPrimary(ctx) {
+		return r.productsRepository.GetProductsStats(ctx, data)
+	}
+	// Paging and projection do not change the stats, share them
+	data.Limit, data.Offset = 0, 0
+	data.Fields, data.Expand = nil, nil
+	key, err := r.productsKey(ctx, statsKeyPrefix, data)
+	if err != nil {
+		log.Println(err)
+		return r.productsRepository.GetProductsStats(ctx, data)
+	}
+	stats := &productsDomain.ProductsStats{}
+	if r.get(ctx, key, stats) {
+		cacheHits.WithLabelValues(resourceProductsStats).Inc()
+		return stats, nil
+	}
+	cacheMisses.WithLabelValues(resourceProductsStats).Inc()
+
+	value, err, _ := r.group.Do(key, func() (interface{}, error) {
+		version := r.version(ctx)
+		stats, err := r.productsRepository.GetProductsStats(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		r.setIfCurrent(ctx, version, key, stats)
+		return stats, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats = new(productsDomain.ProductsStats)
+	*stats = *value.(*productsDomain.ProductsStats)
+	return stats, nil
+}
+
+func (r *ProductsRepository) GetProductsFacets(
+	ctx context.Context,
+	data productsDomain.GetProductsFacetsDTO,
+) (*productsDomain.ProductsFacets, error) {
+	if r.readPrimary(ctx) {
+		return r.productsRepository.GetProductsFacets(ctx, data)
+	}
+	// Paging, sorting and projection do not change the facets, share them
+	data.Filter.Limit, data.Filter.Offset = 0, 0
+	data.Filter.Fields, data.Filter.Expand = nil, nil
+	data.Filter.Sort = ""
+	key, err := r.productsKey(ctx, facetsKeyPrefix, data)
+	if err != nil {
+		log.Println(err)
+		return r.productsRepository.GetProductsFacets(ctx, data)
+	}
+	facets := &productsDomain.ProductsFacets{}
+	if r.get(ctx, key, facets) {
+		cacheHits.WithLabelValues(resourceProductsFacets).Inc()
+		return facets, nil
+	}
+	cacheMisses.WithLabelValues(resourceProductsFacets).Inc()
+
+	value, err, _ := r.group.Do(key, func() (interface{}, error) {
+		version := r.version(ctx)
+		facets, err := r.productsRepository.GetProductsFacets(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		r.setIfCurrent(ctx, version, key, facets)
+		return facets, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the slices are only read, sharing them is fine
+	facets = new(productsDomain.ProductsFacets)
+	*facets = *value.(*productsDomain.ProductsFacets)
+	return facets, nil
+}
+
+func (r *ProductsRepository) GetTagCounts(
+	ctx context.Context,
+	data tagsDomain.GetTagCountsDTO,
+) ([]tagsDomain.TagCount, error) {
+	if r.readPrimary(ctx) {
+		return r.productsRepository.GetTagCounts(ctx, data)
+	}
+	// Paging, sorting and projection do not change the counts, share them
+	data.Filter.Limit, data.Filter.Offset = 0, 0
+	data.Filter.Fields, data.Filter.Expand = nil, nil
+	data.Filter.Sort = ""
+	key, err := r.productsKey(ctx, tagCountsKeyPrefix, data)
+	if err != nil {
+		log.Println(err)
+		return r.productsRepository.GetTagCounts(ctx, data)
+	}
+	var counts []tagsDomain.TagCount
+	if r.get(ctx, key, &counts) {
+		cacheHits.WithLabelValues(resourceTagCounts).Inc()
+		return counts, nil
+	}
+	cacheMisses.WithLabelValues(resourceTagCounts).Inc()
+
+	value, err, _ := r.group.Do(key, func() (interface{}, error) {
+		version := r.version(ctx)
+		counts, err := r.productsRepository.GetTagCounts(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		r.setIfCurrent(ctx, version, key, counts)
+		return counts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]tagsDomain.TagCount(nil), value.([]tagsDomain.TagCount)...), nil
+}
+
+func (r *ProductsRepository) CreateProduct(
+	ctx context.Context,
+	data productsDomain.CreateProductDTO,
+) (*productsDomain.Product, error) {
+	product, err := r.productsRepository.CreateProduct(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return product, nil
+}
+
+func (r *ProductsRepository) PartialUpdateProduct(
+	ctx context.Context,
+	data productsDomain.PartialUpdateProductDTO,
+) (*productsDomain.Product, error) {
+	product, err := r.productsRepository.PartialUpdateProduct(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, data.ID)
+	return product, nil
+}
+
+func (r *ProductsRepository) DeleteProduct(
+	ctx context.Context,
+	data productsDomain.DeleteProductDTO,
+) (*productsDomain.Product, error) {
+	product, err := r.productsRepository.DeleteProduct(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, data.ID)
+	return product, nil
+}
+
+func (r *ProductsRepository) PurgeDeletedProducts(
+	ctx context.Context,
+	data productsDomain.PurgeDeletedProductsDTO,
+) ([]uuid.UUID, error) {
+	ids, err := r.productsRepository.PurgeDeletedProducts(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, ids...)
+	return ids, nil
+}
+
+func (r *ProductsRepository) BulkCreateProducts(
+	ctx context.Context,
+	data []productsDomain.Product,
+) ([]productsDomain.Product, error) {
+	products, err := r.productsRepository.BulkCreateProducts(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return products, nil
+}
+
+func (r *ProductsRepository) BulkUpdateProducts(
+	ctx context.Context,
+	updateFields []string,
+	data []productsDomain.Product,
+) ([]productsDomain.Product, error) {
+	products, err := r.productsRepository.BulkUpdateProducts(ctx, updateFields, data)
+	// Part of the batch may have been applied even if the call failed
+	ids := make([]uuid.UUID, 0, len(data))
+	for _, product := range data {
+		ids = append(ids, product.ID)
+	}
+	r.invalidate(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *ProductsRepository) get(ctx context.Context, key string, value any) bool {
+	raw, ok, err := r.cache.Get(ctx, key)
+	if err != nil {
+		log.Printf("cache get %s: %v", key, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err = json.Unmarshal(raw, value); err != nil {
+		log.Printf("cache decode %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+func (r *ProductsRepository) set(ctx context.Context, key string, value any) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("cache encode %s: %v", key, err)
+		return
+	}
+	if err = r.cache.Set(ctx, key, raw, r.ttl); err != nil {
+		log.Printf("cache set %s: %v", key, err)
+	}
+}
+
+// setIfCurrent caches value unless the version token moved away from
+// version while it was loaded. A write landing during the load has already
+// invalidated the key, caching the loaded value would bring it back stale.
+// The token is checked again after the set: invalidate moves it before
+// deleting, so a write in between is either seen here or deletes the entry.
+func (r *ProductsRepository) setIfCurrent(ctx context.Context, version []byte, key string, value any) {
+	if version == nil || !bytes.Equal(version, r.version(ctx)) {
+		return
+	}
+	r.set(ctx, key, value)
+	if !bytes.Equal(version, r.version(ctx)) {
+		if err := r.cache.Delete(ctx, key); err != nil {
+			log.Printf("cache delete %s: %v", key, err)
+		}
+	}
+}
+
+// version returns the current version token, nil if it can not be read.
+func (r *ProductsRepository) version(ctx context.Context) []byte {
+	version, err := r.currentVersion(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return version
+}
+
+// invalidate drops the given products and all cached list pages.
+func (r *ProductsRepository) invalidate(ctx context.Context, ids ...uuid.UUID) {
+	// the token moves first, see setIfCurrent
+	if _, err := r.newProductsVersion(ctx); err != nil {
+		log.Println(err)
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := productKey(id)
+		keys = append(keys, key)
+		r.group.Forget(key)
+	}
+	if len(keys) > 0 {
+		if err := r.cache.Delete(ctx, keys...); err != nil {
+			log.Printf("cache delete products: %v", err)
+		}
+	}
+}
+
+// productsKey builds a key for the read parameters in data under the
+// current version token.
+func (r *ProductsRepository) productsKey(
+	ctx context.Context,
+	prefix string,
+	data any,
+) (string, error) {
+	version, err := r.currentVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	params, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("cache encode read params: %w", err)
+	}
+	return prefix + string(version) + ":" + string(params), nil
+}
+
+// currentVersion returns the version token, storing a fresh one if there
+// is none.
+func (r *ProductsRepository) currentVersion(ctx context.Context) ([]byte, error) {
+	version, ok, err := r.cache.Get(ctx, productsVersionKey)
+	if err != nil {
+		return nil, fmt.Errorf("cache get %s: %w", productsVersionKey, err)
+	}
+	if !ok {
+		return r.newProductsVersion(ctx)
+	}
+	return version, nil
+}
+
+// newProductsVersion stores a fresh version token. The token never expires
+// on its own: losing it only orphans the pages cached under it.
+func (r *ProductsRepository) newProductsVersion(ctx context.Context) ([]byte, error) {
+	version := []byte(uuid.NewString())
+	if err := r.cache.Set(ctx, productsVersionKey, version, 0); err != nil {
+		return nil, fmt.Errorf("cache set %s: %w", productsVersionKey, err)
+	}
+	return version, nil
+}
+
+func productKey(id uuid.UUID) string {
+	return productKeyPrefix + id.String()
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type (
+	// testProductsRepository serves one product, onLoad runs after its name
+	// was read and before it is returned.
+	testProductsRepository struct {
+		productsRepository
+		name   string
+		loads  int
+		onLoad func()
+	}
+
+	// testCache runs onSet once before storing key.
+	testCache struct {
+		*LRU
+		key   string
+		onSet func()
+	}
+)
+
+func (r *testProductsRepository) GetProduct(
+	_ context.Context,
+	data productsDomain.GetProductDTO,
+) (*productsDomain.Product, error) {
+	r.loads++
+	product := &productsDomain.Product{ID: data.ID, Name: r.name}
+	if onLoad := r.onLoad; onLoad != nil {
+		r.onLoad = nil
+		onLoad()
+	}
+	return product, nil
+}
+
+func (c *testCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if onSet := c.onSet; onSet != nil && key == c.key {
+		c.onSet = nil
+		onSet()
+	}
+	return c.LRU.Set(ctx, key, value, ttl)
+}
+
+func TestProductsRepositoryFillRacingWrite(t *testing.T) {
+	tests := []struct {
+		name       string
+		duringLoad bool
+		beforeSet  bool
+		wantLoads  int
+	}{
+		{name: "no write", wantLoads: 1},
+		{name: "write during the load", duringLoad: true, wantLoads: 2},
+		{name: "write between the check and the set", beforeSet: true, wantLoads: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			id := uuid.New()
+			repo := &testProductsRepository{name: "old"}
+			cache := &testCache{LRU: NewLRU(100), key: productKey(id)}
+			r := NewProductsRepository(repo, cache, time.Minute, func(context.Context) bool { return false })
+
+			write := func() {
+				repo.name = "new"
+				r.invalidate(ctx, id)
+			}
+			if tt.duringLoad {
+				repo.onLoad = write
+			}
+			if tt.beforeSet {
+				cache.onSet = write
+			}
+
+			if _, err := r.GetProduct(ctx, productsDomain.GetProductDTO{ID: id}); err != nil {
+				t.Fatalf("first read: %v", err)
+			}
+			product, err := r.GetProduct(ctx, productsDomain.GetProductDTO{ID: id})
+			if err != nil {
+				t.Fatalf("second read: %v", err)
+			}
+			if product.Name != repo.name {
+				t.Errorf("got name %q, want %q", product.Name, repo.name)
+			}
+			if repo.loads != tt.wantLoads {
+				t.Errorf("got %d loads, want %d", repo.loads, tt.wantLoads)
+			}
+		})
+	}
+}
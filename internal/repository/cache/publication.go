@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+// PublicationRepository drops the moved products and cached product lists
+// whenever a status or schedule changes, public reads see a different set
+// afterwards. Transition histories are not cached.
+type PublicationRepository struct {
+	publicationRepository
+	products *ProductsRepository
+}
+
+func NewPublicationRepository(repo publicationRepository, products *ProductsRepository) *PublicationRepository {
+	return &PublicationRepository{
+		publicationRepository: repo,
+		products:              products,
+	}
+}
+
+func (r *PublicationRepository) TransitionProduct(
+	ctx context.Context,
+	data productsDomain.TransitionProductDTO,
+) (*productsDomain.ProductTransition, error) {
+	transition, err := r.publicationRepository.TransitionProduct(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx, data.ProductID)
+	return transition, nil
+}
+
+func (r *PublicationRepository) SetProductSchedule(
+	ctx context.Context,
+	data productsDomain.SetProductScheduleDTO,
+) (*productsDomain.ProductSchedule, error) {
+	schedule, err := r.publicationRepository.SetProductSchedule(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx, data.ProductID)
+	return schedule, nil
+}
+
+func (r *PublicationRepository) RunScheduledTransitions(
+	ctx context.Context,
+	data productsDomain.RunScheduledTransitionsDTO,
+) ([]productsDomain.ProductTransition, error) {
+	// Part of the run may have been applied even if it failed
+	transitions, err := r.publicationRepository.RunScheduledTransitions(ctx, data)
+	if len(transitions) > 0 {
+		ids := make([]uuid.UUID, 0, len(transitions))
+		for _, transition := range transitions {
+			ids = append(ids, transition.ProductID)
+		}
+		r.products.invalidate(ctx, ids...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
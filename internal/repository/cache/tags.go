@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	tagsDomain "go_template_project/internal/domain/tags"
+)
+
+// TagsRepository drops the tagged products, cached product lists and tag
+// counts whenever products are tagged or untagged, tag filters match a
+// different set afterwards. Tags themselves are not cached.
+type TagsRepository struct {
+	tagsRepository
+	products *ProductsRepository
+}
+
+func NewTagsRepository(repo tagsRepository, products *ProductsRepository) *TagsRepository {
+	return &TagsRepository{
+		tagsRepository: repo,
+		products:       products,
+	}
+}
+
+func (r *TagsRepository) TagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	result, err := r.tagsRepository.TagProducts(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx, data.ProductIDs...)
+	return result, nil
+}
+
+func (r *TagsRepository) UntagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	result, err := r.tagsRepository.UntagProducts(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	r.products.invalidate(ctx, data.ProductIDs...)
+	return result, nil
+}
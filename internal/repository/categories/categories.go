@@ -0,0 +1,388 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go_template_project/internal/domain/attributes"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"go_template_project/internal/repository/dbtx"
+	"strings"
+)
+
+func (r *Repository) GetCategories(
+	ctx context.Context,
+	data categoriesDomain.GetCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	params := SqGetCategoriesParams{
+		ParentID:     NConvertUUID(data.ParentID),
+		ChildrenOnly: data.ParentID != nil,
+	}
+	sqCategories, err := r.queries.SqGetCategories(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get categories error: %w", err)
+	}
+	return convertCategories(sqCategories), nil
+}
+
+func (r *Repository) GetCategory(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	params := SqGetCategoryParams{
+		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+	}
+	sqCategory, err := r.queries.SqGetCategory(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, categoriesDomain.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("sq get category error: %w", err)
+	}
+	return convertCategory(sqCategory), nil
+}
+
+func (r *Repository) GetCategoryBreadcrumbs(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryDTO,
+) ([]categoriesDomain.Category, error) {
+	params := SqGetCategoryParams{
+		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+	}
+	sqCategories, err := r.queries.SqGetCategoryBreadcrumbs(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get category breadcrumbs error: %w", err)
+	}
+	if len(sqCategories) == 0 {
+		return nil, categoriesDomain.ErrCategoryNotFound
+	}
+	return convertCategories(sqCategories), nil
+}
+
+func (r *Repository) CreateCategory(
+	ctx context.Context,
+	data categoriesDomain.CreateCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	var sqCategory *SqCategoryRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockTree(ctx); err != nil {
+			return err
+		}
+
+		params := SqCreateCategoryParams{
+			ParentID:        NConvertUUID(data.ParentID),
+			Name:            data.Name,
+			Slug:            data.Slug,
+			AttributeSchema: data.AttributeSchema,
+		}
+		if params.ParentID.Valid {
+			parent, err := q.SqLockCategory(ctx, SqGetCategoryParams{ID: params.ParentID})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return categoriesDomain.ErrParentCategoryNotFound
+				}
+				return err
+			}
+			params.ParentPath = parent.Path
+			params.Depth = parent.Depth + 1
+		}
+
+		position, err := q.placeCategory(ctx, params.ParentID, data.Position)
+		if err != nil {
+			return err
+		}
+		params.Position = position
+
+		sqCategory, err = q.SqCreateCategory(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, categoryError("sq create category error", err)
+	}
+	return convertCategory(sqCategory), nil
+}
+
+func (r *Repository) PartialUpdateCategory(
+	ctx context.Context,
+	data categoriesDomain.PartialUpdateCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	params := SqPartialUpdateCategoryParams{
+		ID:              pgtype.UUID{Bytes: data.ID, Valid: true},
+		Name:            data.Name,
+		Slug:            data.Slug,
+		AttributeSchema: data.AttributeSchema,
+	}
+	sqCategory, err := r.queries.SqPartialUpdateCategory(ctx, params)
+	if err != nil {
+		return nil, categoryError("sq partial update category error", err)
+	}
+	return convertCategory(sqCategory), nil
+}
+
+// DeleteCategory removes a category without children, its product
+// assignments go with it.
+func (r *Repository) DeleteCategory(
+	ctx context.Context,
+	data categoriesDomain.DeleteCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	params := SqDeleteCategoryParams{
+		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+	}
+	sqCategory, err := r.queries.SqDeleteCategory(ctx, params)
+	if err != nil {
+		return nil, categoryError("sq delete category error", err)
+	}
+	return convertCategory(sqCategory), nil
+}
+
+// MoveCategory re-parents a category, rewriting the paths of its subtree in
+// the same transaction.
+func (r *Repository) MoveCategory(
+	ctx context.Context,
+	data categoriesDomain.MoveCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	var sqCategory *SqCategoryRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockTree(ctx); err != nil {
+			return err
+		}
+
+		id := pgtype.UUID{Bytes: data.ID, Valid: true}
+		node, err := q.SqLockCategory(ctx, SqGetCategoryParams{ID: id})
+		if err != nil {
+			return err
+		}
+
+		params := SqMoveCategoryParams{
+			ID:       id,
+			ParentID: NConvertUUID(data.ParentID),
+		}
+		newPath := data.ID.String() + "/"
+		var depth int32
+		if params.ParentID.Valid {
+			parent, err := q.SqLockCategory(ctx, SqGetCategoryParams{ID: params.ParentID})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return categoriesDomain.ErrParentCategoryNotFound
+				}
+				return err
+			}
+			if strings.HasPrefix(parent.Path, node.Path) {
+				return categoriesDomain.ErrCategoryCycle
+			}
+			newPath = parent.Path + newPath
+			depth = parent.Depth + 1
+		}
+
+		if params.Position, err = q.placeCategory(ctx, params.ParentID, data.Position); err != nil {
+			return err
+		}
+
+		if newPath != node.Path {
+			err = q.SqMoveCategorySubtree(ctx, SqMoveCategorySubtreeParams{
+				OldPath:    node.Path,
+				NewPath:    newPath,
+				DepthDelta: depth - node.Depth,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		sqCategory, err = q.SqMoveCategory(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, categoryError("sq move category error", err)
+	}
+	return convertCategory(sqCategory), nil
+}
+
+func (r *Repository) ReorderCategories(
+	ctx context.Context,
+	data categoriesDomain.ReorderCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	params := SqReorderCategoriesParams{
+		ParentID: NConvertUUID(data.ParentID),
+		IDs:      make([]pgtype.UUID, 0, len(data.IDs)),
+	}
+	for _, id := range data.IDs {
+		params.IDs = append(params.IDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+
+	var sqCategories []SqCategoryRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockTree(ctx); err != nil {
+			return err
+		}
+		updated, err := q.SqReorderCategories(ctx, params)
+		if err != nil {
+			return err
+		}
+		if updated != int64(len(params.IDs)) {
+			return categoriesDomain.ErrCategoryNotChild
+		}
+		sqCategories, err = q.SqGetCategories(ctx, SqGetCategoriesParams{
+			ParentID:     params.ParentID,
+			ChildrenOnly: true,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, categoryError("sq reorder categories error", err)
+	}
+	return convertCategories(sqCategories), nil
+}
+
+func (r *Repository) GetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.GetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	params := SqProductCategoriesParams{
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+	}
+	sqCategories, err := r.queries.SqGetProductCategories(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get product categories error: %w", err)
+	}
+	return convertCategories(sqCategories), nil
+}
+
+// GetProductsCategories groups the categories by product, products without
+// categories are left out.
+func (r *Repository) GetProductsCategories(
+	ctx context.Context,
+	data categoriesDomain.GetProductsCategoriesDTO,
+) (map[uuid.UUID][]categoriesDomain.Category, error) {
+	productIDs := make([]pgtype.UUID, 0, len(data.ProductIDs))
+	for _, id := range data.ProductIDs {
+		productIDs = append(productIDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	sqCategories, err := r.queries.SqGetProductsCategories(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products categories error: %w", err)
+	}
+	categories := make(map[uuid.UUID][]categoriesDomain.Category)
+	for i := range sqCategories {
+		productID := uuid.UUID(sqCategories[i].ProductID.Bytes)
+		categories[productID] = append(categories[productID], *convertCategory(&sqCategories[i].SqCategoryRow))
+	}
+	return categories, nil
+}
+
+func (r *Repository) SetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.SetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	params := SqProductCategoriesParams{
+		ProductID:   pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		CategoryIDs: make([]pgtype.UUID, 0, len(data.CategoryIDs)),
+	}
+	for _, id := range data.CategoryIDs {
+		params.CategoryIDs = append(params.CategoryIDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+
+	// the product has to satisfy the schemas of its new categories, both
+	// are locked so neither changes before the assignment
+	var sqCategories []SqCategoryRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		productAttributes, err := q.SqLockProduct(ctx, params.ProductID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+		schemas, err := q.SqGetAttributeSchemas(ctx, params.CategoryIDs)
+		if err != nil {
+			return err
+		}
+		if err = attributes.Validate(schemas, productAttributes); err != nil {
+			return err
+		}
+		if err = q.SqSetProductCategories(ctx, params); err != nil {
+			return err
+		}
+		sqCategories, err = q.SqGetProductCategories(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, categoryError("sq set product categories error", err)
+	}
+	return convertCategories(sqCategories), nil
+}
+
+// placeCategory returns the position for a category joining the children of
+// parentID, making room at position if one is asked for.
+func (q *RepoQueries) placeCategory(
+	ctx context.Context,
+	parentID pgtype.UUID,
+	position *int,
+) (int32, error) {
+	if position == nil {
+		return q.SqNextCategoryPosition(ctx, parentID)
+	}
+	err := q.SqShiftCategoryPositions(ctx, SqShiftCategoryPositionsParams{
+		ParentID: parentID,
+		From:     int32(*position),
+	})
+	return int32(*position), err
+}
+
+// categoryError maps database errors to domain errors, wrapping anything
+// unexpected with msg.
+func categoryError(msg string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return categoriesDomain.ErrCategoryNotFound
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.UniqueViolation); ok {
+		return categoriesDomain.ErrCategorySlugTaken
+	}
+	if constraint, ok := dbtx.ConstraintViolation(err, dbtx.ForeignKeyViolation); ok {
+		if constraint == "categories_parent_id_fkey" {
+			return categoriesDomain.ErrCategoryHasChildren
+		}
+		return categoriesDomain.ErrCategoryNotFound
+	}
+	if errors.Is(err, productsDomain.ErrInvalidAttributes) {
+		// keeps the details of what failed
+		return err
+	}
+	for _, domainErr := range []error{
+		categoriesDomain.ErrParentCategoryNotFound,
+		categoriesDomain.ErrCategoryCycle,
+		categoriesDomain.ErrCategoryNotChild,
+		productsDomain.ErrProductNotFound,
+	} {
+		if errors.Is(err, domainErr) {
+			return domainErr
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func convertCategory(sqCategory *SqCategoryRow) *categoriesDomain.Category {
+	return &categoriesDomain.Category{
+		ID:              sqCategory.ID.Bytes,
+		ParentID:        NConvertPgUUID(sqCategory.ParentID),
+		Name:            sqCategory.Name,
+		Slug:            sqCategory.Slug,
+		Depth:           int(sqCategory.Depth),
+		Position:        int(sqCategory.Position),
+		AttributeSchema: sqCategory.AttributeSchema,
+		CreatedAt:       sqCategory.CreatedAt.Time,
+		UpdatedAt:       sqCategory.UpdatedAt.Time,
+	}
+}
+
+func convertCategories(sqCategories []SqCategoryRow) []categoriesDomain.Category {
+	categories := make([]categoriesDomain.Category, 0, len(sqCategories))
+	for i := range sqCategories {
+		categories = append(categories, *convertCategory(&sqCategories[i]))
+	}
+	return categories
+}
@@ -0,0 +1,663 @@
+package categories
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	CategoriesTable        = "categories"
+	ProductCategoriesTable = "product_categories"
+)
+
+// treeLockKey serialises structural changes so concurrent moves can not
+// create a cycle or leave stale paths behind.
+const treeLockKey = "categories_tree"
+
+var categoryColumns = []string{
+	"id",
+	"parent_id",
+	"name",
+	"slug",
+	"path",
+	"depth",
+	"position",
+	"attribute_schema",
+	"created_at",
+	"updated_at",
+}
+
+var categoryReturning = "RETURNING " + joinColumns(categoryColumns)
+
+type SqCategoryRow struct {
+	ID              pgtype.UUID
+	ParentID        pgtype.UUID
+	Name            string
+	Slug            string
+	Path            string
+	Depth           int32
+	Position        int32
+	AttributeSchema map[string]any
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
+type SqGetCategoriesParams struct {
+	// ParentID narrows the list to direct children, roots if it is not valid.
+	ParentID     pgtype.UUID
+	ChildrenOnly bool
+}
+
+type SqGetCategoryParams struct {
+	ID pgtype.UUID
+}
+
+type SqCreateCategoryParams struct {
+	ParentID        pgtype.UUID
+	ParentPath      string
+	Depth           int32
+	Name            string
+	Slug            string
+	Position        int32
+	AttributeSchema map[string]any
+}
+
+type SqPartialUpdateCategoryParams struct {
+	ID              pgtype.UUID
+	Name            string         `db:"name"`
+	Slug            string         `db:"slug"`
+	AttributeSchema map[string]any `db:"attribute_schema"`
+}
+
+type SqDeleteCategoryParams struct {
+	ID pgtype.UUID
+}
+
+type SqShiftCategoryPositionsParams struct {
+	ParentID pgtype.UUID
+	From     int32
+}
+
+type SqMoveCategoryParams struct {
+	ID       pgtype.UUID
+	ParentID pgtype.UUID
+	Position int32
+}
+
+type SqMoveCategorySubtreeParams struct {
+	OldPath    string
+	NewPath    string
+	DepthDelta int32
+}
+
+type SqReorderCategoriesParams struct {
+	ParentID pgtype.UUID
+	IDs      []pgtype.UUID
+}
+
+type SqProductCategoriesParams struct {
+	ProductID   pgtype.UUID
+	CategoryIDs []pgtype.UUID
+}
+
+// SqProductCategoryRow is a category a product is assigned to.
+type SqProductCategoryRow struct {
+	ProductID pgtype.UUID
+	SqCategoryRow
+}
+
+func scanCategory(row pgx.Row) (*SqCategoryRow, error) {
+	var i SqCategoryRow
+	err := row.Scan(
+		&i.ID,
+		&i.ParentID,
+		&i.Name,
+		&i.Slug,
+		&i.Path,
+		&i.Depth,
+		&i.Position,
+		&i.AttributeSchema,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+func scanCategories(rows pgx.Rows) ([]SqCategoryRow, error) {
+	defer rows.Close()
+	var items []SqCategoryRow
+	for rows.Next() {
+		i, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (q *RepoQueries) SqLockTree(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", treeLockKey)
+	return err
+}
+
+func (q *RepoQueries) SqGetCategories(
+	ctx context.Context,
+	params SqGetCategoriesParams,
+) ([]SqCategoryRow, error) {
+	query, args, err := buildGetCategoriesQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get categories build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanCategories(rows)
+}
+
+func buildGetCategoriesQuery(
+	params SqGetCategoriesParams,
+) (string, []interface{}, error) {
+	query := sq.Select(categoryColumns...).
+		From(CategoriesTable).
+		OrderBy("depth", "position", "name").
+		PlaceholderFormat(sq.Dollar)
+	if params.ChildrenOnly {
+		query = query.Where(sq.Expr("parent_id IS NOT DISTINCT FROM ?", params.ParentID))
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get categories query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetCategory(
+	ctx context.Context,
+	params SqGetCategoryParams,
+) (*SqCategoryRow, error) {
+	query, args, err := buildGetCategoryQuery(params, false)
+	if err != nil {
+		return nil, fmt.Errorf("sq get category build query error: %w", err)
+	}
+	return scanCategory(q.readDB.QueryRow(ctx, query, args...))
+}
+
+// SqLockCategory reads the category from the primary and locks it until the
+// transaction ends.
+func (q *RepoQueries) SqLockCategory(
+	ctx context.Context,
+	params SqGetCategoryParams,
+) (*SqCategoryRow, error) {
+	query, args, err := buildGetCategoryQuery(params, true)
+	if err != nil {
+		return nil, fmt.Errorf("sq lock category build query error: %w", err)
+	}
+	return scanCategory(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildGetCategoryQuery(
+	params SqGetCategoryParams,
+	forUpdate bool,
+) (string, []interface{}, error) {
+	query := sq.Select(categoryColumns...).
+		From(CategoriesTable).
+		Where(sq.Eq{"id": params.ID}).
+		PlaceholderFormat(sq.Dollar)
+	if forUpdate {
+		query = query.Suffix("FOR UPDATE")
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get category query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqGetCategoryBreadcrumbs returns the category and its ancestors, root first.
+func (q *RepoQueries) SqGetCategoryBreadcrumbs(
+	ctx context.Context,
+	params SqGetCategoryParams,
+) ([]SqCategoryRow, error) {
+	query, args, err := buildGetCategoryBreadcrumbsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get category breadcrumbs build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanCategories(rows)
+}
+
+func buildGetCategoryBreadcrumbsQuery(
+	params SqGetCategoryParams,
+) (string, []interface{}, error) {
+	query := sq.Select(categoryColumns...).
+		From(CategoriesTable).
+		Where(sq.Expr(
+			"id = ANY(string_to_array(rtrim((SELECT path FROM categories WHERE id = ?), '/'), '/')::uuid[])",
+			params.ID,
+		)).
+		OrderBy("depth").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get category breadcrumbs query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqNextCategoryPosition returns the position after the last child of
+// parentID, or after the last root.
+func (q *RepoQueries) SqNextCategoryPosition(
+	ctx context.Context,
+	parentID pgtype.UUID,
+) (int32, error) {
+	query, args, err := sq.Select("COALESCE(MAX(position) + 1, 0)").
+		From(CategoriesTable).
+		Where(sq.Expr("parent_id IS NOT DISTINCT FROM ?", parentID)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("sq next category position build query error: %w", err)
+	}
+	var position int32
+	err = q.db.QueryRow(ctx, query, args...).Scan(&position)
+	return position, err
+}
+
+func (q *RepoQueries) SqCreateCategory(
+	ctx context.Context,
+	params SqCreateCategoryParams,
+) (*SqCategoryRow, error) {
+	query, args, err := buildCreateCategoryQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq create category build query error: %w", err)
+	}
+	return scanCategory(q.db.QueryRow(ctx, query, args...))
+}
+
+// buildCreateCategoryQuery generates the id in the statement so the path,
+// which ends with it, is written together with the row.
+func buildCreateCategoryQuery(
+	params SqCreateCategoryParams,
+) (string, []interface{}, error) {
+	values := sq.Select("new_category.id").
+		Column("?::uuid", params.ParentID).
+		Column("?", params.Name).
+		Column("?", params.Slug).
+		Column("?::text || new_category.id::text || '/'", params.ParentPath).
+		Column("?::integer", params.Depth).
+		Column("?::integer", params.Position).
+		Column("?::jsonb", params.AttributeSchema).
+		From("(SELECT uuid_generate_v4() AS id) AS new_category")
+	query := sq.Insert(CategoriesTable).
+		Columns("id", "parent_id", "name", "slug", "path", "depth", "position", "attribute_schema").
+		Select(values).
+		Suffix(categoryReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqPartialUpdateCategory(
+	ctx context.Context,
+	params SqPartialUpdateCategoryParams,
+) (*SqCategoryRow, error) {
+	query, args, err := buildPartialUpdateCategoryQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq partial update category build query error: %w", err)
+	}
+	return scanCategory(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildPartialUpdateCategoryQuery(
+	params SqPartialUpdateCategoryParams,
+) (string, []interface{}, error) {
+	query := sq.Update(CategoriesTable).
+		SetMap(GetDbFieldsWithValues(params)).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID}).
+		Suffix(categoryReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqDeleteCategory(
+	ctx context.Context,
+	params SqDeleteCategoryParams,
+) (*SqCategoryRow, error) {
+	query, args, err := buildDeleteCategoryQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq delete category build query error: %w", err)
+	}
+	return scanCategory(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildDeleteCategoryQuery(
+	params SqDeleteCategoryParams,
+) (string, []interface{}, error) {
+	query := sq.Delete(CategoriesTable).
+		Where(sq.Eq{"id": params.ID}).
+		Suffix(categoryReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+// SqShiftCategoryPositions moves children of ParentID at or after From one
+// position down to make room.
+func (q *RepoQueries) SqShiftCategoryPositions(
+	ctx context.Context,
+	params SqShiftCategoryPositionsParams,
+) error {
+	query, args, err := sq.Update(CategoriesTable).
+		Set("position", sq.Expr("position + 1")).
+		Where(sq.Expr("parent_id IS NOT DISTINCT FROM ?", params.ParentID)).
+		Where(sq.GtOrEq{"position": params.From}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("sq shift category positions build query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, query, args...)
+	return err
+}
+
+// SqMoveCategory changes the parent and position of a single row, paths are
+// updated by SqMoveCategorySubtree.
+func (q *RepoQueries) SqMoveCategory(
+	ctx context.Context,
+	params SqMoveCategoryParams,
+) (*SqCategoryRow, error) {
+	query, args, err := sq.Update(CategoriesTable).
+		Set("parent_id", params.ParentID).
+		Set("position", params.Position).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID}).
+		Suffix(categoryReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq move category build query error: %w", err)
+	}
+	return scanCategory(q.db.QueryRow(ctx, query, args...))
+}
+
+// SqMoveCategorySubtree rewrites the path prefix and depth of a category and
+// all its descendants.
+func (q *RepoQueries) SqMoveCategorySubtree(
+	ctx context.Context,
+	params SqMoveCategorySubtreeParams,
+) error {
+	query, args, err := buildMoveCategorySubtreeQuery(params)
+	if err != nil {
+		return fmt.Errorf("sq move category subtree build query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, query, args...)
+	return err
+}
+
+func buildMoveCategorySubtreeQuery(
+	params SqMoveCategorySubtreeParams,
+) (string, []interface{}, error) {
+	query := sq.Update(CategoriesTable).
+		Set("path", sq.Expr("?::text || substr(path, ?)", params.NewPath, len(params.OldPath)+1)).
+		Set("depth", sq.Expr("depth + ?", params.DepthDelta)).
+		Where(sq.Like{"path": params.OldPath + "%"}).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+// SqReorderCategories sets positions of the children of ParentID to their
+// index in IDs and returns how many rows matched.
+func (q *RepoQueries) SqReorderCategories(
+	ctx context.Context,
+	params SqReorderCategoriesParams,
+) (int64, error) {
+	query, args, err := buildReorderCategoriesQuery(params)
+	if err != nil {
+		return 0, fmt.Errorf("sq reorder categories build query error: %w", err)
+	}
+	tag, err := q.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func buildReorderCategoriesQuery(
+	params SqReorderCategoriesParams,
+) (string, []interface{}, error) {
+	query := sq.Update(CategoriesTable).
+		Set("position", sq.Expr("array_position(?::uuid[], id) - 1", params.IDs)).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("id = ANY(?)", params.IDs)).
+		Where(sq.Expr("parent_id IS NOT DISTINCT FROM ?", params.ParentID)).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetAttributeSchemas(
+	ctx context.Context,
+	categoryIDs []pgtype.UUID,
+) ([]map[string]any, error) {
+	query, args, err := buildGetAttributeSchemasQuery(categoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sq get attribute schemas build query error: %w", err)
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []map[string]any
+	for rows.Next() {
+		var i map[string]any
+		if err := rows.Scan(&i); err != nil {
+			return nil, err
+		}
+		if i != nil {
+			items = append(items, i)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// buildGetAttributeSchemasQuery selects the schemas of the categories and
+// their ancestors, root first, each schema once. The rows are locked
+// against schema changes until the transaction ends, categories without a
+// schema as well since one may gain a schema meanwhile.
+func buildGetAttributeSchemasQuery(
+	categoryIDs []pgtype.UUID,
+) (string, []interface{}, error) {
+	query := sq.Select("a.attribute_schema").
+		From(CategoriesTable+" AS a").
+		Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM "+CategoriesTable+" AS c WHERE c.id = ANY(?) AND c.path LIKE a.path || '%')",
+			categoryIDs,
+		)).
+		OrderBy("a.depth", "a.path").
+		Suffix("FOR SHARE OF a").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get attribute schemas query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqLockProduct locks the live product row against deletion, soft or
+// hard, and attribute changes until the transaction ends and returns its
+// attributes.
+func (q *RepoQueries) SqLockProduct(
+	ctx context.Context,
+	productID pgtype.UUID,
+) (map[string]any, error) {
+	var attributes map[string]any
+	err := q.db.QueryRow(ctx,
+		"SELECT attributes FROM products WHERE id = $1 AND deleted_at IS NULL FOR SHARE",
+		productID,
+	).Scan(&attributes)
+	return attributes, err
+}
+
+func (q *RepoQueries) SqGetProductCategories(
+	ctx context.Context,
+	params SqProductCategoriesParams,
+) ([]SqCategoryRow, error) {
+	query, args, err := buildGetProductCategoriesQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get product categories build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanCategories(rows)
+}
+
+func buildGetProductCategoriesQuery(
+	params SqProductCategoriesParams,
+) (string, []interface{}, error) {
+	columns := make([]string, 0, len(categoryColumns))
+	for _, column := range categoryColumns {
+		columns = append(columns, "c."+column)
+	}
+	query := sq.Select(columns...).
+		From(CategoriesTable+" AS c").
+		Join(ProductCategoriesTable+" AS pc ON pc.category_id = c.id").
+		Where(sq.Eq{"pc.product_id": params.ProductID}).
+		OrderBy("c.depth", "c.position", "c.name").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get product categories query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetProductsCategories(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) ([]SqProductCategoryRow, error) {
+	query, args, err := buildGetProductsCategoriesQuery(productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products categories build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqProductCategoryRow
+	for rows.Next() {
+		var i SqProductCategoryRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ID,
+			&i.ParentID,
+			&i.Name,
+			&i.Slug,
+			&i.Path,
+			&i.Depth,
+			&i.Position,
+			&i.AttributeSchema,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func buildGetProductsCategoriesQuery(
+	productIDs []pgtype.UUID,
+) (string, []interface{}, error) {
+	columns := make([]string, 0, len(categoryColumns)+1)
+	columns = append(columns, "pc.product_id")
+	for _, column := range categoryColumns {
+		columns = append(columns, "c."+column)
+	}
+	query := sq.Select(columns...).
+		From(CategoriesTable+" AS c").
+		Join(ProductCategoriesTable+" AS pc ON pc.category_id = c.id").
+		Where(sq.Expr("pc.product_id = ANY(?)", productIDs)).
+		OrderBy("pc.product_id", "c.depth", "c.position", "c.name").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get products categories query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqSetProductCategories replaces the assignments of a product with
+// CategoryIDs.
+func (q *RepoQueries) SqSetProductCategories(
+	ctx context.Context,
+	params SqProductCategoriesParams,
+) error {
+	query, args, err := sq.Delete(ProductCategoriesTable).
+		Where(sq.Eq{"product_id": params.ProductID}).
+		Where(sq.Expr("NOT (category_id = ANY(?))", params.CategoryIDs)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("sq set product categories build delete query error: %w", err)
+	}
+	if _, err = q.db.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+	if len(params.CategoryIDs) == 0 {
+		return nil
+	}
+
+	query, args, err = sq.Insert(ProductCategoriesTable).
+		Columns("product_id", "category_id").
+		Select(sq.Select().
+			Column("?::uuid", params.ProductID).
+			Column("unnest(?::uuid[])", params.CategoryIDs)).
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("sq set product categories build insert query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,21 @@
+package categories
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func NConvertPgUUID(value pgtype.UUID) *uuid.UUID {
+	if value.Valid {
+		id := uuid.UUID(value.Bytes)
+		return &id
+	}
+	return nil
+}
+
+func NConvertUUID(value *uuid.UUID) pgtype.UUID {
+	if value != nil {
+		return pgtype.UUID{Bytes: *value, Valid: true}
+	}
+	return pgtype.UUID{}
+}
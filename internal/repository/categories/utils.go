@@ -0,0 +1,29 @@
+package categories
+
+import (
+	"reflect"
+	"strings"
+)
+
+func joinColumns(columns []string) string {
+	return strings.Join(columns, ", ")
+}
+
+func GetDbFieldsWithValues(data interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	t := reflect.TypeOf(data)
+	v := reflect.ValueOf(data)
+	numFields := v.NumField()
+	for i := 0; i < numFields; i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+		isEmpty := reflect.DeepEqual(fieldValue.Interface(), reflect.Zero(fieldValue.Type()).Interface())
+		if !isEmpty {
+			tag := field.Tag.Get("db")
+			if tag != "" {
+				result[tag] = fieldValue.Interface()
+			}
+		}
+	}
+	return result
+}
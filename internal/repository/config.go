@@ -1,6 +1,124 @@
 package repository
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
 type Config struct {
+	// DSN is a full connection string (URL or key=value). When set it
+	// replaces Host, Port, Name, Username and Password.
+	DSN                            string
 	Host, Name, Username, Password string
 	Port                           int
+
+	// TLS, SSLMode is one of disable, allow, prefer, require, verify-ca
+	// and verify-full, empty keeps the libpq default prefer.
+	SSLMode, SSLRootCert, SSLCert, SSLKey string
+
+	// Pool, zero values keep the pgx defaults.
+	MaxConns, MinConns int
+	MaxConnLifetime    time.Duration
+	MaxConnIdleTime    time.Duration
+
+	StatementTimeout time.Duration
+	ApplicationName  string
+
+	// The initial connect is retried ConnectRetries times, the wait starts
+	// at ConnectBackoff and doubles up to maxConnectBackoff.
+	ConnectRetries int
+	ConnectBackoff time.Duration
+
+	// Replicas are DSNs of read replicas, they share every other setting
+	// with the primary. Each is pinged every ReplicaHealthCheckInterval.
+	Replicas                   []string
+	ReplicaHealthCheckInterval time.Duration
+}
+
+// ConnString returns the connection string shared by the app pool and the
+// migrators. Pool sizing is not part of it, database/sql has its own.
+// Settings already carried by DSN win over the ones in Config, so an
+// sslmode in the DSN is never downgraded.
+func (c Config) ConnString() string {
+	dsn := c.DSN
+	if dsn == "" {
+		dsn = (&url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(c.Username, c.Password),
+			Host:   fmt.Sprintf("%s:%d", c.Host, c.Port),
+			Path:   "/" + c.Name,
+		}).String()
+	}
+
+	params := [][2]string{
+		{"sslmode", c.SSLMode},
+		{"sslrootcert", c.SSLRootCert},
+		{"sslcert", c.SSLCert},
+		{"sslkey", c.SSLKey},
+		{"application_name", c.ApplicationName},
+	}
+	if c.StatementTimeout > 0 {
+		// unknown keys are sent by pgx as run-time parameters
+		params = append(params, [2]string{"statement_timeout", fmt.Sprint(c.StatementTimeout.Milliseconds())})
+	}
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			// leave it to pgx to report
+			return dsn
+		}
+		query := u.Query()
+		for _, param := range params {
+			if param[1] != "" && !query.Has(param[0]) {
+				query.Set(param[0], param[1])
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	keys := keyValueKeys(dsn)
+	for _, param := range params {
+		if param[1] != "" && !keys[param[0]] {
+			dsn += fmt.Sprintf(" %s='%s'", param[0], strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(param[1]))
+		}
+	}
+	return strings.TrimSpace(dsn)
+}
+
+// keyValueKeys returns the keys set in a key=value connection string.
+// Values may be single quoted with backslash escapes.
+func keyValueKeys(dsn string) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < len(dsn); {
+		for i < len(dsn) && dsn[i] == ' ' {
+			i++
+		}
+		start := i
+		for i < len(dsn) && dsn[i] != '=' && dsn[i] != ' ' {
+			i++
+		}
+		if i > start {
+			keys[dsn[start:i]] = true
+		}
+		for i < len(dsn) && (dsn[i] == ' ' || dsn[i] == '=') {
+			i++
+		}
+		if i < len(dsn) && dsn[i] == '\'' {
+			for i++; i < len(dsn) && dsn[i] != '\''; i++ {
+				if dsn[i] == '\\' {
+					i++
+				}
+			}
+			i++
+			continue
+		}
+		for i < len(dsn) && dsn[i] != ' ' {
+			i++
+		}
+	}
+	return keys
 }
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigConnString(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name: "built from the fields",
+			config: Config{
+				Host:            "db",
+				Port:            5432,
+				Name:            "app",
+				Username:        "app",
+				Password:        "p@ss word",
+				SSLMode:         "require",
+				ApplicationName: "api",
+			},
+			want: "postgres://app:p%40ss%20word@db:5432/app?application_name=api&sslmode=require",
+		},
+		{
+			name: "URL keeps its own params",
+			config: Config{
+				DSN:             "postgres://app@db/app?sslmode=verify-full&application_name=worker",
+				SSLMode:         "disable",
+				SSLRootCert:     "/certs/root.crt",
+				ApplicationName: "api",
+			},
+			want: "postgres://app@db/app?application_name=worker&sslmode=verify-full&sslrootcert=%2Fcerts%2Froot.crt",
+		},
+		{
+			name: "statement timeout in milliseconds",
+			config: Config{
+				DSN:              "postgresql://app@db/app",
+				StatementTimeout: 5 * time.Second,
+			},
+			want: "postgresql://app@db/app?statement_timeout=5000",
+		},
+		{
+			name: "key=value keeps its own params",
+			config: Config{
+				DSN:             "host=db dbname=app sslmode=verify-full",
+				SSLMode:         "disable",
+				ApplicationName: "it's",
+			},
+			want: `host=db dbname=app sslmode=verify-full application_name='it\'s'`,
+		},
+		{
+			name: "key=value quoted values are not keys",
+			config: Config{
+				DSN:     `host=db password='x sslmode=disable \' y'`,
+				SSLMode: "require",
+			},
+			want: `host=db password='x sslmode=disable \' y' sslmode='require'`,
+		},
+		{
+			name:   "unparsable URL is left to pgx",
+			config: Config{DSN: "postgres://app@db:port/app", SSLMode: "require"},
+			want:   "postgres://app@db:port/app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ConnString(); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
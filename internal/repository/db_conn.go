@@ -2,33 +2,95 @@ package repository
 
 import (
 	"context"
-	"errors"
+	"database/sql"
 	"fmt"
-	"net/url"
+	"log"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+const maxConnectBackoff = 30 * time.Second
+
 func NewPgxConn(ctx context.Context, config Config) (*pgxpool.Pool, error) {
-	connURL := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s",
-		config.Username,
-		url.QueryEscape(config.Password),
-		config.Host,
-		config.Port,
-		config.Name,
-	)
+	poolConfig, err := newPoolConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var pool *pgxpool.Pool
+	err = retryConnect(ctx, config, func() error {
+		pool, err = pgxpool.NewWithConfig(ctx, poolConfig)
+		if err != nil {
+			return err
+		}
+		if err = pool.Ping(ctx); err != nil {
+			pool.Close()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect to db: %w", err)
+	}
 
-	pool, err := pgxpool.New(ctx, connURL)
+	return pool, nil
+}
 
+func newPoolConfig(config Config) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(config.ConnString())
 	if err != nil {
-		return nil, errors.New("invalid credentials for db connection")
+		return nil, fmt.Errorf("parse db connection config: %w", err)
+	}
+	if config.MaxConns > 0 {
+		poolConfig.MaxConns = int32(config.MaxConns)
+	}
+	if config.MinConns > 0 {
+		poolConfig.MinConns = int32(config.MinConns)
+	}
+	if config.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.MaxConnLifetime
 	}
+	if config.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.MaxConnIdleTime
+	}
+	return poolConfig, nil
+}
 
-	err = pool.Ping(ctx)
+// NewSQLDB opens a database/sql handle through the pgx driver with the same
+// settings as NewPgxConn, for tools such as goose.
+func NewSQLDB(ctx context.Context, config Config) (*sql.DB, error) {
+	db, err := sql.Open("pgx", config.ConnString())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("parse db connection config: %w", err)
 	}
 
-	return pool, nil
+	err = retryConnect(ctx, config, func() error {
+		return db.PingContext(ctx)
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("connect to db: %w", err)
+	}
+
+	return db, nil
+}
+
+func retryConnect(ctx context.Context, config Config, connect func() error) error {
+	backoff := config.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		if err == nil || attempt > config.ConnectRetries {
+			return err
+		}
+
+		log.Printf("db connection attempt %d/%d failed, retrying in %s: %v", attempt, config.ConnectRetries+1, backoff, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (gave up: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 }
@@ -1,19 +1,70 @@
 package repository
 
 import (
+	cacheRepo "go_template_project/internal/repository/cache"
+	categoriesRepo "go_template_project/internal/repository/categories"
+	inventoryRepo "go_template_project/internal/repository/inventory"
+	jobsRepo "go_template_project/internal/repository/jobs"
+	mediaRepo "go_template_project/internal/repository/media"
+	pricesRepo "go_template_project/internal/repository/prices"
 	productsRepo "go_template_project/internal/repository/products"
+	publicationRepo "go_template_project/internal/repository/publication"
+	tagsRepo "go_template_project/internal/repository/tags"
+	variantsRepo "go_template_project/internal/repository/variants"
 )
 
 type Repository struct {
-	conn         Connect
-	productsRepo ProductsRepository
+	conn            Connect
+	productsRepo    ProductsRepository
+	categoriesRepo  CategoriesRepository
+	pricesRepo      PricesRepository
+	variantsRepo    VariantsRepository
+	tagsRepo        TagsRepository
+	publicationRepo PublicationRepository
+	mediaRepo       MediaRepository
+	inventoryRepo   InventoryRepository
+	jobsRepo        JobsRepository
 }
 
-func NewRepo(conn Connect) *Repository {
+// NewRepo builds the repository, reads that tolerate replication lag go to
+// reader while everything else uses conn.
+func NewRepo(conn Connect, reader DBTX, cacheConfig cacheRepo.Config) *Repository {
 	queries := *New(conn)
+
+	var products ProductsRepository = productsRepo.NewProductsRepository(conn, reader)
+	var categories CategoriesRepository = categoriesRepo.NewCategoriesRepository(conn, reader)
+	var prices PricesRepository = pricesRepo.NewPricesRepository(conn, reader)
+	var tags TagsRepository = tagsRepo.NewTagsRepository(conn, reader)
+	var publication PublicationRepository = publicationRepo.NewPublicationRepository(conn, reader)
+	if cacheConfig.Enabled {
+		cachedProducts := cacheRepo.NewProductsRepository(
+			products,
+			cacheRepo.NewLRU(cacheConfig.Size),
+			cacheConfig.TTL,
+			ReadPrimary,
+		)
+		products = cachedProducts
+		// category changes move products in and out of filtered lists
+		categories = cacheRepo.NewCategoriesRepository(categories, cachedProducts)
+		// so do price changes to lists filtered or sorted by price
+		prices = cacheRepo.NewPricesRepository(prices, cachedProducts)
+		// and tagging to lists filtered by tag
+		tags = cacheRepo.NewTagsRepository(tags, cachedProducts)
+		// and publishing to anything public reads see
+		publication = cacheRepo.NewPublicationRepository(publication, cachedProducts)
+	}
+
 	return &Repository{
-		conn:         conn,
-		productsRepo: productsRepo.NewProductsRepository(queries.db),
+		conn:            conn,
+		productsRepo:    products,
+		categoriesRepo:  categories,
+		pricesRepo:      prices,
+		variantsRepo:    variantsRepo.NewVariantsRepository(conn, reader),
+		tagsRepo:        tags,
+		publicationRepo: publication,
+		mediaRepo:       mediaRepo.NewMediaRepository(conn, reader),
+		inventoryRepo:   inventoryRepo.NewInventoryRepository(conn, reader),
+		jobsRepo:        jobsRepo.NewJobsRepository(queries.db),
 	}
 }
 
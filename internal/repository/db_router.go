@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type (
+	// Router sends writes and transactions to the primary and, through
+	// Reader, reads to healthy replicas in round robin. Without replicas
+	// everything goes to the primary.
+	Router struct {
+		primary  *pgxpool.Pool
+		replicas []*replica
+		next     atomic.Uint64
+		interval time.Duration
+	}
+
+	replica struct {
+		pool    *pgxpool.Pool
+		healthy atomic.Bool
+	}
+
+	// reader is a DBTX picking a target per call, so a context marked with
+	// WithReadPrimary can opt out of replicas.
+	reader struct {
+		router *Router
+	}
+
+	readPrimaryKey struct{}
+)
+
+// WithReadPrimary makes reads in ctx go to the primary, used after a
+// mutation so clients see their own writes despite replication lag.
+func WithReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readPrimaryKey{}, true)
+}
+
+// ReadPrimary reports whether ctx was marked with WithReadPrimary.
+func ReadPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(readPrimaryKey{}).(bool)
+	return v
+}
+
+// NewRouter connects to the primary, failing if it is unreachable, and to
+// each replica in config.Replicas. Replicas share the primary settings
+// except for the DSN and start out as healthy only if they answer a ping.
+func NewRouter(ctx context.Context, config Config) (*Router, error) {
+	primary, err := NewPgxConn(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Router{
+		primary:  primary,
+		interval: config.ReplicaHealthCheckInterval,
+	}
+	for _, dsn := range config.Replicas {
+		replicaConfig := config
+		replicaConfig.DSN = dsn
+
+		poolConfig, err := newPoolConfig(replicaConfig)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		r.replicas = append(r.replicas, &replica{pool: pool})
+	}
+	r.checkReplicas(ctx)
+
+	return r, nil
+}
+
+// Run checks replica health every ReplicaHealthCheckInterval until ctx is done.
+func (r *Router) Run(ctx context.Context, wg *sync.WaitGroup) {
+	if len(r.replicas) == 0 || r.interval <= 0 {
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.checkReplicas(ctx)
+			}
+		}
+	}()
+}
+
+func (r *Router) Close() {
+	for _, replica := range r.replicas {
+		replica.pool.Close()
+	}
+	r.primary.Close()
+}
+
+// Reader returns a DBTX for read-only queries.
+func (r *Router) Reader() DBTX {
+	return reader{router: r}
+}
+
+func (r *Router) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return r.primary.Exec(ctx, sql, args...)
+}
+
+func (r *Router) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return r.primary.Query(ctx, sql, args...)
+}
+
+func (r *Router) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return r.primary.QueryRow(ctx, sql, args...)
+}
+
+func (r *Router) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	return r.primary.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+func (r *Router) Begin(ctx context.Context) (pgx.Tx, error) {
+	return r.primary.Begin(ctx)
+}
+
+// pick returns the next healthy replica, or the primary if there is none or
+// ctx asks for it.
+func (r *Router) pick(ctx context.Context) DBTX {
+	if len(r.replicas) == 0 || ReadPrimary(ctx) {
+		return r.primary
+	}
+	start := r.next.Add(1)
+	for i := range uint64(len(r.replicas)) {
+		replica := r.replicas[(start+i)%uint64(len(r.replicas))]
+		if replica.healthy.Load() {
+			return replica.pool
+		}
+	}
+	return r.primary
+}
+
+func (r *Router) checkReplicas(ctx context.Context) {
+	for i, replica := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, time.Second)
+		err := replica.pool.Ping(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.Printf("db replica %d is healthy", i)
+			} else {
+				log.Printf("db replica %d is unhealthy, reading from other replicas or primary: %v", i, err)
+			}
+		}
+	}
+}
+
+func (rd reader) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return rd.router.pick(ctx).Exec(ctx, sql, args...)
+}
+
+func (rd reader) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return rd.router.pick(ctx).Query(ctx, sql, args...)
+}
+
+func (rd reader) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return rd.router.pick(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (rd reader) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	return rd.router.primary.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
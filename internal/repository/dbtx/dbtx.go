@@ -0,0 +1,61 @@
+// Package dbtx holds the transaction and error helpers shared by the
+// repository subpackages.
+package dbtx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes of the constraint violations repositories map to domain
+// errors.
+const (
+	UniqueViolation     = "23505"
+	ForeignKeyViolation = "23503"
+	CheckViolation      = "23514"
+	ExclusionViolation  = "23P01"
+)
+
+// Beginner starts transactions, a pool or a connection.
+type Beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// InTx runs fn with the queries withTx binds to a new transaction on conn,
+// committed if fn returns nil and rolled back otherwise.
+func InTx[Q any](ctx context.Context, conn Beginner, withTx func(pgx.Tx) Q, fn func(q Q) error) (err error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, ignoreClosed(tx.Rollback(ctx)))
+		}
+	}()
+
+	if err = fn(withTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ConstraintViolation reports whether err has the SQLSTATE code and which
+// constraint it violated.
+func ConstraintViolation(err error, code string) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == code {
+		return pgErr.ConstraintName, true
+	}
+	return "", false
+}
+
+func ignoreClosed(err error) error {
+	if errors.Is(err, pgx.ErrTxClosed) {
+		return nil
+	}
+	return err
+}
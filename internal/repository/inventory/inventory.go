@@ -0,0 +1,329 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"go_template_project/internal/repository/dbtx"
+)
+
+func (r *Repository) GetWarehouses(
+	ctx context.Context,
+	_ inventoryDomain.GetWarehousesDTO,
+) ([]inventoryDomain.Warehouse, error) {
+	sqWarehouses, err := r.queries.SqGetWarehouses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sq get warehouses error: %w", err)
+	}
+	warehouses := make([]inventoryDomain.Warehouse, 0, len(sqWarehouses))
+	for i := range sqWarehouses {
+		warehouses = append(warehouses, *convertWarehouse(&sqWarehouses[i]))
+	}
+	return warehouses, nil
+}
+
+func (r *Repository) CreateWarehouse(
+	ctx context.Context,
+	data inventoryDomain.CreateWarehouseDTO,
+) (*inventoryDomain.Warehouse, error) {
+	params := SqCreateWarehouseParams{
+		Code: data.Code,
+		Name: data.Name,
+	}
+	sqWarehouse, err := r.queries.SqCreateWarehouse(ctx, params)
+	if err != nil {
+		return nil, inventoryError("sq create warehouse error", err)
+	}
+	return convertWarehouse(sqWarehouse), nil
+}
+
+func (r *Repository) GetProductStock(
+	ctx context.Context,
+	data inventoryDomain.GetProductStockDTO,
+) ([]inventoryDomain.StockLevel, error) {
+	sqLevels, err := r.queries.SqGetProductStock(ctx, pgtype.UUID{Bytes: data.ProductID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("sq get product stock error: %w", err)
+	}
+	levels := make([]inventoryDomain.StockLevel, 0, len(sqLevels))
+	for i := range sqLevels {
+		levels = append(levels, *convertStockLevel(&sqLevels[i]))
+	}
+	return levels, nil
+}
+
+// AdjustStock applies a manual adjustment and records it in the ledger.
+func (r *Repository) AdjustStock(
+	ctx context.Context,
+	data inventoryDomain.AdjustStockDTO,
+) (*inventoryDomain.StockLevel, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+	warehouseID := pgtype.UUID{Bytes: data.WarehouseID, Valid: true}
+
+	var sqLevel *SqStockLevelRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		var err error
+		sqLevel, err = q.SqAddStock(ctx, SqChangeStockParams{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			OnHand:      data.Delta,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.SqCreateAdjustment(ctx, SqCreateAdjustmentParams{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Delta:       data.Delta,
+			Reason:      data.Reason,
+			Note:        data.Note,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, inventoryError("sq adjust stock error", err)
+	}
+	return convertStockLevel(sqLevel), nil
+}
+
+func (r *Repository) GetStockAdjustments(
+	ctx context.Context,
+	data inventoryDomain.GetStockAdjustmentsDTO,
+) ([]inventoryDomain.Adjustment, error) {
+	params := SqGetAdjustmentsParams{
+		ProductID:   pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		WarehouseID: NConvertUUID(data.WarehouseID),
+		Limit:       uint64(data.Limit),
+		Offset:      uint64(data.Offset),
+	}
+	sqAdjustments, err := r.queries.SqGetAdjustments(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get stock adjustments error: %w", err)
+	}
+	adjustments := make([]inventoryDomain.Adjustment, 0, len(sqAdjustments))
+	for _, sqAdjustment := range sqAdjustments {
+		adjustments = append(adjustments, inventoryDomain.Adjustment{
+			ID:            sqAdjustment.ID,
+			ProductID:     sqAdjustment.ProductID.Bytes,
+			WarehouseID:   sqAdjustment.WarehouseID.Bytes,
+			Delta:         sqAdjustment.Delta,
+			Reason:        sqAdjustment.Reason,
+			Note:          sqAdjustment.Note,
+			ReservationID: NConvertPgUUID(sqAdjustment.ReservationID),
+			CreatedAt:     sqAdjustment.CreatedAt.Time,
+		})
+	}
+	return adjustments, nil
+}
+
+// ReserveStock holds units for a later commit. The stock level is updated
+// with a conditional statement, so concurrent reservations of the last
+// units can not both succeed.
+func (r *Repository) ReserveStock(
+	ctx context.Context,
+	data inventoryDomain.ReserveStockDTO,
+) (*inventoryDomain.Reservation, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+	warehouseID := pgtype.UUID{Bytes: data.WarehouseID, Valid: true}
+
+	var sqReservation *SqReservationRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		_, err := q.SqReserveStock(ctx, SqReserveStockParams{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Quantity:    data.Quantity,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			exists, err := q.SqWarehouseExists(ctx, warehouseID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return inventoryDomain.ErrWarehouseNotFound
+			}
+			return inventoryDomain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		sqReservation, err = q.SqCreateReservation(ctx, SqCreateReservationParams{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Quantity:    data.Quantity,
+			ExpiresAt:   pgtype.Timestamp{Time: data.ExpiresAt.UTC(), Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, inventoryError("sq reserve stock error", err)
+	}
+	return convertReservation(sqReservation), nil
+}
+
+func (r *Repository) GetReservation(
+	ctx context.Context,
+	data inventoryDomain.GetReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	sqReservation, err := r.queries.SqGetReservation(ctx, pgtype.UUID{Bytes: data.ID, Valid: true})
+	if err != nil {
+		return nil, inventoryError("sq get reservation error", err)
+	}
+	return convertReservation(sqReservation), nil
+}
+
+// ReleaseReservation returns the reserved units to the available stock.
+func (r *Repository) ReleaseReservation(
+	ctx context.Context,
+	data inventoryDomain.ReleaseReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	sqReservation, err := r.finishReservation(ctx, data.ID, inventoryDomain.ReservationReleased)
+	if err != nil {
+		return nil, inventoryError("sq release reservation error", err)
+	}
+	return convertReservation(sqReservation), nil
+}
+
+// CommitReservation takes the reserved units out of stock and records the
+// sale in the ledger.
+func (r *Repository) CommitReservation(
+	ctx context.Context,
+	data inventoryDomain.CommitReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	sqReservation, err := r.finishReservation(ctx, data.ID, inventoryDomain.ReservationCommitted)
+	if err != nil {
+		return nil, inventoryError("sq commit reservation error", err)
+	}
+	return convertReservation(sqReservation), nil
+}
+
+func (r *Repository) ExpireReservations(
+	ctx context.Context,
+	data inventoryDomain.ExpireReservationsDTO,
+) (int64, error) {
+	expired, err := r.queries.SqExpireReservations(ctx, data.Limit)
+	if err != nil {
+		return 0, fmt.Errorf("sq expire reservations error: %w", err)
+	}
+	return expired, nil
+}
+
+// finishReservation moves an active reservation to status, releasing its
+// units and for commits also taking them off hand. The reservation row is
+// locked first, so a release, a commit and the expiry sweeper racing for
+// the same reservation are applied exactly once.
+func (r *Repository) finishReservation(
+	ctx context.Context,
+	id uuid.UUID,
+	status string,
+) (*SqReservationRow, error) {
+	var sqReservation *SqReservationRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		reservation, err := q.SqLockReservation(ctx, pgtype.UUID{Bytes: id, Valid: true})
+		if err != nil {
+			return err
+		}
+		if reservation.Status != inventoryDomain.ReservationActive {
+			return inventoryDomain.ErrReservationNotActive
+		}
+
+		change := SqChangeStockParams{
+			ProductID:   reservation.ProductID,
+			WarehouseID: reservation.WarehouseID,
+			Reserved:    -reservation.Quantity,
+		}
+		if status == inventoryDomain.ReservationCommitted {
+			change.OnHand = -reservation.Quantity
+		}
+		if _, err = q.SqChangeStock(ctx, change); err != nil {
+			return err
+		}
+		if status == inventoryDomain.ReservationCommitted {
+			_, err = q.SqCreateAdjustment(ctx, SqCreateAdjustmentParams{
+				ProductID:     reservation.ProductID,
+				WarehouseID:   reservation.WarehouseID,
+				Delta:         -reservation.Quantity,
+				Reason:        inventoryDomain.ReasonSale,
+				ReservationID: reservation.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		sqReservation, err = q.SqSetReservationStatus(ctx, SqSetReservationStatusParams{
+			ID:     reservation.ID,
+			Status: status,
+		})
+		return err
+	})
+	return sqReservation, err
+}
+
+// inventoryError maps database errors to domain errors, wrapping anything
+// unexpected with msg.
+func inventoryError(msg string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return inventoryDomain.ErrReservationNotFound
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.UniqueViolation); ok {
+		return inventoryDomain.ErrWarehouseCodeTaken
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.CheckViolation); ok {
+		return inventoryDomain.ErrInsufficientStock
+	}
+	if constraint, ok := dbtx.ConstraintViolation(err, dbtx.ForeignKeyViolation); ok {
+		if constraint == "stock_levels_product_id_fkey" {
+			return productsDomain.ErrProductNotFound
+		}
+		return inventoryDomain.ErrWarehouseNotFound
+	}
+	for _, domainErr := range []error{
+		inventoryDomain.ErrWarehouseNotFound,
+		inventoryDomain.ErrInsufficientStock,
+		inventoryDomain.ErrReservationNotActive,
+	} {
+		if errors.Is(err, domainErr) {
+			return domainErr
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func convertWarehouse(sqWarehouse *SqWarehouseRow) *inventoryDomain.Warehouse {
+	return &inventoryDomain.Warehouse{
+		ID:        sqWarehouse.ID.Bytes,
+		Code:      sqWarehouse.Code,
+		Name:      sqWarehouse.Name,
+		CreatedAt: sqWarehouse.CreatedAt.Time,
+	}
+}
+
+func convertStockLevel(sqLevel *SqStockLevelRow) *inventoryDomain.StockLevel {
+	return &inventoryDomain.StockLevel{
+		ProductID:   sqLevel.ProductID.Bytes,
+		WarehouseID: sqLevel.WarehouseID.Bytes,
+		OnHand:      sqLevel.OnHand,
+		Reserved:    sqLevel.Reserved,
+		Available:   sqLevel.OnHand - sqLevel.Reserved,
+		UpdatedAt:   sqLevel.UpdatedAt.Time,
+	}
+}
+
+func convertReservation(sqReservation *SqReservationRow) *inventoryDomain.Reservation {
+	return &inventoryDomain.Reservation{
+		ID:          sqReservation.ID.Bytes,
+		ProductID:   sqReservation.ProductID.Bytes,
+		WarehouseID: sqReservation.WarehouseID.Bytes,
+		Quantity:    sqReservation.Quantity,
+		Status:      sqReservation.Status,
+		ExpiresAt:   sqReservation.ExpiresAt.Time,
+		CreatedAt:   sqReservation.CreatedAt.Time,
+		UpdatedAt:   sqReservation.UpdatedAt.Time,
+	}
+}
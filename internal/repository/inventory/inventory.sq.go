@@ -0,0 +1,534 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	WarehousesTable   = "warehouses"
+	StockLevelsTable  = "stock_levels"
+	ReservationsTable = "stock_reservations"
+	AdjustmentsTable  = "stock_adjustments"
+)
+
+var warehouseColumns = []string{
+	"id",
+	"code",
+	"name",
+	"created_at",
+}
+
+var stockLevelColumns = []string{
+	"product_id",
+	"warehouse_id",
+	"on_hand",
+	"reserved",
+	"updated_at",
+}
+
+var reservationColumns = []string{
+	"id",
+	"product_id",
+	"warehouse_id",
+	"quantity",
+	"status",
+	"expires_at",
+	"created_at",
+	"updated_at",
+}
+
+var adjustmentColumns = []string{
+	"id",
+	"product_id",
+	"warehouse_id",
+	"delta",
+	"reason",
+	"note",
+	"reservation_id",
+	"created_at",
+}
+
+var (
+	warehouseReturning   = "RETURNING " + joinColumns(warehouseColumns)
+	stockLevelReturning  = "RETURNING " + joinColumns(stockLevelColumns)
+	reservationReturning = "RETURNING " + joinColumns(reservationColumns)
+	adjustmentReturning  = "RETURNING " + joinColumns(adjustmentColumns)
+)
+
+type SqWarehouseRow struct {
+	ID        pgtype.UUID
+	Code      string
+	Name      string
+	CreatedAt pgtype.Timestamp
+}
+
+type SqStockLevelRow struct {
+	ProductID   pgtype.UUID
+	WarehouseID pgtype.UUID
+	OnHand      int64
+	Reserved    int64
+	UpdatedAt   pgtype.Timestamp
+}
+
+type SqReservationRow struct {
+	ID          pgtype.UUID
+	ProductID   pgtype.UUID
+	WarehouseID pgtype.UUID
+	Quantity    int64
+	Status      string
+	ExpiresAt   pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type SqAdjustmentRow struct {
+	ID            int64
+	ProductID     pgtype.UUID
+	WarehouseID   pgtype.UUID
+	Delta         int64
+	Reason        string
+	Note          string
+	ReservationID pgtype.UUID
+	CreatedAt     pgtype.Timestamp
+}
+
+type SqCreateWarehouseParams struct {
+	Code string
+	Name string
+}
+
+// SqChangeStockParams moves OnHand and Reserved by the given deltas.
+type SqChangeStockParams struct {
+	ProductID   pgtype.UUID
+	WarehouseID pgtype.UUID
+	OnHand      int64
+	Reserved    int64
+}
+
+type SqReserveStockParams struct {
+	ProductID   pgtype.UUID
+	WarehouseID pgtype.UUID
+	Quantity    int64
+}
+
+type SqCreateReservationParams struct {
+	ProductID   pgtype.UUID
+	WarehouseID pgtype.UUID
+	Quantity    int64
+	ExpiresAt   pgtype.Timestamp
+}
+
+type SqSetReservationStatusParams struct {
+	ID     pgtype.UUID
+	Status string
+}
+
+type SqCreateAdjustmentParams struct {
+	ProductID     pgtype.UUID
+	WarehouseID   pgtype.UUID
+	Delta         int64
+	Reason        string
+	Note          string
+	ReservationID pgtype.UUID
+}
+
+type SqGetAdjustmentsParams struct {
+	ProductID   pgtype.UUID
+	WarehouseID pgtype.UUID
+	Limit       uint64
+	Offset      uint64
+}
+
+func scanWarehouse(row pgx.Row) (*SqWarehouseRow, error) {
+	var i SqWarehouseRow
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+func scanStockLevel(row pgx.Row) (*SqStockLevelRow, error) {
+	var i SqStockLevelRow
+	err := row.Scan(
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.OnHand,
+		&i.Reserved,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+func scanReservation(row pgx.Row) (*SqReservationRow, error) {
+	var i SqReservationRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.Quantity,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+func scanAdjustment(row pgx.Row) (*SqAdjustmentRow, error) {
+	var i SqAdjustmentRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.Delta,
+		&i.Reason,
+		&i.Note,
+		&i.ReservationID,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+// scanAll reads every row with scan.
+func scanAll[T any](rows pgx.Rows, scan func(pgx.Row) (*T, error)) ([]T, error) {
+	defer rows.Close()
+	var items []T
+	for rows.Next() {
+		i, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (q *RepoQueries) SqGetWarehouses(ctx context.Context) ([]SqWarehouseRow, error) {
+	query, args, err := sq.Select(warehouseColumns...).
+		From(WarehousesTable).
+		OrderBy("code").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get warehouses build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, scanWarehouse)
+}
+
+func (q *RepoQueries) SqWarehouseExists(ctx context.Context, id pgtype.UUID) (bool, error) {
+	var exists bool
+	err := q.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM warehouses WHERE id = $1)", id).Scan(&exists)
+	return exists, err
+}
+
+func (q *RepoQueries) SqCreateWarehouse(
+	ctx context.Context,
+	params SqCreateWarehouseParams,
+) (*SqWarehouseRow, error) {
+	query, args, err := sq.Insert(WarehousesTable).
+		Columns("code", "name").
+		Values(params.Code, params.Name).
+		Suffix(warehouseReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq create warehouse build query error: %w", err)
+	}
+	return scanWarehouse(q.db.QueryRow(ctx, query, args...))
+}
+
+func (q *RepoQueries) SqGetProductStock(
+	ctx context.Context,
+	productID pgtype.UUID,
+) ([]SqStockLevelRow, error) {
+	query, args, err := sq.Select(stockLevelColumns...).
+		From(StockLevelsTable).
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("warehouse_id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get product stock build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, scanStockLevel)
+}
+
+// SqAddStock adds OnHand units, creating the stock level on first use. The
+// table checks reject a result below zero or below the reserved units.
+func (q *RepoQueries) SqAddStock(
+	ctx context.Context,
+	params SqChangeStockParams,
+) (*SqStockLevelRow, error) {
+	query, args, err := buildAddStockQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq add stock build query error: %w", err)
+	}
+	return scanStockLevel(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildAddStockQuery(
+	params SqChangeStockParams,
+) (string, []interface{}, error) {
+	query := sq.Insert(StockLevelsTable).
+		Columns("product_id", "warehouse_id", "on_hand").
+		Values(params.ProductID, params.WarehouseID, params.OnHand).
+		Suffix(
+			"ON CONFLICT (product_id, warehouse_id) DO UPDATE SET " +
+				"on_hand = stock_levels.on_hand + EXCLUDED.on_hand, updated_at = NOW() " +
+				stockLevelReturning,
+		).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+// SqReserveStock reserves Quantity units only if that many are available.
+// The condition is re-checked against the latest row version when
+// concurrent reservations wait on the row lock, so stock is never
+// oversold. No row means not enough stock or no stock level at all.
+func (q *RepoQueries) SqReserveStock(
+	ctx context.Context,
+	params SqReserveStockParams,
+) (*SqStockLevelRow, error) {
+	query, args, err := buildReserveStockQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq reserve stock build query error: %w", err)
+	}
+	return scanStockLevel(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildReserveStockQuery(
+	params SqReserveStockParams,
+) (string, []interface{}, error) {
+	query := sq.Update(StockLevelsTable).
+		Set("reserved", sq.Expr("reserved + ?", params.Quantity)).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"product_id": params.ProductID, "warehouse_id": params.WarehouseID}).
+		Where(sq.Expr("on_hand - reserved >= ?", params.Quantity)).
+		Suffix(stockLevelReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+// SqChangeStock moves on hand and reserved units of an existing stock level.
+func (q *RepoQueries) SqChangeStock(
+	ctx context.Context,
+	params SqChangeStockParams,
+) (*SqStockLevelRow, error) {
+	query, args, err := sq.Update(StockLevelsTable).
+		Set("on_hand", sq.Expr("on_hand + ?", params.OnHand)).
+		Set("reserved", sq.Expr("reserved + ?", params.Reserved)).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"product_id": params.ProductID, "warehouse_id": params.WarehouseID}).
+		Suffix(stockLevelReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq change stock build query error: %w", err)
+	}
+	return scanStockLevel(q.db.QueryRow(ctx, query, args...))
+}
+
+func (q *RepoQueries) SqCreateReservation(
+	ctx context.Context,
+	params SqCreateReservationParams,
+) (*SqReservationRow, error) {
+	query, args, err := sq.Insert(ReservationsTable).
+		Columns("product_id", "warehouse_id", "quantity", "expires_at").
+		Values(params.ProductID, params.WarehouseID, params.Quantity, params.ExpiresAt).
+		Suffix(reservationReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq create reservation build query error: %w", err)
+	}
+	return scanReservation(q.db.QueryRow(ctx, query, args...))
+}
+
+func (q *RepoQueries) SqGetReservation(
+	ctx context.Context,
+	id pgtype.UUID,
+) (*SqReservationRow, error) {
+	query, args, err := buildGetReservationQuery(id, false)
+	if err != nil {
+		return nil, fmt.Errorf("sq get reservation build query error: %w", err)
+	}
+	return scanReservation(q.readDB.QueryRow(ctx, query, args...))
+}
+
+// SqLockReservation reads the reservation from the primary and locks it
+// until the transaction ends.
+func (q *RepoQueries) SqLockReservation(
+	ctx context.Context,
+	id pgtype.UUID,
+) (*SqReservationRow, error) {
+	query, args, err := buildGetReservationQuery(id, true)
+	if err != nil {
+		return nil, fmt.Errorf("sq lock reservation build query error: %w", err)
+	}
+	return scanReservation(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildGetReservationQuery(
+	id pgtype.UUID,
+	forUpdate bool,
+) (string, []interface{}, error) {
+	query := sq.Select(reservationColumns...).
+		From(ReservationsTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar)
+	if forUpdate {
+		query = query.Suffix("FOR UPDATE")
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get reservation query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqSetReservationStatus(
+	ctx context.Context,
+	params SqSetReservationStatusParams,
+) (*SqReservationRow, error) {
+	query, args, err := sq.Update(ReservationsTable).
+		Set("status", params.Status).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID}).
+		Suffix(reservationReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq set reservation status build query error: %w", err)
+	}
+	return scanReservation(q.db.QueryRow(ctx, query, args...))
+}
+
+// SqExpireReservations expires up to limit overdue reservations and returns
+// their units to stock in one statement. Reservations locked by a running
+// release or commit are skipped and left to that transaction.
+func (q *RepoQueries) SqExpireReservations(
+	ctx context.Context,
+	limit int64,
+) (int64, error) {
+	query, args, err := buildExpireReservationsQuery(limit)
+	if err != nil {
+		return 0, fmt.Errorf("sq expire reservations build query error: %w", err)
+	}
+	var expired int64
+	err = q.db.QueryRow(ctx, query, args...).Scan(&expired)
+	return expired, err
+}
+
+func buildExpireReservationsQuery(limit int64) (string, []interface{}, error) {
+	overdue := sq.Select("id").
+		From(ReservationsTable).
+		Where(sq.Eq{"status": "active"}).
+		Where("expires_at <= NOW()").
+		OrderBy("expires_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+	expired := sq.Update(ReservationsTable).
+		Set("status", "expired").
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("id IN (?)", overdue)).
+		Where(sq.Eq{"status": "active"}).
+		Suffix("RETURNING product_id, warehouse_id, quantity")
+	expiredSQL, args, err := expired.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	query := sq.Select("COUNT(*)").
+		From("expired").
+		Prefix(
+			"WITH expired AS ("+expiredSQL+"), "+
+				"totals AS (SELECT product_id, warehouse_id, SUM(quantity)::bigint AS quantity "+
+				"FROM expired GROUP BY product_id, warehouse_id), "+
+				"released AS (UPDATE stock_levels SET reserved = stock_levels.reserved - totals.quantity, updated_at = NOW() "+
+				"FROM totals WHERE stock_levels.product_id = totals.product_id "+
+				"AND stock_levels.warehouse_id = totals.warehouse_id RETURNING 1)",
+			args...,
+		).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqCreateAdjustment(
+	ctx context.Context,
+	params SqCreateAdjustmentParams,
+) (*SqAdjustmentRow, error) {
+	query, args, err := sq.Insert(AdjustmentsTable).
+		Columns("product_id", "warehouse_id", "delta", "reason", "note", "reservation_id").
+		Values(params.ProductID, params.WarehouseID, params.Delta, params.Reason, params.Note, params.ReservationID).
+		Suffix(adjustmentReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq create adjustment build query error: %w", err)
+	}
+	return scanAdjustment(q.db.QueryRow(ctx, query, args...))
+}
+
+func (q *RepoQueries) SqGetAdjustments(
+	ctx context.Context,
+	params SqGetAdjustmentsParams,
+) ([]SqAdjustmentRow, error) {
+	query, args, err := buildGetAdjustmentsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get adjustments build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, scanAdjustment)
+}
+
+func buildGetAdjustmentsQuery(
+	params SqGetAdjustmentsParams,
+) (string, []interface{}, error) {
+	query := sq.Select(adjustmentColumns...).
+		From(AdjustmentsTable).
+		Where(sq.Eq{"product_id": params.ProductID}).
+		OrderBy("id DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		PlaceholderFormat(sq.Dollar)
+	if params.WarehouseID.Valid {
+		query = query.Where(sq.Eq{"warehouse_id": params.WarehouseID})
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get adjustments query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
@@ -0,0 +1,215 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+)
+
+// The tests run against the database at DB_DSN with the migrations applied
+// and clean up the rows they create.
+
+// testStock creates a product and a warehouse holding onHand units of it.
+func testStock(t *testing.T, onHand int64) (*Repository, *pgxpool.Pool, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	repo := NewInventoryRepository(pool, pool)
+
+	var productID uuid.UUID
+	err = pool.QueryRow(ctx,
+		"INSERT INTO products (name, title) VALUES ($1, $1) RETURNING id",
+		"inventory test "+t.Name(),
+	).Scan(&productID)
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	warehouse, err := repo.CreateWarehouse(ctx, inventoryDomain.CreateWarehouseDTO{
+		Code: "test-" + uuid.NewString()[:8],
+		Name: "Inventory test",
+	})
+	if err != nil {
+		t.Fatalf("create warehouse: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM products WHERE id = $1", productID)
+		_, _ = pool.Exec(ctx, "DELETE FROM warehouses WHERE id = $1", warehouse.ID)
+	})
+
+	_, err = repo.AdjustStock(ctx, inventoryDomain.AdjustStockDTO{
+		ProductID:   productID,
+		WarehouseID: warehouse.ID,
+		Delta:       onHand,
+		Reason:      "received",
+	})
+	if err != nil {
+		t.Fatalf("adjust stock: %v", err)
+	}
+	return repo, pool, productID, warehouse.ID
+}
+
+func testStockLevel(t *testing.T, repo *Repository, productID uuid.UUID) inventoryDomain.StockLevel {
+	t.Helper()
+	levels, err := repo.GetProductStock(context.Background(), inventoryDomain.GetProductStockDTO{ProductID: productID})
+	if err != nil {
+		t.Fatalf("get product stock: %v", err)
+	}
+	if len(levels) != 1 {
+		t.Fatalf("got %d stock levels, want 1", len(levels))
+	}
+	return levels[0]
+}
+
+func TestReserveStockConcurrently(t *testing.T) {
+	const onHand, workers = 10, 50
+	repo, _, productID, warehouseID := testStock(t, onHand)
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		reserved     int64
+		insufficient int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.ReserveStock(context.Background(), inventoryDomain.ReserveStockDTO{
+				ProductID:   productID,
+				WarehouseID: warehouseID,
+				Quantity:    1,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				reserved++
+			case errors.Is(err, inventoryDomain.ErrInsufficientStock):
+				insufficient++
+			default:
+				t.Errorf("reserve stock: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved != onHand {
+		t.Errorf("got %d reservations, want %d", reserved, onHand)
+	}
+	if insufficient != workers-onHand {
+		t.Errorf("got %d insufficient stock errors, want %d", insufficient, workers-onHand)
+	}
+	level := testStockLevel(t, repo, productID)
+	if level.OnHand != onHand || level.Reserved != reserved {
+		t.Errorf("got on hand %d reserved %d, want %d and %d", level.OnHand, level.Reserved, onHand, reserved)
+	}
+	if level.Reserved > level.OnHand || level.Available != 0 {
+		t.Errorf("oversold: on hand %d reserved %d available %d", level.OnHand, level.Reserved, level.Available)
+	}
+}
+
+func TestExpireReservationsWhileCommitting(t *testing.T) {
+	const onHand = 20
+	repo, pool, productID, warehouseID := testStock(t, onHand)
+	ctx := context.Background()
+
+	// The reservations are overdue, so the sweeper races every commit.
+	ids := make([]uuid.UUID, 0, onHand)
+	for i := 0; i < onHand; i++ {
+		reservation, err := repo.ReserveStock(ctx, inventoryDomain.ReserveStockDTO{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Quantity:    1,
+			ExpiresAt:   time.Now().Add(-24 * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("reserve stock: %v", err)
+		}
+		ids = append(ids, reservation.ID)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		committed int64
+	)
+	for _, id := range ids {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := repo.CommitReservation(ctx, inventoryDomain.CommitReservationDTO{ID: id})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				committed++
+			case !errors.Is(err, inventoryDomain.ErrReservationNotActive):
+				t.Errorf("commit reservation: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := repo.ExpireReservations(ctx, inventoryDomain.ExpireReservationsDTO{Limit: 5}); err != nil {
+				t.Errorf("expire reservations: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// Sweep what the racing sweepers skipped while it was locked.
+	if _, err := repo.ExpireReservations(ctx, inventoryDomain.ExpireReservationsDTO{Limit: onHand}); err != nil {
+		t.Fatalf("expire reservations: %v", err)
+	}
+
+	var expired int64
+	for _, id := range ids {
+		reservation, err := repo.GetReservation(ctx, inventoryDomain.GetReservationDTO{ID: id})
+		if err != nil {
+			t.Fatalf("get reservation: %v", err)
+		}
+		switch reservation.Status {
+		case inventoryDomain.ReservationExpired:
+			expired++
+		case inventoryDomain.ReservationCommitted:
+		default:
+			t.Errorf("reservation %s is %s", id, reservation.Status)
+		}
+	}
+	if committed+expired != onHand {
+		t.Errorf("got %d committed and %d expired, want %d in total", committed, expired, onHand)
+	}
+
+	level := testStockLevel(t, repo, productID)
+	if level.Reserved != 0 {
+		t.Errorf("got reserved %d, want 0", level.Reserved)
+	}
+	if level.OnHand != onHand-committed {
+		t.Errorf("got on hand %d, want %d", level.OnHand, onHand-committed)
+	}
+	var sales int64
+	err := pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM stock_adjustments WHERE product_id = $1 AND reason = $2",
+		productID, inventoryDomain.ReasonSale,
+	).Scan(&sales)
+	if err != nil {
+		t.Fatalf("count sales: %v", err)
+	}
+	if sales != committed {
+		t.Errorf("got %d sale adjustments, want %d", sales, committed)
+	}
+}
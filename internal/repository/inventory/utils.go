@@ -0,0 +1,7 @@
+package inventory
+
+import "strings"
+
+func joinColumns(columns []string) string {
+	return strings.Join(columns, ", ")
+}
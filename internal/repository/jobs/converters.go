@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+func NConvertPgTimestamp(value pgtype.Timestamp) *time.Time {
+	if value.Valid {
+		return &value.Time
+	}
+	return nil
+}
+
+func NConvertPgText(value pgtype.Text) *string {
+	if value.Valid {
+		return &value.String
+	}
+	return nil
+}
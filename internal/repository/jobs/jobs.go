@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	jobsDomain "go_template_project/internal/domain/jobs"
+)
+
+func (r *Repository) EnqueueJob(
+	ctx context.Context,
+	data jobsDomain.EnqueueJobDTO,
+) (*jobsDomain.Job, error) {
+	params := SqEnqueueJobParams{
+		Kind:        data.Kind,
+		Payload:     data.Payload,
+		MaxAttempts: int32(data.MaxAttempts),
+	}
+	if params.MaxAttempts <= 0 {
+		params.MaxAttempts = jobsDomain.DefaultMaxAttempts
+	}
+	sqJob, err := r.queries.SqEnqueueJob(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq enqueue job error: %w", err)
+	}
+	return convertJob(sqJob), nil
+}
+
+func (r *Repository) GetJob(
+	ctx context.Context,
+	data jobsDomain.GetJobDTO,
+) (*jobsDomain.Job, error) {
+	params := SqGetJobParams{
+		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+	}
+	sqJob, err := r.queries.SqGetJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, jobsDomain.ErrJobNotFound
+		}
+		return nil, fmt.Errorf("sq get job error: %w", err)
+	}
+	return convertJob(sqJob), nil
+}
+
+func (r *Repository) GetJobArtifact(
+	ctx context.Context,
+	data jobsDomain.GetJobDTO,
+) (*jobsDomain.Artifact, error) {
+	params := SqGetJobParams{
+		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+	}
+	sqArtifact, err := r.queries.SqGetJobArtifact(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, jobsDomain.ErrJobArtifactNotFound
+		}
+		return nil, fmt.Errorf("sq get job artifact error: %w", err)
+	}
+	return &jobsDomain.Artifact{
+		Name:        sqArtifact.Name.String,
+		ContentType: sqArtifact.ContentType.String,
+		Key:         sqArtifact.Key.String,
+	}, nil
+}
+
+// ClaimJob returns nil without error when there is nothing to run.
+func (r *Repository) ClaimJob(
+	ctx context.Context,
+	data jobsDomain.ClaimJobDTO,
+) (*jobsDomain.Job, error) {
+	params := SqClaimJobParams{
+		WorkerID:         data.WorkerID,
+		Kinds:            data.Kinds,
+		LeaseTimeoutSecs: data.LeaseTimeout.Seconds(),
+	}
+	sqJob, err := r.queries.SqClaimJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("sq claim job error: %w", err)
+	}
+	return convertJob(sqJob), nil
+}
+
+func (r *Repository) HeartbeatJob(
+	ctx context.Context,
+	data jobsDomain.HeartbeatJobDTO,
+) (*jobsDomain.Job, error) {
+	params := SqHeartbeatJobParams{
+		ID:            pgtype.UUID{Bytes: data.ID, Valid: true},
+		WorkerID:      data.WorkerID,
+		ProgressDone:  data.Progress.Done,
+		ProgressTotal: data.Progress.Total,
+	}
+	sqJob, err := r.queries.SqHeartbeatJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, jobsDomain.ErrJobLeaseLost
+		}
+		return nil, fmt.Errorf("sq heartbeat job error: %w", err)
+	}
+	return convertJob(sqJob), nil
+}
+
+func (r *Repository) FinishJob(
+	ctx context.Context,
+	data jobsDomain.FinishJobDTO,
+) (*jobsDomain.Job, error) {
+	params := SqFinishJobParams{
+		ID:             pgtype.UUID{Bytes: data.ID, Valid: true},
+		WorkerID:       data.WorkerID,
+		Status:         data.Status,
+		ProgressDone:   data.Progress.Done,
+		ProgressTotal:  data.Progress.Total,
+		Result:         data.Result,
+		LastError:      pgtype.Text{String: data.Error, Valid: data.Error != ""},
+		Retry:          data.Retry,
+		RetryAfterSecs: data.RetryAfter.Seconds(),
+	}
+	if data.Artifact != nil {
+		params.ArtifactKey = pgtype.Text{String: data.Artifact.Key, Valid: true}
+		params.ArtifactName = pgtype.Text{String: data.Artifact.Name, Valid: true}
+		params.ArtifactContentType = pgtype.Text{String: data.Artifact.ContentType, Valid: true}
+	}
+	sqJob, err := r.queries.SqFinishJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, jobsDomain.ErrJobLeaseLost
+		}
+		return nil, fmt.Errorf("sq finish job error: %w", err)
+	}
+	return convertJob(sqJob), nil
+}
+
+func (r *Repository) CancelJob(
+	ctx context.Context,
+	data jobsDomain.CancelJobDTO,
+) (*jobsDomain.Job, error) {
+	params := SqCancelJobParams{
+		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+	}
+	sqJob, err := r.queries.SqCancelJob(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Either there is no such job or it is already finished
+			if _, err := r.GetJob(ctx, jobsDomain.GetJobDTO{ID: data.ID}); err != nil {
+				return nil, err
+			}
+			return nil, jobsDomain.ErrJobFinished
+		}
+		return nil, fmt.Errorf("sq cancel job error: %w", err)
+	}
+	return convertJob(sqJob), nil
+}
+
+func convertJob(sqJob *SqJobRow) *jobsDomain.Job {
+	return &jobsDomain.Job{
+		ID:                  sqJob.ID.Bytes,
+		Kind:                sqJob.Kind,
+		Status:              sqJob.Status,
+		Payload:             sqJob.Payload,
+		Result:              sqJob.Result,
+		HasArtifact:         sqJob.HasArtifact,
+		ArtifactName:        NConvertPgText(sqJob.ArtifactName),
+		ArtifactContentType: NConvertPgText(sqJob.ArtifactContentType),
+		Progress: jobsDomain.Progress{
+			Done:  sqJob.ProgressDone,
+			Total: sqJob.ProgressTotal,
+		},
+		Attempts:        int(sqJob.Attempts),
+		MaxAttempts:     int(sqJob.MaxAttempts),
+		LastError:       NConvertPgText(sqJob.LastError),
+		CancelRequested: sqJob.CancelRequested,
+		RunAt:           sqJob.RunAt.Time,
+		CreatedAt:       sqJob.CreatedAt.Time,
+		UpdatedAt:       sqJob.UpdatedAt.Time,
+		StartedAt:       NConvertPgTimestamp(sqJob.StartedAt),
+		FinishedAt:      NConvertPgTimestamp(sqJob.FinishedAt),
+	}
+}
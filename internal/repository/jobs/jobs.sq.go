@@ -0,0 +1,371 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	JobsTable = "jobs"
+)
+
+var jobColumns = []string{
+	"id",
+	"kind",
+	"status",
+	"payload",
+	"result",
+	"artifact_key IS NOT NULL",
+	"artifact_name",
+	"artifact_content_type",
+	"progress_done",
+	"progress_total",
+	"attempts",
+	"max_attempts",
+	"last_error",
+	"cancel_requested",
+	"run_at",
+	"created_at",
+	"updated_at",
+	"started_at",
+	"finished_at",
+}
+
+var jobReturning = "RETURNING " + joinColumns(jobColumns)
+
+type SqJobRow struct {
+	ID                  pgtype.UUID
+	Kind                string
+	Status              string
+	Payload             []byte
+	Result              []byte
+	HasArtifact         bool
+	ArtifactName        pgtype.Text
+	ArtifactContentType pgtype.Text
+	ProgressDone        int64
+	ProgressTotal       int64
+	Attempts            int32
+	MaxAttempts         int32
+	LastError           pgtype.Text
+	CancelRequested     bool
+	RunAt               pgtype.Timestamp
+	CreatedAt           pgtype.Timestamp
+	UpdatedAt           pgtype.Timestamp
+	StartedAt           pgtype.Timestamp
+	FinishedAt          pgtype.Timestamp
+}
+
+type SqJobArtifactRow struct {
+	Name        pgtype.Text
+	ContentType pgtype.Text
+	Key         pgtype.Text
+}
+
+type SqEnqueueJobParams struct {
+	Kind        string
+	Payload     []byte
+	MaxAttempts int32
+}
+
+type SqGetJobParams struct {
+	ID pgtype.UUID
+}
+
+type SqClaimJobParams struct {
+	WorkerID         string
+	Kinds            []string
+	LeaseTimeoutSecs float64
+}
+
+type SqHeartbeatJobParams struct {
+	ID            pgtype.UUID
+	WorkerID      string
+	ProgressDone  int64
+	ProgressTotal int64
+}
+
+type SqFinishJobParams struct {
+	ID                  pgtype.UUID
+	WorkerID            string
+	Status              string
+	ProgressDone        int64
+	ProgressTotal       int64
+	Result              []byte
+	ArtifactKey         pgtype.Text
+	ArtifactName        pgtype.Text
+	ArtifactContentType pgtype.Text
+	LastError           pgtype.Text
+	Retry               bool
+	RetryAfterSecs      float64
+}
+
+type SqCancelJobParams struct {
+	ID pgtype.UUID
+}
+
+func scanJob(row pgx.Row) (*SqJobRow, error) {
+	var i SqJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Status,
+		&i.Payload,
+		&i.Result,
+		&i.HasArtifact,
+		&i.ArtifactName,
+		&i.ArtifactContentType,
+		&i.ProgressDone,
+		&i.ProgressTotal,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.LastError,
+		&i.CancelRequested,
+		&i.RunAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return &i, err
+}
+
+func (q *RepoQueries) SqEnqueueJob(
+	ctx context.Context,
+	params SqEnqueueJobParams,
+) (*SqJobRow, error) {
+	query, args, err := buildEnqueueJobQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq enqueue job build query error: %w", err)
+	}
+	return scanJob(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildEnqueueJobQuery(
+	params SqEnqueueJobParams,
+) (string, []interface{}, error) {
+	query := sq.Insert(JobsTable).
+		Columns("kind", "payload", "max_attempts").
+		Values(params.Kind, params.Payload, params.MaxAttempts).
+		Suffix(jobReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetJob(
+	ctx context.Context,
+	params SqGetJobParams,
+) (*SqJobRow, error) {
+	query, args, err := buildGetJobQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get job build query error: %w", err)
+	}
+	return scanJob(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildGetJobQuery(
+	params SqGetJobParams,
+) (string, []interface{}, error) {
+	query := sq.Select(jobColumns...).
+		From(JobsTable).
+		Where(sq.Eq{"id": params.ID}).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get job query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetJobArtifact(
+	ctx context.Context,
+	params SqGetJobParams,
+) (*SqJobArtifactRow, error) {
+	query, args, err := buildGetJobArtifactQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get job artifact build query error: %w", err)
+	}
+	row := q.db.QueryRow(ctx, query, args...)
+	var i SqJobArtifactRow
+	err = row.Scan(
+		&i.Name,
+		&i.ContentType,
+		&i.Key,
+	)
+	return &i, err
+}
+
+func buildGetJobArtifactQuery(
+	params SqGetJobParams,
+) (string, []interface{}, error) {
+	query := sq.Select("artifact_name", "artifact_content_type", "artifact_key").
+		From(JobsTable).
+		Where(sq.Eq{"id": params.ID}).
+		Where("artifact_key IS NOT NULL").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get job artifact query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqClaimJob locks the oldest runnable job with FOR UPDATE SKIP LOCKED, so
+// concurrent workers never pick the same row. Running jobs whose lease was not
+// renewed within the timeout are considered abandoned and claimed again.
+func (q *RepoQueries) SqClaimJob(
+	ctx context.Context,
+	params SqClaimJobParams,
+) (*SqJobRow, error) {
+	query, args, err := buildClaimJobQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq claim job build query error: %w", err)
+	}
+	return scanJob(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildClaimJobQuery(
+	params SqClaimJobParams,
+) (string, []interface{}, error) {
+	next := sq.Select("id").
+		From(JobsTable).
+		Where(sq.Expr("kind = ANY(?)", params.Kinds)).
+		Where(sq.Or{
+			sq.Expr("status = 'queued' AND run_at <= NOW()"),
+			sq.Expr(
+				"status = 'running' AND locked_at < NOW() - make_interval(secs => ?)",
+				params.LeaseTimeoutSecs,
+			),
+		}).
+		OrderBy("run_at").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query := sq.Update(JobsTable).
+		Set("status", "running").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("locked_by", params.WorkerID).
+		Set("locked_at", sq.Expr("NOW()")).
+		Set("started_at", sq.Expr("COALESCE(started_at, NOW())")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("id = (?)", next)).
+		Suffix(jobReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqHeartbeatJob(
+	ctx context.Context,
+	params SqHeartbeatJobParams,
+) (*SqJobRow, error) {
+	query, args, err := buildHeartbeatJobQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq heartbeat job build query error: %w", err)
+	}
+	return scanJob(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildHeartbeatJobQuery(
+	params SqHeartbeatJobParams,
+) (string, []interface{}, error) {
+	query := sq.Update(JobsTable).
+		Set("locked_at", sq.Expr("NOW()")).
+		Set("progress_done", params.ProgressDone).
+		Set("progress_total", params.ProgressTotal).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID, "locked_by": params.WorkerID, "status": "running"}).
+		Suffix(jobReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqFinishJob(
+	ctx context.Context,
+	params SqFinishJobParams,
+) (*SqJobRow, error) {
+	query, args, err := buildFinishJobQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq finish job build query error: %w", err)
+	}
+	return scanJob(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildFinishJobQuery(
+	params SqFinishJobParams,
+) (string, []interface{}, error) {
+	query := sq.Update(JobsTable).
+		Set("locked_by", nil).
+		Set("locked_at", nil).
+		Set("last_error", params.LastError).
+		Set("progress_done", params.ProgressDone).
+		Set("progress_total", params.ProgressTotal).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID, "locked_by": params.WorkerID, "status": "running"}).
+		Suffix(jobReturning).
+		PlaceholderFormat(sq.Dollar)
+
+	if params.Retry {
+		query = query.
+			Set("status", "queued").
+			Set("run_at", sq.Expr("NOW() + make_interval(secs => ?)", params.RetryAfterSecs))
+	} else {
+		query = query.
+			Set("status", params.Status).
+			Set("result", params.Result).
+			Set("artifact_key", params.ArtifactKey).
+			Set("artifact_name", params.ArtifactName).
+			Set("artifact_content_type", params.ArtifactContentType).
+			Set("finished_at", sq.Expr("NOW()"))
+	}
+
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+// SqCancelJob cancels a queued job right away and flags a running one, so
+// its worker stops on the next heartbeat.
+func (q *RepoQueries) SqCancelJob(
+	ctx context.Context,
+	params SqCancelJobParams,
+) (*SqJobRow, error) {
+	query, args, err := buildCancelJobQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq cancel job build query error: %w", err)
+	}
+	return scanJob(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildCancelJobQuery(
+	params SqCancelJobParams,
+) (string, []interface{}, error) {
+	query := sq.Update(JobsTable).
+		Set("status", sq.Expr("CASE WHEN status = 'queued' THEN 'canceled' ELSE status END")).
+		Set("finished_at", sq.Expr("CASE WHEN status = 'queued' THEN NOW() ELSE finished_at END")).
+		Set("cancel_requested", sq.Expr("status = 'running'")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID, "status": []string{"queued", "running"}}).
+		Suffix(jobReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
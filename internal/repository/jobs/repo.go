@@ -0,0 +1,19 @@
+package jobs
+
+type RepoQueries struct {
+	db DBTX
+}
+
+type Repository struct {
+	queries RepoQueries
+}
+
+func NewJobsRepository(db DBTX) *Repository {
+	return &Repository{
+		queries: *New(db),
+	}
+}
+
+func New(db DBTX) *RepoQueries {
+	return &RepoQueries{db: db}
+}
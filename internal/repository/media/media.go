@@ -0,0 +1,287 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"go_template_project/internal/repository/dbtx"
+)
+
+// CreateMedia adds the media to the end of the gallery of a product that is
+// not soft-deleted.
+func (r *Repository) CreateMedia(
+	ctx context.Context,
+	data mediaDomain.CreateMediaDTO,
+) (*mediaDomain.Media, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+
+	var sqMedia *SqMediaRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockProduct(ctx, productID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+
+		var err error
+		sqMedia, err = q.SqCreateMedia(ctx, SqCreateMediaParams{
+			ID:          pgtype.UUID{Bytes: data.ID, Valid: true},
+			ProductID:   productID,
+			ContentType: data.ContentType,
+			FileSize:    data.FileSize,
+			Width:       int32(data.Width),
+			Height:      int32(data.Height),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, mediaError("sq create media error", err)
+	}
+	return convertMedia(sqMedia), nil
+}
+
+func (r *Repository) GetMedia(
+	ctx context.Context,
+	data mediaDomain.GetMediaDTO,
+) (*mediaDomain.Media, error) {
+	params := SqGetMediaParams{
+		ID:                 pgtype.UUID{Bytes: data.ID, Valid: true},
+		WithDeletedProduct: data.WithDeletedProduct,
+	}
+	sqMedia, err := r.queries.SqGetMedia(ctx, params)
+	if err != nil {
+		return nil, mediaError("sq get media error", err)
+	}
+	return convertMedia(sqMedia), nil
+}
+
+func (r *Repository) GetProductMedia(
+	ctx context.Context,
+	data mediaDomain.GetProductMediaDTO,
+) ([]mediaDomain.Media, error) {
+	sqMedia, err := r.queries.SqGetProductMedia(ctx, pgtype.UUID{Bytes: data.ProductID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("sq get product media error: %w", err)
+	}
+	media := make([]mediaDomain.Media, 0, len(sqMedia))
+	for i := range sqMedia {
+		media = append(media, *convertMedia(&sqMedia[i]))
+	}
+	return media, nil
+}
+
+// GetProductsMedia groups the galleries by product, products without media
+// are left out.
+func (r *Repository) GetProductsMedia(
+	ctx context.Context,
+	data mediaDomain.GetProductsMediaDTO,
+) (map[uuid.UUID][]mediaDomain.Media, error) {
+	productIDs := make([]pgtype.UUID, 0, len(data.ProductIDs))
+	for _, id := range data.ProductIDs {
+		productIDs = append(productIDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	sqMedia, err := r.queries.SqGetProductsMedia(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products media error: %w", err)
+	}
+	media := make(map[uuid.UUID][]mediaDomain.Media)
+	for i := range sqMedia {
+		item := convertMedia(&sqMedia[i])
+		media[item.ProductID] = append(media[item.ProductID], *item)
+	}
+	return media, nil
+}
+
+// SetMediaOrder reorders the gallery while holding the product lock, so
+// media can not be added in between.
+func (r *Repository) SetMediaOrder(
+	ctx context.Context,
+	data mediaDomain.SetMediaOrderDTO,
+) ([]mediaDomain.Media, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+
+	var sqMedia []SqMediaRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockProduct(ctx, productID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+		current, err := q.SqGetProductMedia(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if !sameMedia(current, data.IDs) {
+			return mediaDomain.ErrInvalidMediaOrder
+		}
+
+		ids := make([]pgtype.UUID, 0, len(data.IDs))
+		for _, id := range data.IDs {
+			ids = append(ids, pgtype.UUID{Bytes: id, Valid: true})
+		}
+		err = q.SqSetMediaPositions(ctx, SqSetMediaPositionsParams{
+			ProductID: productID,
+			IDs:       ids,
+		})
+		if err != nil {
+			return err
+		}
+		sqMedia, err = q.SqGetProductMedia(ctx, productID)
+		return err
+	})
+	if err != nil {
+		return nil, mediaError("sq set media order error", err)
+	}
+	media := make([]mediaDomain.Media, 0, len(sqMedia))
+	for i := range sqMedia {
+		media = append(media, *convertMedia(&sqMedia[i]))
+	}
+	return media, nil
+}
+
+// DeleteMedia detaches the media from its product, the files are removed by
+// the media cleaner.
+func (r *Repository) DeleteMedia(
+	ctx context.Context,
+	data mediaDomain.DeleteMediaDTO,
+) (*mediaDomain.Media, error) {
+	params := SqDetachMediaParams{
+		ID:        pgtype.UUID{Bytes: data.ID, Valid: true},
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+	}
+	sqMedia, err := r.queries.SqDetachMedia(ctx, params)
+	if err != nil {
+		return nil, mediaError("sq delete media error", err)
+	}
+	// the row no longer points to the product
+	media := convertMedia(sqMedia)
+	media.ProductID = data.ProductID
+	return media, nil
+}
+
+func (r *Repository) SetThumbnails(
+	ctx context.Context,
+	data mediaDomain.SetThumbnailsDTO,
+) (*mediaDomain.Media, error) {
+	params := SqSetThumbnailsParams{
+		ID:         pgtype.UUID{Bytes: data.ID, Valid: true},
+		Status:     data.Status,
+		Thumbnails: make([]SqThumbnail, 0, len(data.Thumbnails)),
+	}
+	for _, thumbnail := range data.Thumbnails {
+		params.Thumbnails = append(params.Thumbnails, SqThumbnail{
+			Size:        thumbnail.Size,
+			Width:       thumbnail.Width,
+			Height:      thumbnail.Height,
+			ContentType: thumbnail.ContentType,
+			FileSize:    thumbnail.FileSize,
+		})
+	}
+	sqMedia, err := r.queries.SqSetThumbnails(ctx, params)
+	if err != nil {
+		return nil, mediaError("sq set thumbnails error", err)
+	}
+	return convertMedia(sqMedia), nil
+}
+
+func (r *Repository) GetOrphanedMedia(
+	ctx context.Context,
+	data mediaDomain.GetOrphanedMediaDTO,
+) ([]uuid.UUID, error) {
+	sqIDs, err := r.queries.SqGetOrphanedMedia(ctx, uint64(data.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("sq get orphaned media error: %w", err)
+	}
+	ids := make([]uuid.UUID, 0, len(sqIDs))
+	for _, id := range sqIDs {
+		ids = append(ids, id.Bytes)
+	}
+	return ids, nil
+}
+
+func (r *Repository) PurgeMedia(
+	ctx context.Context,
+	data mediaDomain.PurgeMediaDTO,
+) (int64, error) {
+	ids := make([]pgtype.UUID, 0, len(data.IDs))
+	for _, id := range data.IDs {
+		ids = append(ids, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	purged, err := r.queries.SqPurgeMedia(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("sq purge media error: %w", err)
+	}
+	return purged, nil
+}
+
+// sameMedia reports whether ids lists every media of current exactly once.
+func sameMedia(current []SqMediaRow, ids []uuid.UUID) bool {
+	if len(current) != len(ids) {
+		return false
+	}
+	listed := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		listed[id] = true
+	}
+	for _, media := range current {
+		if !listed[media.ID.Bytes] {
+			return false
+		}
+	}
+	return true
+}
+
+// mediaError maps database errors to domain errors, wrapping anything
+// unexpected with msg.
+func mediaError(msg string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return mediaDomain.ErrMediaNotFound
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.ForeignKeyViolation); ok {
+		return productsDomain.ErrProductNotFound
+	}
+	for _, domainErr := range []error{
+		productsDomain.ErrProductNotFound,
+		mediaDomain.ErrInvalidMediaOrder,
+	} {
+		if errors.Is(err, domainErr) {
+			return domainErr
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func convertMedia(sqMedia *SqMediaRow) *mediaDomain.Media {
+	media := &mediaDomain.Media{
+		ID:          sqMedia.ID.Bytes,
+		ProductID:   sqMedia.ProductID.Bytes,
+		ContentType: sqMedia.ContentType,
+		FileSize:    sqMedia.FileSize,
+		Width:       int(sqMedia.Width),
+		Height:      int(sqMedia.Height),
+		Position:    sqMedia.Position,
+		Status:      sqMedia.Status,
+		URL:         mediaDomain.URL(sqMedia.ID.Bytes, mediaDomain.Original),
+		Thumbnails:  make([]mediaDomain.Thumbnail, 0, len(sqMedia.Thumbnails)),
+		CreatedAt:   sqMedia.CreatedAt.Time,
+		UpdatedAt:   sqMedia.UpdatedAt.Time,
+	}
+	for _, thumbnail := range sqMedia.Thumbnails {
+		media.Thumbnails = append(media.Thumbnails, mediaDomain.Thumbnail{
+			Size:        thumbnail.Size,
+			Width:       thumbnail.Width,
+			Height:      thumbnail.Height,
+			ContentType: thumbnail.ContentType,
+			FileSize:    thumbnail.FileSize,
+			URL:         mediaDomain.URL(sqMedia.ID.Bytes, mediaDomain.ThumbnailName(thumbnail.Size)),
+		})
+	}
+	return media
+}
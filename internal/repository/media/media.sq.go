@@ -0,0 +1,348 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const MediaTable = "product_media"
+
+var mediaColumns = []string{
+	"id",
+	"product_id",
+	"content_type",
+	"file_size",
+	"width",
+	"height",
+	"position",
+	"status",
+	"thumbnails",
+	"created_at",
+	"updated_at",
+}
+
+var mediaReturning = "RETURNING " + joinColumns(mediaColumns)
+
+type SqMediaRow struct {
+	ID          pgtype.UUID
+	ProductID   pgtype.UUID
+	ContentType string
+	FileSize    int64
+	Width       int32
+	Height      int32
+	Position    int32
+	Status      string
+	Thumbnails  []SqThumbnail
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+// SqThumbnail is an element of the thumbnails column.
+type SqThumbnail struct {
+	Size        int    `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+}
+
+type SqCreateMediaParams struct {
+	ID          pgtype.UUID
+	ProductID   pgtype.UUID
+	ContentType string
+	FileSize    int64
+	Width       int32
+	Height      int32
+}
+
+type SqGetMediaParams struct {
+	ID pgtype.UUID
+	// WithDeletedProduct also finds media of soft-deleted products
+	WithDeletedProduct bool
+}
+
+type SqSetMediaPositionsParams struct {
+	ProductID pgtype.UUID
+	IDs       []pgtype.UUID
+}
+
+type SqDetachMediaParams struct {
+	ID        pgtype.UUID
+	ProductID pgtype.UUID
+}
+
+type SqSetThumbnailsParams struct {
+	ID         pgtype.UUID
+	Status     string
+	Thumbnails []SqThumbnail
+}
+
+func scanMedia(row pgx.Row) (*SqMediaRow, error) {
+	var i SqMediaRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ContentType,
+		&i.FileSize,
+		&i.Width,
+		&i.Height,
+		&i.Position,
+		&i.Status,
+		&i.Thumbnails,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+func scanMediaRows(rows pgx.Rows) ([]SqMediaRow, error) {
+	defer rows.Close()
+	var items []SqMediaRow
+	for rows.Next() {
+		i, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SqLockProduct locks a product that is not soft-deleted until the
+// transaction ends, serialising changes to its gallery.
+func (q *RepoQueries) SqLockProduct(
+	ctx context.Context,
+	productID pgtype.UUID,
+) error {
+	var id pgtype.UUID
+	return q.db.QueryRow(
+		ctx,
+		"SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE",
+		productID,
+	).Scan(&id)
+}
+
+// SqCreateMedia appends the media to the end of the gallery.
+func (q *RepoQueries) SqCreateMedia(
+	ctx context.Context,
+	params SqCreateMediaParams,
+) (*SqMediaRow, error) {
+	query, args, err := buildCreateMediaQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq create media build query error: %w", err)
+	}
+	return scanMedia(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildCreateMediaQuery(
+	params SqCreateMediaParams,
+) (string, []interface{}, error) {
+	position := sq.Expr(
+		"(SELECT COALESCE(MAX(position) + 1, 0) FROM "+MediaTable+" WHERE product_id = ?)",
+		params.ProductID,
+	)
+	query := sq.Insert(MediaTable).
+		Columns("id", "product_id", "content_type", "file_size", "width", "height", "position").
+		Values(params.ID, params.ProductID, params.ContentType, params.FileSize, params.Width, params.Height, position).
+		Suffix(mediaReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq create media query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqGetMedia finds media attached to a product, by default only to one
+// that is not soft-deleted.
+func (q *RepoQueries) SqGetMedia(
+	ctx context.Context,
+	params SqGetMediaParams,
+) (*SqMediaRow, error) {
+	query, args, err := buildGetMediaQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get media build query error: %w", err)
+	}
+	return scanMedia(q.readDB.QueryRow(ctx, query, args...))
+}
+
+func buildGetMediaQuery(
+	params SqGetMediaParams,
+) (string, []interface{}, error) {
+	columns := make([]string, 0, len(mediaColumns))
+	for _, column := range mediaColumns {
+		columns = append(columns, MediaTable+"."+column)
+	}
+	query := sq.Select(columns...).
+		From(MediaTable).
+		Join("products ON products.id = " + MediaTable + ".product_id").
+		Where(sq.Eq{MediaTable + ".id": params.ID}).
+		PlaceholderFormat(sq.Dollar)
+	if !params.WithDeletedProduct {
+		query = query.Where(sq.Eq{"products.deleted_at": nil})
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get media query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetProductMedia(
+	ctx context.Context,
+	productID pgtype.UUID,
+) ([]SqMediaRow, error) {
+	query, args, err := sq.Select(mediaColumns...).
+		From(MediaTable).
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("position", "id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get product media build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanMediaRows(rows)
+}
+
+func (q *RepoQueries) SqGetProductsMedia(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) ([]SqMediaRow, error) {
+	query, args, err := sq.Select(mediaColumns...).
+		From(MediaTable).
+		Where(sq.Expr("product_id = ANY(?)", productIDs)).
+		OrderBy("product_id", "position", "id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get products media build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanMediaRows(rows)
+}
+
+// SqSetMediaPositions numbers the media of a product in the order of ids.
+func (q *RepoQueries) SqSetMediaPositions(
+	ctx context.Context,
+	params SqSetMediaPositionsParams,
+) error {
+	_, err := q.db.Exec(
+		ctx,
+		"UPDATE "+MediaTable+" SET position = o.position - 1, updated_at = NOW() "+
+			"FROM unnest($1::uuid[]) WITH ORDINALITY AS o(id, position) "+
+			"WHERE "+MediaTable+".id = o.id AND "+MediaTable+".product_id = $2",
+		params.IDs,
+		params.ProductID,
+	)
+	return err
+}
+
+// SqDetachMedia removes the media from the gallery, the cleaner deletes its
+// files and row later.
+func (q *RepoQueries) SqDetachMedia(
+	ctx context.Context,
+	params SqDetachMediaParams,
+) (*SqMediaRow, error) {
+	query, args, err := sq.Update(MediaTable).
+		Set("product_id", nil).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID, "product_id": params.ProductID}).
+		Suffix(mediaReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq detach media build query error: %w", err)
+	}
+	return scanMedia(q.db.QueryRow(ctx, query, args...))
+}
+
+// SqSetThumbnails records generated thumbnails, detached media is left
+// alone.
+func (q *RepoQueries) SqSetThumbnails(
+	ctx context.Context,
+	params SqSetThumbnailsParams,
+) (*SqMediaRow, error) {
+	thumbnails := params.Thumbnails
+	if thumbnails == nil {
+		thumbnails = []SqThumbnail{}
+	}
+	query, args, err := sq.Update(MediaTable).
+		Set("status", params.Status).
+		Set("thumbnails", thumbnails).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID}).
+		Where(sq.NotEq{"product_id": nil}).
+		Suffix(mediaReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq set thumbnails build query error: %w", err)
+	}
+	return scanMedia(q.db.QueryRow(ctx, query, args...))
+}
+
+// SqGetOrphanedMedia lists media detached from their product, oldest first.
+func (q *RepoQueries) SqGetOrphanedMedia(
+	ctx context.Context,
+	limit uint64,
+) ([]pgtype.UUID, error) {
+	query, args, err := sq.Select("id").
+		From(MediaTable).
+		Where(sq.Eq{"product_id": nil}).
+		OrderBy("updated_at").
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get orphaned media build query error: %w", err)
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// SqPurgeMedia deletes rows of orphaned media.
+func (q *RepoQueries) SqPurgeMedia(
+	ctx context.Context,
+	ids []pgtype.UUID,
+) (int64, error) {
+	query, args, err := sq.Delete(MediaTable).
+		Where(sq.Eq{"id": ids, "product_id": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("sq purge media build query error: %w", err)
+	}
+	tag, err := q.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
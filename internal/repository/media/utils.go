@@ -0,0 +1,7 @@
+package media
+
+import "strings"
+
+func joinColumns(columns []string) string {
+	return strings.Join(columns, ", ")
+}
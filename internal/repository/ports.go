@@ -2,7 +2,15 @@ package repository
 
 import (
 	"context"
+	"github.com/google/uuid"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	mediaDomain "go_template_project/internal/domain/media"
+	pricesDomain "go_template_project/internal/domain/prices"
 	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	variantsDomain "go_template_project/internal/domain/variants"
 )
 
 type (
@@ -11,10 +19,27 @@ type (
 			ctx context.Context,
 			data productsDomain.GetProductsDTO,
 		) ([]productsDomain.Product, error)
+		GetProductsStats(
+			ctx context.Context,
+			data productsDomain.GetProductsDTO,
+		) (*productsDomain.ProductsStats, error)
+		GetProductsFacets(
+			ctx context.Context,
+			data productsDomain.GetProductsFacetsDTO,
+		) (*productsDomain.ProductsFacets, error)
 		GetProduct(
 			ctx context.Context,
 			data productsDomain.GetProductDTO,
 		) (*productsDomain.Product, error)
+		GetProductsByIDs(
+			ctx context.Context,
+			data productsDomain.GetProductsByIDsDTO,
+		) ([]productsDomain.Product, error)
+		ExportProducts(
+			ctx context.Context,
+			data productsDomain.ExportProductsDTO,
+			fn func(productsDomain.Product) error,
+		) error
 		CreateProduct(
 			ctx context.Context,
 			data productsDomain.CreateProductDTO,
@@ -27,6 +52,10 @@ type (
 			ctx context.Context,
 			data productsDomain.DeleteProductDTO,
 		) (*productsDomain.Product, error)
+		PurgeDeletedProducts(
+			ctx context.Context,
+			data productsDomain.PurgeDeletedProductsDTO,
+		) ([]uuid.UUID, error)
 		BulkCreateProducts(
 			ctx context.Context,
 			data []productsDomain.Product,
@@ -36,5 +65,269 @@ type (
 			updateFields []string,
 			data []productsDomain.Product,
 		) ([]productsDomain.Product, error)
+		GetProductTranslations(
+			ctx context.Context,
+			data productsDomain.GetProductTranslationsDTO,
+		) ([]productsDomain.ProductTranslation, error)
+		GetMissingTranslations(
+			ctx context.Context,
+			data productsDomain.GetMissingTranslationsDTO,
+		) ([]productsDomain.MissingTranslation, error)
+		GetTagCounts(
+			ctx context.Context,
+			data tagsDomain.GetTagCountsDTO,
+		) ([]tagsDomain.TagCount, error)
+	}
+
+	CategoriesRepository interface {
+		GetCategories(
+			ctx context.Context,
+			data categoriesDomain.GetCategoriesDTO,
+		) ([]categoriesDomain.Category, error)
+		GetCategory(
+			ctx context.Context,
+			data categoriesDomain.GetCategoryDTO,
+		) (*categoriesDomain.Category, error)
+		GetCategoryBreadcrumbs(
+			ctx context.Context,
+			data categoriesDomain.GetCategoryDTO,
+		) ([]categoriesDomain.Category, error)
+		CreateCategory(
+			ctx context.Context,
+			data categoriesDomain.CreateCategoryDTO,
+		) (*categoriesDomain.Category, error)
+		PartialUpdateCategory(
+			ctx context.Context,
+			data categoriesDomain.PartialUpdateCategoryDTO,
+		) (*categoriesDomain.Category, error)
+		DeleteCategory(
+			ctx context.Context,
+			data categoriesDomain.DeleteCategoryDTO,
+		) (*categoriesDomain.Category, error)
+		MoveCategory(
+			ctx context.Context,
+			data categoriesDomain.MoveCategoryDTO,
+		) (*categoriesDomain.Category, error)
+		ReorderCategories(
+			ctx context.Context,
+			data categoriesDomain.ReorderCategoriesDTO,
+		) ([]categoriesDomain.Category, error)
+		GetProductCategories(
+			ctx context.Context,
+			data categoriesDomain.GetProductCategoriesDTO,
+		) ([]categoriesDomain.Category, error)
+		GetProductsCategories(
+			ctx context.Context,
+			data categoriesDomain.GetProductsCategoriesDTO,
+		) (map[uuid.UUID][]categoriesDomain.Category, error)
+		SetProductCategories(
+			ctx context.Context,
+			data categoriesDomain.SetProductCategoriesDTO,
+		) ([]categoriesDomain.Category, error)
+	}
+
+	PricesRepository interface {
+		GetProductPrices(
+			ctx context.Context,
+			data pricesDomain.GetProductPricesDTO,
+		) ([]pricesDomain.Price, error)
+		GetProductsPrices(
+			ctx context.Context,
+			data pricesDomain.GetProductsPricesDTO,
+		) (map[uuid.UUID][]pricesDomain.Price, error)
+		GetProductPriceHistory(
+			ctx context.Context,
+			data pricesDomain.GetProductPriceHistoryDTO,
+		) ([]pricesDomain.PriceChange, error)
+		SetProductPrice(
+			ctx context.Context,
+			data pricesDomain.SetProductPriceDTO,
+		) (*pricesDomain.Price, error)
+		DeleteProductPrice(
+			ctx context.Context,
+			data pricesDomain.DeleteProductPriceDTO,
+		) (*pricesDomain.Price, error)
+	}
+
+	VariantsRepository interface {
+		GetOptionTypes(
+			ctx context.Context,
+			data variantsDomain.GetOptionTypesDTO,
+		) ([]variantsDomain.OptionType, error)
+		CreateOptionType(
+			ctx context.Context,
+			data variantsDomain.CreateOptionTypeDTO,
+		) (*variantsDomain.OptionType, error)
+		GetVariants(
+			ctx context.Context,
+			data variantsDomain.GetVariantsDTO,
+		) ([]variantsDomain.Variant, error)
+		GetProductsVariants(
+			ctx context.Context,
+			data variantsDomain.GetProductsVariantsDTO,
+		) (map[uuid.UUID][]variantsDomain.Variant, error)
+		GetVariant(
+			ctx context.Context,
+			data variantsDomain.GetVariantDTO,
+		) (*variantsDomain.Variant, error)
+		CreateVariant(
+			ctx context.Context,
+			data variantsDomain.CreateVariantDTO,
+		) (*variantsDomain.Variant, error)
+		DeleteVariant(
+			ctx context.Context,
+			data variantsDomain.DeleteVariantDTO,
+		) (*variantsDomain.Variant, error)
+	}
+
+	TagsRepository interface {
+		TagProducts(
+			ctx context.Context,
+			data tagsDomain.TagProductsDTO,
+		) (*tagsDomain.TagProductsResult, error)
+		UntagProducts(
+			ctx context.Context,
+			data tagsDomain.TagProductsDTO,
+		) (*tagsDomain.TagProductsResult, error)
+		AutocompleteTags(
+			ctx context.Context,
+			data tagsDomain.AutocompleteTagsDTO,
+		) ([]tagsDomain.TagCount, error)
+		GetProductsTags(
+			ctx context.Context,
+			data tagsDomain.GetProductsTagsDTO,
+		) (map[uuid.UUID][]string, error)
+	}
+
+	PublicationRepository interface {
+		TransitionProduct(
+			ctx context.Context,
+			data productsDomain.TransitionProductDTO,
+		) (*productsDomain.ProductTransition, error)
+		GetProductTransitions(
+			ctx context.Context,
+			data productsDomain.GetProductTransitionsDTO,
+		) ([]productsDomain.ProductTransition, error)
+		SetProductSchedule(
+			ctx context.Context,
+			data productsDomain.SetProductScheduleDTO,
+		) (*productsDomain.ProductSchedule, error)
+		RunScheduledTransitions(
+			ctx context.Context,
+			data productsDomain.RunScheduledTransitionsDTO,
+		) ([]productsDomain.ProductTransition, error)
+	}
+
+	MediaRepository interface {
+		CreateMedia(
+			ctx context.Context,
+			data mediaDomain.CreateMediaDTO,
+		) (*mediaDomain.Media, error)
+		GetMedia(
+			ctx context.Context,
+			data mediaDomain.GetMediaDTO,
+		) (*mediaDomain.Media, error)
+		GetProductMedia(
+			ctx context.Context,
+			data mediaDomain.GetProductMediaDTO,
+		) ([]mediaDomain.Media, error)
+		GetProductsMedia(
+			ctx context.Context,
+			data mediaDomain.GetProductsMediaDTO,
+		) (map[uuid.UUID][]mediaDomain.Media, error)
+		SetMediaOrder(
+			ctx context.Context,
+			data mediaDomain.SetMediaOrderDTO,
+		) ([]mediaDomain.Media, error)
+		DeleteMedia(
+			ctx context.Context,
+			data mediaDomain.DeleteMediaDTO,
+		) (*mediaDomain.Media, error)
+		SetThumbnails(
+			ctx context.Context,
+			data mediaDomain.SetThumbnailsDTO,
+		) (*mediaDomain.Media, error)
+		GetOrphanedMedia(
+			ctx context.Context,
+			data mediaDomain.GetOrphanedMediaDTO,
+		) ([]uuid.UUID, error)
+		PurgeMedia(
+			ctx context.Context,
+			data mediaDomain.PurgeMediaDTO,
+		) (int64, error)
+	}
+
+	InventoryRepository interface {
+		GetWarehouses(
+			ctx context.Context,
+			data inventoryDomain.GetWarehousesDTO,
+		) ([]inventoryDomain.Warehouse, error)
+		CreateWarehouse(
+			ctx context.Context,
+			data inventoryDomain.CreateWarehouseDTO,
+		) (*inventoryDomain.Warehouse, error)
+		GetProductStock(
+			ctx context.Context,
+			data inventoryDomain.GetProductStockDTO,
+		) ([]inventoryDomain.StockLevel, error)
+		AdjustStock(
+			ctx context.Context,
+			data inventoryDomain.AdjustStockDTO,
+		) (*inventoryDomain.StockLevel, error)
+		GetStockAdjustments(
+			ctx context.Context,
+			data inventoryDomain.GetStockAdjustmentsDTO,
+		) ([]inventoryDomain.Adjustment, error)
+		ReserveStock(
+			ctx context.Context,
+			data inventoryDomain.ReserveStockDTO,
+		) (*inventoryDomain.Reservation, error)
+		GetReservation(
+			ctx context.Context,
+			data inventoryDomain.GetReservationDTO,
+		) (*inventoryDomain.Reservation, error)
+		ReleaseReservation(
+			ctx context.Context,
+			data inventoryDomain.ReleaseReservationDTO,
+		) (*inventoryDomain.Reservation, error)
+		CommitReservation(
+			ctx context.Context,
+			data inventoryDomain.CommitReservationDTO,
+		) (*inventoryDomain.Reservation, error)
+		ExpireReservations(
+			ctx context.Context,
+			data inventoryDomain.ExpireReservationsDTO,
+		) (int64, error)
+	}
+
+	JobsRepository interface {
+		EnqueueJob(
+			ctx context.Context,
+			data jobsDomain.EnqueueJobDTO,
+		) (*jobsDomain.Job, error)
+		GetJob(
+			ctx context.Context,
+			data jobsDomain.GetJobDTO,
+		) (*jobsDomain.Job, error)
+		GetJobArtifact(
+			ctx context.Context,
+			data jobsDomain.GetJobDTO,
+		) (*jobsDomain.Artifact, error)
+		ClaimJob(
+			ctx context.Context,
+			data jobsDomain.ClaimJobDTO,
+		) (*jobsDomain.Job, error)
+		HeartbeatJob(
+			ctx context.Context,
+			data jobsDomain.HeartbeatJobDTO,
+		) (*jobsDomain.Job, error)
+		FinishJob(
+			ctx context.Context,
+			data jobsDomain.FinishJobDTO,
+		) (*jobsDomain.Job, error)
+		CancelJob(
+			ctx context.Context,
+			data jobsDomain.CancelJobDTO,
+		) (*jobsDomain.Job, error)
 	}
 )
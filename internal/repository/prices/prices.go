@@ -0,0 +1,224 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	"go_template_project/internal/repository/dbtx"
+)
+
+func (r *Repository) GetProductPrices(
+	ctx context.Context,
+	data pricesDomain.GetProductPricesDTO,
+) ([]pricesDomain.Price, error) {
+	params := SqGetProductPricesParams{
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		Currency:  data.Currency,
+	}
+	sqPrices, err := r.queries.SqGetProductPrices(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get product prices error: %w", err)
+	}
+	prices := make([]pricesDomain.Price, 0, len(sqPrices))
+	for i := range sqPrices {
+		prices = append(prices, *convertPrice(&sqPrices[i]))
+	}
+	return prices, nil
+}
+
+// GetProductsPrices groups the prices by product, products without prices
+// are left out.
+func (r *Repository) GetProductsPrices(
+	ctx context.Context,
+	data pricesDomain.GetProductsPricesDTO,
+) (map[uuid.UUID][]pricesDomain.Price, error) {
+	productIDs := make([]pgtype.UUID, 0, len(data.ProductIDs))
+	for _, id := range data.ProductIDs {
+		productIDs = append(productIDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	sqPrices, err := r.queries.SqGetProductsPrices(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products prices error: %w", err)
+	}
+	prices := make(map[uuid.UUID][]pricesDomain.Price)
+	for i := range sqPrices {
+		price := convertPrice(&sqPrices[i])
+		prices[price.ProductID] = append(prices[price.ProductID], *price)
+	}
+	return prices, nil
+}
+
+func (r *Repository) GetProductPriceHistory(
+	ctx context.Context,
+	data pricesDomain.GetProductPriceHistoryDTO,
+) ([]pricesDomain.PriceChange, error) {
+	params := SqGetProductPriceHistoryParams{
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		Currency:  data.Currency,
+		Limit:     uint64(data.Limit),
+		Offset:    uint64(data.Offset),
+	}
+	sqChanges, err := r.queries.SqGetProductPriceHistory(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get product price history error: %w", err)
+	}
+	changes := make([]pricesDomain.PriceChange, 0, len(sqChanges))
+	for _, sqChange := range sqChanges {
+		changes = append(changes, pricesDomain.PriceChange{
+			PriceID:   sqChange.PriceID.Bytes,
+			Currency:  sqChange.Currency,
+			Amount:    sqChange.Amount,
+			ValidFrom: sqChange.ValidFrom.Time,
+			ValidTo:   NConvertPgTimestamp(sqChange.ValidTo),
+			Operation: sqChange.Operation,
+			ChangedAt: sqChange.ChangedAt.Time,
+		})
+	}
+	return changes, nil
+}
+
+// SetProductPrice schedules a price, making room for it first: prices
+// starting before the new range are cut back to its start and keep their
+// tail after its end, prices inside it are removed and prices running past
+// its end start at its end. Each step keeps the ranges disjoint, so the
+// exclusion constraint holds throughout the transaction.
+func (r *Repository) SetProductPrice(
+	ctx context.Context,
+	data pricesDomain.SetProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+	validFrom := pgtype.Timestamp{Time: data.ValidFrom.UTC(), Valid: true}
+	validTo := NConvertTime(data.ValidTo)
+
+	var sqPrice *SqPriceRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockProduct(ctx, productID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+		if err := q.SqLockProductPrices(ctx, productID, data.Currency); err != nil {
+			return err
+		}
+
+		overlapping, err := q.SqGetOverlappingPrices(ctx, SqGetOverlappingPricesParams{
+			ProductID: productID,
+			Currency:  data.Currency,
+			ValidFrom: validFrom,
+			ValidTo:   validTo,
+		})
+		if err != nil {
+			return err
+		}
+		for _, price := range overlapping {
+			if err := q.makeRoom(ctx, price, validFrom, validTo); err != nil {
+				return err
+			}
+		}
+
+		sqPrice, err = q.SqCreatePrice(ctx, SqCreatePriceParams{
+			ProductID: productID,
+			Currency:  data.Currency,
+			Amount:    data.Amount,
+			ValidFrom: validFrom,
+			ValidTo:   validTo,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, priceError("sq set product price error", err)
+	}
+	return convertPrice(sqPrice), nil
+}
+
+func (r *Repository) DeleteProductPrice(
+	ctx context.Context,
+	data pricesDomain.DeleteProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	params := SqDeletePriceParams{
+		ID:        pgtype.UUID{Bytes: data.ID, Valid: true},
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+	}
+	sqPrice, err := r.queries.SqDeletePrice(ctx, params)
+	if err != nil {
+		return nil, priceError("sq delete product price error", err)
+	}
+	return convertPrice(sqPrice), nil
+}
+
+// makeRoom clears [from, to) of price, an invalid to is unbounded.
+func (q *RepoQueries) makeRoom(
+	ctx context.Context,
+	price SqPriceRow,
+	from pgtype.Timestamp,
+	to pgtype.Timestamp,
+) error {
+	// true when price still runs after the new range ends
+	runsPast := to.Valid && (!price.ValidTo.Valid || price.ValidTo.Time.After(to.Time))
+
+	if price.ValidFrom.Time.Before(from.Time) {
+		err := q.SqUpdatePriceRange(ctx, SqUpdatePriceRangeParams{
+			ID:        price.ID,
+			ValidFrom: price.ValidFrom,
+			ValidTo:   from,
+		})
+		if err != nil || !runsPast {
+			return err
+		}
+		_, err = q.SqCreatePrice(ctx, SqCreatePriceParams{
+			ProductID: price.ProductID,
+			Currency:  price.Currency,
+			Amount:    price.Amount,
+			ValidFrom: to,
+			ValidTo:   price.ValidTo,
+		})
+		return err
+	}
+
+	if !runsPast {
+		_, err := q.SqDeletePrice(ctx, SqDeletePriceParams{ID: price.ID, ProductID: price.ProductID})
+		return err
+	}
+	return q.SqUpdatePriceRange(ctx, SqUpdatePriceRangeParams{
+		ID:        price.ID,
+		ValidFrom: to,
+		ValidTo:   price.ValidTo,
+	})
+}
+
+// priceError maps database errors to domain errors, wrapping anything
+// unexpected with msg.
+func priceError(msg string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pricesDomain.ErrPriceNotFound
+	}
+	if errors.Is(err, productsDomain.ErrProductNotFound) {
+		return productsDomain.ErrProductNotFound
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.ForeignKeyViolation); ok {
+		return productsDomain.ErrProductNotFound
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.ExclusionViolation); ok {
+		return pricesDomain.ErrPriceConflict
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func convertPrice(sqPrice *SqPriceRow) *pricesDomain.Price {
+	return &pricesDomain.Price{
+		ID:        sqPrice.ID.Bytes,
+		ProductID: sqPrice.ProductID.Bytes,
+		Currency:  sqPrice.Currency,
+		Amount:    sqPrice.Amount,
+		ValidFrom: sqPrice.ValidFrom.Time,
+		ValidTo:   NConvertPgTimestamp(sqPrice.ValidTo),
+		Current:   sqPrice.Current,
+		CreatedAt: sqPrice.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,352 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	PricesTable       = "product_prices"
+	PriceHistoryTable = "product_price_history"
+)
+
+// currentCondition matches the price in effect right now.
+const currentCondition = "valid_from <= NOW() AND (valid_to IS NULL OR valid_to > NOW())"
+
+var priceColumns = []string{
+	"id",
+	"product_id",
+	"currency",
+	"amount",
+	"valid_from",
+	"valid_to",
+	"created_at",
+	"(" + currentCondition + ") AS current",
+}
+
+var priceReturning = "RETURNING " + joinColumns(priceColumns)
+
+var priceChangeColumns = []string{
+	"price_id",
+	"currency",
+	"amount",
+	"valid_from",
+	"valid_to",
+	"operation",
+	"changed_at",
+}
+
+type SqPriceRow struct {
+	ID        pgtype.UUID
+	ProductID pgtype.UUID
+	Currency  string
+	Amount    int64
+	ValidFrom pgtype.Timestamp
+	ValidTo   pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+	Current   bool
+}
+
+type SqPriceChangeRow struct {
+	PriceID   pgtype.UUID
+	Currency  string
+	Amount    int64
+	ValidFrom pgtype.Timestamp
+	ValidTo   pgtype.Timestamp
+	Operation string
+	ChangedAt pgtype.Timestamp
+}
+
+type SqGetProductPricesParams struct {
+	ProductID pgtype.UUID
+	Currency  string
+}
+
+type SqGetProductPriceHistoryParams struct {
+	ProductID pgtype.UUID
+	Currency  string
+	Limit     uint64
+	Offset    uint64
+}
+
+// SqGetOverlappingPricesParams selects prices of a product and currency
+// intersecting [ValidFrom, ValidTo), an invalid ValidTo is unbounded.
+type SqGetOverlappingPricesParams struct {
+	ProductID pgtype.UUID
+	Currency  string
+	ValidFrom pgtype.Timestamp
+	ValidTo   pgtype.Timestamp
+}
+
+type SqCreatePriceParams struct {
+	ProductID pgtype.UUID
+	Currency  string
+	Amount    int64
+	ValidFrom pgtype.Timestamp
+	ValidTo   pgtype.Timestamp
+}
+
+type SqUpdatePriceRangeParams struct {
+	ID        pgtype.UUID
+	ValidFrom pgtype.Timestamp
+	ValidTo   pgtype.Timestamp
+}
+
+type SqDeletePriceParams struct {
+	ID        pgtype.UUID
+	ProductID pgtype.UUID
+}
+
+func scanPrice(row pgx.Row) (*SqPriceRow, error) {
+	var i SqPriceRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Currency,
+		&i.Amount,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.CreatedAt,
+		&i.Current,
+	)
+	return &i, err
+}
+
+func scanPrices(rows pgx.Rows) ([]SqPriceRow, error) {
+	defer rows.Close()
+	var items []SqPriceRow
+	for rows.Next() {
+		i, err := scanPrice(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SqLockProduct locks the live product row against deletion, soft or
+// hard, until the transaction ends.
+func (q *RepoQueries) SqLockProduct(
+	ctx context.Context,
+	productID pgtype.UUID,
+) error {
+	var id pgtype.UUID
+	return q.db.QueryRow(ctx, "SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR SHARE", productID).Scan(&id)
+}
+
+// SqLockProductPrices serialises writers of one product and currency until
+// the transaction ends. Row locks alone do not stop two writers inserting
+// into the same empty range.
+func (q *RepoQueries) SqLockProductPrices(
+	ctx context.Context,
+	productID pgtype.UUID,
+	currency string,
+) error {
+	key := fmt.Sprintf("%s:%x:%s", PricesTable, productID.Bytes, currency)
+	_, err := q.db.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", key)
+	return err
+}
+
+func (q *RepoQueries) SqGetProductPrices(
+	ctx context.Context,
+	params SqGetProductPricesParams,
+) ([]SqPriceRow, error) {
+	query, args, err := buildGetProductPricesQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get product prices build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanPrices(rows)
+}
+
+func buildGetProductPricesQuery(
+	params SqGetProductPricesParams,
+) (string, []interface{}, error) {
+	query := sq.Select(priceColumns...).
+		From(PricesTable).
+		Where(sq.Eq{"product_id": params.ProductID}).
+		OrderBy("currency", "valid_from").
+		PlaceholderFormat(sq.Dollar)
+	if params.Currency != "" {
+		query = query.Where(sq.Eq{"currency": params.Currency})
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get product prices query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetProductsPrices(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) ([]SqPriceRow, error) {
+	query, args, err := sq.Select(priceColumns...).
+		From(PricesTable).
+		Where(sq.Expr("product_id = ANY(?)", productIDs)).
+		OrderBy("product_id", "currency", "valid_from").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get products prices build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanPrices(rows)
+}
+
+func (q *RepoQueries) SqGetProductPriceHistory(
+	ctx context.Context,
+	params SqGetProductPriceHistoryParams,
+) ([]SqPriceChangeRow, error) {
+	query, args, err := buildGetProductPriceHistoryQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get product price history build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqPriceChangeRow
+	for rows.Next() {
+		var i SqPriceChangeRow
+		if err := rows.Scan(
+			&i.PriceID,
+			&i.Currency,
+			&i.Amount,
+			&i.ValidFrom,
+			&i.ValidTo,
+			&i.Operation,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// buildGetProductPriceHistoryQuery lists the newest changes first, id breaks
+// ties between changes of one transaction.
+func buildGetProductPriceHistoryQuery(
+	params SqGetProductPriceHistoryParams,
+) (string, []interface{}, error) {
+	query := sq.Select(priceChangeColumns...).
+		From(PriceHistoryTable).
+		Where(sq.Eq{"product_id": params.ProductID}).
+		OrderBy("changed_at DESC", "id DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		PlaceholderFormat(sq.Dollar)
+	if params.Currency != "" {
+		query = query.Where(sq.Eq{"currency": params.Currency})
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get product price history query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqGetOverlappingPrices reads the prices intersecting the range from the
+// primary and locks them until the transaction ends.
+func (q *RepoQueries) SqGetOverlappingPrices(
+	ctx context.Context,
+	params SqGetOverlappingPricesParams,
+) ([]SqPriceRow, error) {
+	query, args, err := buildGetOverlappingPricesQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get overlapping prices build query error: %w", err)
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanPrices(rows)
+}
+
+func buildGetOverlappingPricesQuery(
+	params SqGetOverlappingPricesParams,
+) (string, []interface{}, error) {
+	query := sq.Select(priceColumns...).
+		From(PricesTable).
+		Where(sq.Eq{"product_id": params.ProductID, "currency": params.Currency}).
+		Where(sq.Expr(
+			"tsrange(valid_from, valid_to) && tsrange(?::timestamp, ?::timestamp)",
+			params.ValidFrom,
+			params.ValidTo,
+		)).
+		OrderBy("valid_from").
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get overlapping prices query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqCreatePrice(
+	ctx context.Context,
+	params SqCreatePriceParams,
+) (*SqPriceRow, error) {
+	query, args, err := sq.Insert(PricesTable).
+		Columns("product_id", "currency", "amount", "valid_from", "valid_to").
+		Values(params.ProductID, params.Currency, params.Amount, params.ValidFrom, params.ValidTo).
+		Suffix(priceReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq create price build query error: %w", err)
+	}
+	return scanPrice(q.db.QueryRow(ctx, query, args...))
+}
+
+func (q *RepoQueries) SqUpdatePriceRange(
+	ctx context.Context,
+	params SqUpdatePriceRangeParams,
+) error {
+	query, args, err := sq.Update(PricesTable).
+		Set("valid_from", params.ValidFrom).
+		Set("valid_to", params.ValidTo).
+		Where(sq.Eq{"id": params.ID}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("sq update price range build query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, query, args...)
+	return err
+}
+
+func (q *RepoQueries) SqDeletePrice(
+	ctx context.Context,
+	params SqDeletePriceParams,
+) (*SqPriceRow, error) {
+	query, args, err := sq.Delete(PricesTable).
+		Where(sq.Eq{"id": params.ID}).
+		Where(sq.Eq{"product_id": params.ProductID}).
+		Suffix(priceReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq delete price build query error: %w", err)
+	}
+	return scanPrice(q.db.QueryRow(ctx, query, args...))
+}
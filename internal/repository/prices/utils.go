@@ -0,0 +1,7 @@
+package prices
+
+import "strings"
+
+func joinColumns(columns []string) string {
+	return strings.Join(columns, ", ")
+}
@@ -0,0 +1,95 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SqLockProduct locks the live product row against writers of its
+// attributes and categories until the transaction ends.
+func (q *RepoQueries) SqLockProduct(
+	ctx context.Context,
+	productID pgtype.UUID,
+) error {
+	var id pgtype.UUID
+	return q.db.QueryRow(ctx,
+		"SELECT id FROM "+ProductsTable+" WHERE id = $1 AND "+liveCondition+" FOR NO KEY UPDATE",
+		productID,
+	).Scan(&id)
+}
+
+// SqGetAttributeSchemas selects the schemas of the categories and their
+// ancestors, locking them against schema changes until the transaction
+// ends.
+func (q *RepoQueries) SqGetAttributeSchemas(
+	ctx context.Context,
+	categoryIDs []pgtype.UUID,
+) ([]map[string]any, error) {
+	return q.getAttributeSchemas(ctx, sq.Expr("c.id = ANY(?)", categoryIDs))
+}
+
+// SqGetProductAttributeSchemas is SqGetAttributeSchemas for the categories
+// the product is assigned to.
+func (q *RepoQueries) SqGetProductAttributeSchemas(
+	ctx context.Context,
+	productID pgtype.UUID,
+) ([]map[string]any, error) {
+	return q.getAttributeSchemas(ctx, sq.Expr(
+		"c.id IN (SELECT category_id FROM product_categories WHERE product_id = ?)",
+		productID,
+	))
+}
+
+func (q *RepoQueries) getAttributeSchemas(
+	ctx context.Context,
+	categories sq.Sqlizer,
+) ([]map[string]any, error) {
+	query, args, err := buildGetAttributeSchemasQuery(categories)
+	if err != nil {
+		return nil, fmt.Errorf("sq get attribute schemas build query error: %w", err)
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []map[string]any
+	for rows.Next() {
+		var i map[string]any
+		if err := rows.Scan(&i); err != nil {
+			return nil, err
+		}
+		if i != nil {
+			items = append(items, i)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// buildGetAttributeSchemasQuery selects the schemas of the categories c
+// matched by the condition and of their ancestors, root first. Categories
+// without a schema are locked as well, one may gain a schema meanwhile.
+func buildGetAttributeSchemasQuery(
+	categories sq.Sqlizer,
+) (string, []interface{}, error) {
+	exists, args, err := categories.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	query := sq.Select("a.attribute_schema").
+		From("categories AS a").
+		Where(sq.Expr("EXISTS (SELECT 1 FROM categories AS c WHERE "+exists+" AND c.path LIKE a.path || '%')", args...)).
+		OrderBy("a.depth", "a.path").
+		Suffix("FOR SHARE OF a").
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get attribute schemas query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
@@ -0,0 +1,91 @@
+package products
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	productsDomain "go_template_project/internal/domain/products"
+	"slices"
+	"strconv"
+)
+
+func (r *Repository) GetProductsFacets(
+	ctx context.Context,
+	data productsDomain.GetProductsFacetsDTO,
+) (*productsDomain.ProductsFacets, error) {
+	params := SqGetProductsFacetsParams{
+		Filter:       newProductsFilter(data.Filter),
+		Facets:       data.Facets,
+		PriceBuckets: data.PriceBuckets,
+		Limit:        uint64(data.Limit),
+	}
+	sqFacets, err := r.queries.SqGetProductsFacets(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products facets error: %w", err)
+	}
+	return convertFacets(data, sqFacets)
+}
+
+// convertFacets sorts the rows into their facets. UNION ALL does not keep
+// the order of the facet queries, so every facet is sorted again.
+func convertFacets(
+	data productsDomain.GetProductsFacetsDTO,
+	sqFacets []SqFacetRow,
+) (*productsDomain.ProductsFacets, error) {
+	facets := &productsDomain.ProductsFacets{}
+	for _, facet := range data.Facets {
+		switch facet {
+		case productsDomain.FacetCategory:
+			facets.Categories = []productsDomain.FacetCount{}
+		case productsDomain.FacetTag:
+			facets.Tags = []productsDomain.FacetCount{}
+		case productsDomain.FacetPrice:
+			facets.Prices = []productsDomain.PriceBucket{}
+		case productsDomain.FacetStatus:
+			facets.Statuses = []productsDomain.FacetCount{}
+		}
+	}
+
+	for _, sqFacet := range sqFacets {
+		count := productsDomain.FacetCount{
+			Value: sqFacet.Value,
+			Label: sqFacet.Label,
+			Count: sqFacet.Count,
+		}
+		switch sqFacet.Facet {
+		case productsDomain.FacetCategory:
+			facets.Categories = append(facets.Categories, count)
+		case productsDomain.FacetTag:
+			facets.Tags = append(facets.Tags, count)
+		case productsDomain.FacetStatus:
+			facets.Statuses = append(facets.Statuses, count)
+		case productsDomain.FacetPrice:
+			bucket, err := strconv.Atoi(sqFacet.Value)
+			if err != nil || bucket < 0 || bucket > len(data.PriceBuckets) {
+				return nil, fmt.Errorf("invalid price bucket %q", sqFacet.Value)
+			}
+			priceBucket := productsDomain.PriceBucket{Count: sqFacet.Count}
+			if bucket > 0 {
+				priceBucket.Min = data.PriceBuckets[bucket-1]
+			}
+			if bucket < len(data.PriceBuckets) {
+				max := data.PriceBuckets[bucket]
+				priceBucket.Max = &max
+			}
+			facets.Prices = append(facets.Prices, priceBucket)
+		}
+	}
+
+	byCount := func(a, b productsDomain.FacetCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Label, b.Label))
+	}
+	slices.SortFunc(facets.Categories, byCount)
+	slices.SortFunc(facets.Tags, byCount)
+	slices.SortFunc(facets.Statuses, func(a, b productsDomain.FacetCount) int {
+		return cmp.Compare(a.Value, b.Value)
+	})
+	slices.SortFunc(facets.Prices, func(a, b productsDomain.PriceBucket) int {
+		return cmp.Compare(a.Min, b.Min)
+	})
+	return facets, nil
+}
@@ -0,0 +1,136 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+	productsDomain "go_template_project/internal/domain/products"
+	"strings"
+)
+
+type SqGetProductsFacetsParams struct {
+	Filter       SqProductsFilter
+	Facets       []string
+	PriceBuckets []int64
+	Limit        uint64
+}
+
+// SqFacetRow is a value of a facet. The value of a price bucket is its
+// width_bucket number, 0 for prices below the first bound.
+type SqFacetRow struct {
+	Facet string
+	Value string
+	Label string
+	Count int64
+}
+
+// SqGetProductsFacets computes all facets in a single statement.
+func (q *RepoQueries) SqGetProductsFacets(
+	ctx context.Context,
+	params SqGetProductsFacetsParams,
+) ([]SqFacetRow, error) {
+	query, args, err := buildGetProductsFacetsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products facets build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqFacetRow
+	for rows.Next() {
+		var i SqFacetRow
+		if err := rows.Scan(
+			&i.Facet,
+			&i.Value,
+			&i.Label,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// buildGetProductsFacetsQuery joins one query per facet with UNION ALL. Each
+// of them applies the filter without the facet's own conditions.
+func buildGetProductsFacetsQuery(
+	params SqGetProductsFacetsParams,
+) (string, []interface{}, error) {
+	var (
+		parts []string
+		args  []interface{}
+	)
+	for _, facet := range params.Facets {
+		query, err := buildFacetQuery(params, facet)
+		if err != nil {
+			return "", nil, err
+		}
+		sqlString, facetArgs, err := query.ToSql()
+		if err != nil {
+			return "", nil, fmt.Errorf("sq get products facets query to sql error: %w", err)
+		}
+		parts = append(parts, "("+sqlString+")")
+		args = append(args, facetArgs...)
+	}
+	if len(parts) == 0 {
+		return "", nil, fmt.Errorf("no facets to compute")
+	}
+	sqlString, err := sq.Dollar.ReplacePlaceholders(strings.Join(parts, " UNION ALL "))
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get products facets placeholders error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func buildFacetQuery(params SqGetProductsFacetsParams, facet string) (sq.SelectBuilder, error) {
+	filter := params.Filter
+	var query sq.SelectBuilder
+	switch facet {
+	case productsDomain.FacetCategory:
+		filter.Category = pgtype.UUID{}
+		query = sq.Select("'category'", "a.id::text", "a.name", "COUNT(DISTINCT products.id)").
+			From(ProductsTable).
+			Join("product_categories pc ON pc.product_id = products.id").
+			Join("categories c ON c.id = pc.category_id").
+			Join("categories a ON c.path LIKE a.path || '%'").
+			GroupBy("a.id", "a.name").
+			OrderBy("COUNT(DISTINCT products.id) DESC", "a.name").
+			Limit(params.Limit)
+	case productsDomain.FacetTag:
+		filter.TagsAny = nil
+		query = sq.Select("'tag'", "t.name", "t.name", "COUNT(*)").
+			From(ProductsTable).
+			Join("product_tags pt ON pt.product_id = products.id").
+			Join("tags t ON t.id = pt.tag_id").
+			GroupBy("t.name").
+			OrderBy("COUNT(*) DESC", "t.name").
+			Limit(params.Limit)
+	case productsDomain.FacetPrice:
+		if filter.Currency == "" || len(params.PriceBuckets) == 0 {
+			return query, fmt.Errorf("price facet needs a currency and price buckets")
+		}
+		filter.PriceMin, filter.PriceMax = pgtype.Int8{}, pgtype.Int8{}
+		query = sq.Select("'price'").
+			Column(sq.Expr("width_bucket(pp.amount, ?::bigint[])::text", params.PriceBuckets)).
+			Columns("''", "COUNT(*)").
+			From(ProductsTable).
+			Join("product_prices pp ON pp.product_id = products.id AND pp.currency = ? AND "+currentPriceCondition,
+				filter.Currency).
+			GroupBy("2")
+	case productsDomain.FacetStatus:
+		filter.Statuses = nil
+		query = sq.Select("'status'", ProductsTable+".status", ProductsTable+".status", "COUNT(*)").
+			From(ProductsTable).
+			GroupBy("2")
+	default:
+		return query, fmt.Errorf("unknown facet %q", facet)
+	}
+	return filter.apply(query), nil
+}
@@ -0,0 +1,146 @@
+package products
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+func TestBuildFacetQuery(t *testing.T) {
+	filter := SqProductsFilter{
+		Category: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Currency: "EUR",
+		PriceMin: pgtype.Int8{Int64: 100, Valid: true},
+		TagsAny:  []string{"oak"},
+		Statuses: []string{"draft"},
+	}
+	params := SqGetProductsFacetsParams{Filter: filter, PriceBuckets: []int64{1000, 5000}, Limit: 20}
+
+	// each facet leaves out its own filter and keeps the others
+	tests := []struct {
+		facet       string
+		wantSQL     []string
+		dontWantSQL []string
+	}{
+		{
+			facet:       productsDomain.FacetCategory,
+			wantSQL:     []string{"a.id::text", "product_prices", "t.name = ANY", "products.status = ANY", "LIMIT 20"},
+			dontWantSQL: []string{"root.path"},
+		},
+		{
+			facet:       productsDomain.FacetTag,
+			wantSQL:     []string{"'tag'", "root.path", "product_prices", "products.status = ANY", "LIMIT 20"},
+			dontWantSQL: []string{"t.name = ANY"},
+		},
+		{
+			facet:       productsDomain.FacetPrice,
+			wantSQL:     []string{"width_bucket(pp.amount, ?::bigint[])", "root.path", "t.name = ANY", "products.status = ANY"},
+			dontWantSQL: []string{"pp.amount >= ?"},
+		},
+		{
+			facet:       productsDomain.FacetStatus,
+			wantSQL:     []string{"'status'", "root.path", "product_prices", "t.name = ANY"},
+			dontWantSQL: []string{"products.status = ANY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.facet, func(t *testing.T) {
+			query, err := buildFacetQuery(params, tt.facet)
+			if err != nil {
+				t.Fatalf("build: %v", err)
+			}
+			sqlString, _, err := query.ToSql()
+			if err != nil {
+				t.Fatalf("to sql: %v", err)
+			}
+			if !strings.Contains(sqlString, liveCondition) {
+				t.Errorf("got %s, want it to contain %s", sqlString, liveCondition)
+			}
+			for _, want := range tt.wantSQL {
+				if !strings.Contains(sqlString, want) {
+					t.Errorf("got %s, want it to contain %s", sqlString, want)
+				}
+			}
+			for _, dontWant := range tt.dontWantSQL {
+				if strings.Contains(sqlString, dontWant) {
+					t.Errorf("got %s, want it not to contain %s", sqlString, dontWant)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildGetProductsFacetsQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    SqGetProductsFacetsParams
+		wantParts int
+		wantArgs  int
+		wantErr   string
+	}{
+		{
+			name:      "one query per facet",
+			params:    SqGetProductsFacetsParams{Facets: []string{productsDomain.FacetTag, productsDomain.FacetStatus}, Limit: 20},
+			wantParts: 2,
+		},
+		{
+			name: "placeholders numbered across facets",
+			params: SqGetProductsFacetsParams{
+				Filter:       SqProductsFilter{Name: "chair", Currency: "EUR"},
+				Facets:       []string{productsDomain.FacetPrice, productsDomain.FacetStatus},
+				PriceBuckets: []int64{1000},
+			},
+			wantParts: 2,
+			wantArgs:  4,
+		},
+		{
+			name:    "price without buckets",
+			params:  SqGetProductsFacetsParams{Filter: SqProductsFilter{Currency: "EUR"}, Facets: []string{productsDomain.FacetPrice}},
+			wantErr: "price facet needs a currency and price buckets",
+		},
+		{
+			name:    "unknown facet",
+			params:  SqGetProductsFacetsParams{Facets: []string{"color"}},
+			wantErr: `unknown facet "color"`,
+		},
+		{
+			name:    "no facets",
+			params:  SqGetProductsFacetsParams{},
+			wantErr: "no facets to compute",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlString, args, err := buildGetProductsFacetsQuery(tt.params)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("build: %v", err)
+			}
+			if got := strings.Count(sqlString, " UNION ALL ") + 1; got != tt.wantParts {
+				t.Errorf("got %d parts, want %d", got, tt.wantParts)
+			}
+			if len(args) != tt.wantArgs {
+				t.Errorf("got %d args, want %d", len(args), tt.wantArgs)
+			}
+			if strings.Contains(sqlString, "?") {
+				t.Errorf("got %s, want dollar placeholders", sqlString)
+			}
+			for i := 1; i <= len(args); i++ {
+				if !strings.Contains(sqlString, "$"+strconv.Itoa(i)) {
+					t.Errorf("got %s, want it to contain $%d", sqlString, i)
+				}
+			}
+		})
+	}
+}
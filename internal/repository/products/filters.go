@@ -0,0 +1,151 @@
+package products
+
+import (
+	"encoding/json"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+	"slices"
+)
+
+// currentPriceCondition matches the product_prices row in effect now.
+const currentPriceCondition = "valid_from <= NOW() AND (valid_to IS NULL OR valid_to > NOW())"
+
+// liveCondition matches the products that are not soft deleted, every read
+// is limited to them.
+const liveCondition = ProductsTable + ".deleted_at IS NULL"
+
+// publishedCondition matches the live products public reads may see.
+const publishedCondition = ProductsTable + ".status = 'published'"
+
+// SqProductsFilter holds the filters shared by list, stats and export
+// queries. Zero values do not filter, soft deleted products never match.
+type SqProductsFilter struct {
+	Name  string
+	Title string
+	// Category matches products assigned to the category or any of its
+	// descendants.
+	Category pgtype.UUID
+	// PriceMin and PriceMax bound the current price in Currency, products
+	// without one are left out.
+	Currency string
+	PriceMin pgtype.Int8
+	PriceMax pgtype.Int8
+	// Attributes matches products whose attributes contain the values, see
+	// attributeCondition.
+	Attributes map[string]string
+	// TagsAll matches products carrying all of the tags, TagsAny products
+	// carrying any of them.
+	TagsAll []string
+	TagsAny []string
+	// Statuses matches products in any of the publication statuses,
+	// PublishedOnly products visible to public reads.
+	Statuses      []string
+	PublishedOnly bool
+	// Query is a full-text search over names and titles, untranslated and
+	// translated to Locales. Lists also read translations in Locales.
+	Query   string
+	Locales []string
+}
+
+// compile turns the filter into conditions on the products table, so every
+// query over a filtered set agrees on what the set is.
+func (f SqProductsFilter) compile() sq.And {
+	where := sq.And{sq.Expr(liveCondition)}
+	if f.Name != "" {
+		where = append(where, sq.Eq{ProductsTable + ".name": f.Name})
+	}
+	if f.Title != "" {
+		where = append(where, sq.Eq{ProductsTable + ".title": f.Title})
+	}
+	if f.Category.Valid {
+		where = append(where, sq.Expr(`EXISTS (
+			SELECT 1
+			FROM product_categories pc
+			JOIN categories c ON c.id = pc.category_id
+			JOIN categories root ON root.id = ?
+			WHERE pc.product_id = products.id AND c.path LIKE root.path || '%'
+		)`, f.Category))
+	}
+	if f.Currency != "" && (f.PriceMin.Valid || f.PriceMax.Valid) {
+		price := sq.And{
+			sq.Expr("pp.product_id = products.id"),
+			sq.Eq{"pp.currency": f.Currency},
+			sq.Expr(currentPriceCondition),
+		}
+		if f.PriceMin.Valid {
+			price = append(price, sq.GtOrEq{"pp.amount": f.PriceMin})
+		}
+		if f.PriceMax.Valid {
+			price = append(price, sq.LtOrEq{"pp.amount": f.PriceMax})
+		}
+		where = append(where, sq.Expr("EXISTS (SELECT 1 FROM product_prices pp WHERE ?)", price))
+	}
+	if f.Query != "" {
+		search := sq.Or{sq.Expr(ProductsTable+".search @@ plainto_tsquery('simple', ?)", f.Query)}
+		if len(f.Locales) > 0 {
+			search = append(search, sq.Expr(`EXISTS (
+			SELECT 1
+			FROM `+TranslationsTable+` t
+			WHERE t.product_id = products.id AND t.locale = ANY(?::text[])
+				AND t.search @@ plainto_tsquery('simple', ?)
+		)`, f.Locales, f.Query))
+		}
+		where = append(where, search)
+	}
+	if len(f.TagsAll) > 0 {
+		where = append(where, sq.Expr(`(
+			SELECT COUNT(*)
+			FROM product_tags pt
+			JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.product_id = products.id AND t.name = ANY(?::text[])
+		) = ?`, f.TagsAll, len(f.TagsAll)))
+	}
+	if len(f.TagsAny) > 0 {
+		where = append(where, sq.Expr(`EXISTS (
+			SELECT 1
+			FROM product_tags pt
+			JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.product_id = products.id AND t.name = ANY(?::text[])
+		)`, f.TagsAny))
+	}
+	if len(f.Statuses) > 0 {
+		where = append(where, sq.Expr(ProductsTable+".status = ANY(?::text[])", f.Statuses))
+	}
+	if f.PublishedOnly {
+		where = append(where, sq.Expr(publishedCondition))
+	}
+	names := make([]string, 0, len(f.Attributes))
+	for name := range f.Attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		where = append(where, attributeCondition(name, f.Attributes[name]))
+	}
+	return where
+}
+
+// attributeCondition matches products whose attribute name equals value.
+// Query strings carry no types, so a value reading as a JSON number or
+// boolean also matches the typed value. Both are containment tests, which
+// the GIN index on attributes serves.
+func attributeCondition(name, value string) sq.Sqlizer {
+	contains := ProductsTable + ".attributes @> ?::jsonb"
+	text, _ := json.Marshal(map[string]string{name: value})
+	condition := sq.Or{sq.Expr(contains, string(text))}
+
+	var typed any
+	if json.Unmarshal([]byte(value), &typed) == nil {
+		switch typed.(type) {
+		case float64, bool:
+			raw, _ := json.Marshal(map[string]json.RawMessage{name: json.RawMessage(value)})
+			condition = append(condition, sq.Expr(contains, string(raw)))
+		}
+	}
+	return condition
+}
+
+// apply adds the compiled filter to a query over the products table.
+func (f SqProductsFilter) apply(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(f.compile())
+}
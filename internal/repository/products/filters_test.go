@@ -0,0 +1,124 @@
+package products
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestSqProductsFilterCompile(t *testing.T) {
+	category := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	tests := []struct {
+		name        string
+		filter      SqProductsFilter
+		wantSQL     []string
+		dontWantSQL []string
+		wantArgs    []interface{}
+	}{
+		{
+			name:     "soft deleted products never match",
+			filter:   SqProductsFilter{},
+			wantSQL:  []string{"(" + liveCondition + ")"},
+			wantArgs: nil,
+		},
+		{
+			name:     "name and title",
+			filter:   SqProductsFilter{Name: "chair", Title: "Chair"},
+			wantSQL:  []string{"products.name = ?", "products.title = ?"},
+			wantArgs: []interface{}{"chair", "Chair"},
+		},
+		{
+			name:     "category includes descendants",
+			filter:   SqProductsFilter{Category: category},
+			wantSQL:  []string{"c.path LIKE root.path || '%'"},
+			wantArgs: []interface{}{category},
+		},
+		{
+			name:        "price bounds need a currency",
+			filter:      SqProductsFilter{PriceMin: pgtype.Int8{Int64: 100, Valid: true}},
+			dontWantSQL: []string{"product_prices"},
+		},
+		{
+			name: "price bounds in the currency",
+			filter: SqProductsFilter{
+				Currency: "EUR",
+				PriceMin: pgtype.Int8{Int64: 100, Valid: true},
+				PriceMax: pgtype.Int8{Int64: 500, Valid: true},
+			},
+			wantSQL: []string{"EXISTS (SELECT 1 FROM product_prices pp WHERE", currentPriceCondition, "pp.amount >= ?", "pp.amount <= ?"},
+			// squirrel passes the bounds through their driver values
+			wantArgs: []interface{}{"EUR", int64(100), int64(500)},
+		},
+		{
+			name:        "search without locales",
+			filter:      SqProductsFilter{Query: "oak"},
+			wantSQL:     []string{"products.search @@ plainto_tsquery('simple', ?)"},
+			dontWantSQL: []string{TranslationsTable},
+			wantArgs:    []interface{}{"oak"},
+		},
+		{
+			name:     "search in translations",
+			filter:   SqProductsFilter{Query: "eiche", Locales: []string{"de", "en"}},
+			wantSQL:  []string{"FROM " + TranslationsTable + " t", "t.locale = ANY(?::text[])"},
+			wantArgs: []interface{}{"eiche", []string{"de", "en"}, "eiche"},
+		},
+		{
+			name:     "all tags",
+			filter:   SqProductsFilter{TagsAll: []string{"oak", "sale"}},
+			wantSQL:  []string{") = ?"},
+			wantArgs: []interface{}{[]string{"oak", "sale"}, 2},
+		},
+		{
+			name:     "any tag",
+			filter:   SqProductsFilter{TagsAny: []string{"oak"}},
+			wantSQL:  []string{"EXISTS (", "t.name = ANY(?::text[])"},
+			wantArgs: []interface{}{[]string{"oak"}},
+		},
+		{
+			name:     "statuses and published only",
+			filter:   SqProductsFilter{Statuses: []string{"draft"}, PublishedOnly: true},
+			wantSQL:  []string{"products.status = ANY(?::text[])", publishedCondition},
+			wantArgs: []interface{}{[]string{"draft"}},
+		},
+		{
+			name:    "attributes in name order, typed values also match typed",
+			filter:  SqProductsFilter{Attributes: map[string]string{"size": "10", "color": "red"}},
+			wantSQL: []string{"products.attributes @> ?::jsonb"},
+			wantArgs: []interface{}{
+				`{"color":"red"}`,
+				`{"size":"10"}`,
+				`{"size":10}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlString, args, err := tt.filter.compile().ToSql()
+			if err != nil {
+				t.Fatalf("to sql: %v", err)
+			}
+			if !strings.HasPrefix(sqlString, "("+liveCondition) {
+				t.Errorf("got %s, want it to start with the live condition", sqlString)
+			}
+			for _, want := range tt.wantSQL {
+				if !strings.Contains(sqlString, want) {
+					t.Errorf("got %s, want it to contain %s", sqlString, want)
+				}
+			}
+			for _, dontWant := range tt.dontWantSQL {
+				if strings.Contains(sqlString, dontWant) {
+					t.Errorf("got %s, want it not to contain %s", sqlString, dontWant)
+				}
+			}
+			if len(args) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(args, tt.wantArgs) {
+					t.Errorf("got args %#v, want %#v", args, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
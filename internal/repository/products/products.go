@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"go_template_project/internal/domain/attributes"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	pricesDomain "go_template_project/internal/domain/prices"
 	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"go_template_project/internal/repository/dbtx"
 )
 
 func (r *Repository) GetProducts(
@@ -14,10 +20,11 @@ func (r *Repository) GetProducts(
 	data productsDomain.GetProductsDTO,
 ) ([]productsDomain.Product, error) {
 	params := SqGetProductsParams{
-		Limit:  uint64(data.Limit),
-		Offset: uint64(data.Offset),
-		Name:   data.Name,
-		Title:  data.Title,
+		Limit:   uint64(data.Limit),
+		Offset:  uint64(data.Offset),
+		Columns: data.Fields,
+		Filter:  newProductsFilter(data),
+		Sort:    data.Sort,
 	}
 	sqProducts, err := r.queries.SqGetProducts(ctx, params)
 	if err != nil {
@@ -27,7 +34,59 @@ func (r *Repository) GetProducts(
 	products := make([]productsDomain.Product, 0)
 
 	for _, sqProduct := range sqProducts {
-		products = append(products, productsDomain.Product{
+		product := productsDomain.Product{
+			ID:          sqProduct.ID.Bytes,
+			Name:        sqProduct.Name,
+			Title:       sqProduct.Title,
+			CreatedAt:   sqProduct.CreatedAt.Time,
+			UpdatedAt:   sqProduct.UpdatedAt.Time,
+			DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+			Status:      sqProduct.Status,
+			PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+			UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+			Attributes:  sqProduct.Attributes,
+			Locale:      sqProduct.Locale.String,
+		}
+		if sqProduct.Price.Valid {
+			product.Price = &pricesDomain.Money{
+				Currency: data.Currency,
+				Amount:   sqProduct.Price.Int64,
+			}
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+func (r *Repository) GetProductsStats(
+	ctx context.Context,
+	data productsDomain.GetProductsDTO,
+) (*productsDomain.ProductsStats, error) {
+	params := SqGetProductsParams{
+		Filter: newProductsFilter(data),
+	}
+	sqStats, err := r.queries.SqGetProductsStats(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products stats error: %w", err)
+	}
+
+	return &productsDomain.ProductsStats{
+		Count:         sqStats.Count,
+		LastUpdatedAt: NConvertPgTimestamp(sqStats.LastUpdatedAt),
+	}, nil
+}
+
+func (r *Repository) ExportProducts(
+	ctx context.Context,
+	data productsDomain.ExportProductsDTO,
+	fn func(productsDomain.Product) error,
+) error {
+	params := SqExportProductsParams{
+		Filter: newProductsFilter(data.Filter),
+	}
+	err := r.queries.SqExportProducts(ctx, params, func(sqProduct SqProductRow) error {
+		return fn(productsDomain.Product{
 			ID:        sqProduct.ID.Bytes,
 			Name:      sqProduct.Name,
 			Title:     sqProduct.Title,
@@ -35,9 +94,11 @@ func (r *Repository) GetProducts(
 			UpdatedAt: sqProduct.UpdatedAt.Time,
 			DeletedAt: NConvertPgTimestamp(sqProduct.DeletedAt),
 		})
+	})
+	if err != nil {
+		return fmt.Errorf("sq export products error: %w", err)
 	}
-
-	return products, nil
+	return nil
 }
 
 func (r *Repository) GetProduct(
@@ -45,7 +106,10 @@ func (r *Repository) GetProduct(
 	data productsDomain.GetProductDTO,
 ) (*productsDomain.Product, error) {
 	params := SqGetProductParams{
-		ID: pgtype.UUID{Bytes: data.ID, Valid: true},
+		ID:            pgtype.UUID{Bytes: data.ID, Valid: true},
+		Columns:       data.Fields,
+		Locales:       data.Locales,
+		PublishedOnly: data.PublishedOnly,
 	}
 	sqProduct, err := r.queries.SqGetProduct(ctx, params)
 	if err != nil {
@@ -55,37 +119,119 @@ func (r *Repository) GetProduct(
 		return nil, fmt.Errorf("sq get product error: %w", err)
 	}
 	product := &productsDomain.Product{
-		ID:        sqProduct.ID.Bytes,
-		Name:      sqProduct.Name,
-		Title:     sqProduct.Title,
-		CreatedAt: sqProduct.CreatedAt.Time,
-		UpdatedAt: sqProduct.UpdatedAt.Time,
-		DeletedAt: NConvertPgTimestamp(sqProduct.DeletedAt),
+		ID:          sqProduct.ID.Bytes,
+		Name:        sqProduct.Name,
+		Title:       sqProduct.Title,
+		CreatedAt:   sqProduct.CreatedAt.Time,
+		UpdatedAt:   sqProduct.UpdatedAt.Time,
+		DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+		Status:      sqProduct.Status,
+		PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+		UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+		Attributes:  sqProduct.Attributes,
+		Locale:      sqProduct.Locale.String,
 	}
 	return product, nil
 }
 
+func (r *Repository) GetProductsByIDs(
+	ctx context.Context,
+	data productsDomain.GetProductsByIDsDTO,
+) ([]productsDomain.Product, error) {
+	params := SqGetProductsByIDsParams{
+		IDs:           make([]pgtype.UUID, 0, len(data.IDs)),
+		Locales:       data.Locales,
+		PublishedOnly: data.PublishedOnly,
+	}
+	for _, id := range data.IDs {
+		params.IDs = append(params.IDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	sqProducts, err := r.queries.SqGetProductsByIDs(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products by ids error: %w", err)
+	}
+
+	products := make([]productsDomain.Product, 0, len(sqProducts))
+
+	for _, sqProduct := range sqProducts {
+		products = append(products, productsDomain.Product{
+			ID:          sqProduct.ID.Bytes,
+			Name:        sqProduct.Name,
+			Title:       sqProduct.Title,
+			CreatedAt:   sqProduct.CreatedAt.Time,
+			UpdatedAt:   sqProduct.UpdatedAt.Time,
+			DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+			Status:      sqProduct.Status,
+			PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+			UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+			Attributes:  sqProduct.Attributes,
+			Locale:      sqProduct.Locale.String,
+		})
+	}
+
+	return products, nil
+}
+
 func (r *Repository) CreateProduct(
 	ctx context.Context,
 	data productsDomain.CreateProductDTO,
 ) (*productsDomain.Product, error) {
 	params := SqCreateProductParams{
-		Name:  data.Name,
-		Title: data.Title,
+		Name:        data.Name,
+		Title:       data.Title,
+		Attributes:  data.Attributes,
+		CategoryIDs: make([]pgtype.UUID, 0, len(data.CategoryIDs)),
+	}
+	for _, id := range data.CategoryIDs {
+		params.CategoryIDs = append(params.CategoryIDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	for locale, translation := range data.Translations {
+		params.Translations = append(params.Translations, SqTranslationRow{
+			Locale: locale,
+			Name:   translation.Name,
+			Title:  translation.Title,
+		})
+	}
+	var err error
+	if params.Translations, err = normalizeTranslations(params.Translations); err != nil {
+		return nil, err
 	}
 
-	sqProduct, err := r.queries.SqCreateProduct(ctx, params)
+	// attributes are checked against the schemas on the primary, with the
+	// categories locked so their schemas can not change before the insert
+	var sqProduct *SqProductRow
+	err = r.inTx(ctx, func(q *RepoQueries) error {
+		schemas, err := q.SqGetAttributeSchemas(ctx, params.CategoryIDs)
+		if err != nil {
+			return err
+		}
+		if err = attributes.Validate(schemas, params.Attributes); err != nil {
+			return err
+		}
+		sqProduct, err = q.SqCreateProduct(ctx, params)
+		return err
+	})
 	if err != nil {
+		if errors.Is(err, productsDomain.ErrInvalidAttributes) {
+			return nil, err
+		}
+		if _, ok := dbtx.ConstraintViolation(err, dbtx.ForeignKeyViolation); ok {
+			return nil, categoriesDomain.ErrCategoryNotFound
+		}
 		return nil, fmt.Errorf("sq create product error: %w", err)
 	}
 
 	request := productsDomain.Product{
-		ID:        sqProduct.ID.Bytes,
-		Name:      sqProduct.Name,
-		Title:     sqProduct.Title,
-		CreatedAt: sqProduct.CreatedAt.Time,
-		UpdatedAt: sqProduct.UpdatedAt.Time,
-		DeletedAt: NConvertPgTimestamp(sqProduct.DeletedAt),
+		ID:          sqProduct.ID.Bytes,
+		Name:        sqProduct.Name,
+		Title:       sqProduct.Title,
+		CreatedAt:   sqProduct.CreatedAt.Time,
+		UpdatedAt:   sqProduct.UpdatedAt.Time,
+		DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+		Status:      sqProduct.Status,
+		PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+		UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+		Attributes:  sqProduct.Attributes,
 	}
 
 	return &request, nil
@@ -96,26 +242,73 @@ func (r *Repository) PartialUpdateProduct(
 	data productsDomain.PartialUpdateProductDTO,
 ) (*productsDomain.Product, error) {
 	params := SqPartialUpdateProductParams{
-		ID:    pgtype.UUID{Bytes: data.ID, Valid: true},
-		Name:  data.Name,
-		Title: data.Title,
+		ID:         pgtype.UUID{Bytes: data.ID, Valid: true},
+		Name:       data.Name,
+		Title:      data.Title,
+		Attributes: data.Attributes,
+	}
+	for locale, translation := range data.Translations {
+		if translation == nil {
+			params.RemovedLocales = append(params.RemovedLocales, locale)
+			continue
+		}
+		params.Translations = append(params.Translations, SqTranslationRow{
+			Locale: locale,
+			Name:   translation.Name,
+			Title:  translation.Title,
+		})
+	}
+	var err error
+	if params.Translations, err = normalizeTranslations(params.Translations); err != nil {
+		return nil, err
+	}
+	for i, locale := range params.RemovedLocales {
+		if params.RemovedLocales[i], err = productsDomain.NormalizeLocale(locale); err != nil {
+			return nil, err
+		}
 	}
 
-	sqProduct, err := r.queries.SqPartialUpdateProduct(ctx, params)
+	// new attributes are checked against the schemas of the categories the
+	// product is in, both locked until the update is done
+	var sqProduct *SqProductRow
+	err = r.inTx(ctx, func(q *RepoQueries) error {
+		if params.Attributes != nil {
+			if err := q.SqLockProduct(ctx, params.ID); err != nil {
+				return err
+			}
+			schemas, err := q.SqGetProductAttributeSchemas(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if err = attributes.Validate(schemas, params.Attributes); err != nil {
+				return err
+			}
+		}
+		var err error
+		sqProduct, err = q.SqPartialUpdateProduct(ctx, params)
+		return err
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, productsDomain.ErrProductNotFound
 		}
+		if errors.Is(err, productsDomain.ErrInvalidAttributes) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("sq partial update product error: %w", err)
 	}
 
 	request := productsDomain.Product{
-		ID:        sqProduct.ID.Bytes,
-		Name:      sqProduct.Name,
-		Title:     sqProduct.Title,
-		CreatedAt: sqProduct.CreatedAt.Time,
-		UpdatedAt: sqProduct.UpdatedAt.Time,
-		DeletedAt: NConvertPgTimestamp(sqProduct.DeletedAt),
+		ID:          sqProduct.ID.Bytes,
+		Name:        sqProduct.Name,
+		Title:       sqProduct.Title,
+		CreatedAt:   sqProduct.CreatedAt.Time,
+		UpdatedAt:   sqProduct.UpdatedAt.Time,
+		DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+		Status:      sqProduct.Status,
+		PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+		UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+		Attributes:  sqProduct.Attributes,
 	}
 
 	return &request, nil
@@ -144,6 +337,26 @@ func (r *Repository) DeleteProduct(
 	return &request, nil
 }
 
+func (r *Repository) PurgeDeletedProducts(
+	ctx context.Context,
+	data productsDomain.PurgeDeletedProductsDTO,
+) ([]uuid.UUID, error) {
+	params := SqPurgeDeletedProductsParams{
+		DeletedBefore: pgtype.Timestamp{Time: data.DeletedBefore, Valid: true},
+	}
+
+	sqIDs, err := r.queries.SqPurgeDeletedProducts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq purge deleted products error: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(sqIDs))
+	for _, id := range sqIDs {
+		ids = append(ids, id.Bytes)
+	}
+	return ids, nil
+}
+
 func (r *Repository) BulkCreateProducts(
 	ctx context.Context,
 	data []productsDomain.Product,
@@ -163,12 +376,16 @@ func (r *Repository) BulkCreateProducts(
 
 	for _, sqProduct := range sqProducts {
 		products = append(products, productsDomain.Product{
-			ID:        sqProduct.ID.Bytes,
-			Name:      sqProduct.Name,
-			Title:     sqProduct.Title,
-			CreatedAt: sqProduct.CreatedAt.Time,
-			UpdatedAt: sqProduct.UpdatedAt.Time,
-			DeletedAt: NConvertPgTimestamp(sqProduct.DeletedAt),
+			ID:          sqProduct.ID.Bytes,
+			Name:        sqProduct.Name,
+			Title:       sqProduct.Title,
+			CreatedAt:   sqProduct.CreatedAt.Time,
+			UpdatedAt:   sqProduct.UpdatedAt.Time,
+			DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+			Status:      sqProduct.Status,
+			PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+			UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+			Attributes:  sqProduct.Attributes,
 		})
 	}
 
@@ -199,14 +416,64 @@ func (r *Repository) BulkUpdateProducts(
 
 	for _, sqProduct := range sqProducts {
 		products = append(products, productsDomain.Product{
-			ID:        sqProduct.ID.Bytes,
-			Name:      sqProduct.Name,
-			Title:     sqProduct.Title,
-			CreatedAt: sqProduct.CreatedAt.Time,
-			UpdatedAt: sqProduct.UpdatedAt.Time,
-			DeletedAt: NConvertPgTimestamp(sqProduct.DeletedAt),
+			ID:          sqProduct.ID.Bytes,
+			Name:        sqProduct.Name,
+			Title:       sqProduct.Title,
+			CreatedAt:   sqProduct.CreatedAt.Time,
+			UpdatedAt:   sqProduct.UpdatedAt.Time,
+			DeletedAt:   NConvertPgTimestamp(sqProduct.DeletedAt),
+			Status:      sqProduct.Status,
+			PublishAt:   NConvertPgTimestamp(sqProduct.PublishAt),
+			UnpublishAt: NConvertPgTimestamp(sqProduct.UnpublishAt),
+			Attributes:  sqProduct.Attributes,
 		})
 	}
 
 	return products, nil
 }
+
+func newProductsFilter(data productsDomain.GetProductsDTO) SqProductsFilter {
+	filter := SqProductsFilter{
+		Name:          data.Name,
+		Title:         data.Title,
+		Currency:      data.Currency,
+		Attributes:    data.Attributes,
+		TagsAll:       tagsDomain.NormalizeTags(data.TagsAll),
+		TagsAny:       tagsDomain.NormalizeTags(data.TagsAny),
+		Statuses:      data.Statuses,
+		Query:         data.Query,
+		Locales:       data.Locales,
+		PublishedOnly: data.PublishedOnly,
+	}
+	if data.Category != nil {
+		filter.Category = pgtype.UUID{Bytes: *data.Category, Valid: true}
+	}
+	if data.PriceMin != nil {
+		filter.PriceMin = pgtype.Int8{Int64: *data.PriceMin, Valid: true}
+	}
+	if data.PriceMax != nil {
+		filter.PriceMax = pgtype.Int8{Int64: *data.PriceMax, Valid: true}
+	}
+	return filter
+}
+
+// normalizeTranslations brings locales to their canonical form, keeping one
+// translation per locale.
+func normalizeTranslations(translations []SqTranslationRow) ([]SqTranslationRow, error) {
+	normalized := make([]SqTranslationRow, 0, len(translations))
+	positions := make(map[string]int, len(translations))
+	for _, translation := range translations {
+		locale, err := productsDomain.NormalizeLocale(translation.Locale)
+		if err != nil {
+			return nil, err
+		}
+		translation.Locale = locale
+		if i, ok := positions[locale]; ok {
+			normalized[i] = translation
+			continue
+		}
+		positions[locale] = len(normalized)
+		normalized = append(normalized, translation)
+	}
+	return normalized, nil
+}
@@ -5,53 +5,113 @@ import (
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5/pgtype"
+	"slices"
+	"strings"
 )
 
 const (
 	ProductsTable = "products"
 )
 
-const CreateProductSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at`
-const PartialUpdateProductSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at`
+const CreateProductSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at, status, publish_at, unpublish_at, attributes`
+const PartialUpdateProductSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at, status, publish_at, unpublish_at, attributes`
 const DeleteProductSuffix = `RETURNING id`
-const BulkCreateProductsSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at`
-const BulkUpdateProductsSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at`
+const BulkCreateProductsSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at, status, publish_at, unpublish_at, attributes`
+const BulkUpdateProductsSuffix = `RETURNING id, name, title, created_at, updated_at, deleted_at, status, publish_at, unpublish_at, attributes`
+
+// ProductColumns are the columns a product read may be narrowed to.
+var ProductColumns = []string{
+	"id", "name", "title", "created_at", "updated_at", "deleted_at", "status", "publish_at", "unpublish_at", "attributes",
+}
 
 type SqProductRow struct {
-	ID        pgtype.UUID
-	Name      string
-	Title     string
-	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
-	DeletedAt pgtype.Timestamp
+	ID          pgtype.UUID
+	Name        string
+	Title       string
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	DeletedAt   pgtype.Timestamp
+	Status      string
+	PublishAt   pgtype.Timestamp
+	UnpublishAt pgtype.Timestamp
+	Attributes  map[string]any
+	// Price is only read by lists asking for a currency.
+	Price pgtype.Int8
+	// Locale is only read by localized reads, it is NULL for untranslated
+	// products.
+	Locale pgtype.Text
 }
 
+// SqCreateProductParams assigns the new product to CategoryIDs and stores
+// its Translations in the same statement.
 type SqCreateProductParams struct {
-	Name  string
-	Title string
+	Name         string         `db:"name"`
+	Title        string         `db:"title"`
+	Attributes   map[string]any `db:"attributes"`
+	CategoryIDs  []pgtype.UUID
+	Translations []SqTranslationRow
 }
 
 type SqGetProductsParams struct {
-	Limit  uint64
-	Offset uint64
-	Name   string `db:"name"`
-	Title  string `db:"title"`
+	Limit   uint64
+	Offset  uint64
+	Columns []string
+	Filter  SqProductsFilter
+	Sort    string
+}
+
+// productSorts maps list sorts to ORDER BY terms, id keeps pages stable
+// when values repeat.
+var productSorts = map[string][]string{
+	"name":        {"name", "id"},
+	"-name":       {"name DESC", "id"},
+	"created_at":  {"created_at", "id"},
+	"-created_at": {"created_at DESC", "id"},
+	"price":       {"current_price.amount NULLS LAST", "id"},
+	"-price":      {"current_price.amount DESC NULLS LAST", "id"},
+}
+
+type SqProductsStatsRow struct {
+	Count         int64
+	LastUpdatedAt pgtype.Timestamp
+}
+
+type SqExportProductsParams struct {
+	Filter SqProductsFilter
 }
 
 type SqGetProductParams struct {
-	ID pgtype.UUID `db:"id"`
+	ID            pgtype.UUID `db:"id"`
+	Columns       []string
+	Locales       []string
+	PublishedOnly bool
 }
 
+type SqGetProductsByIDsParams struct {
+	IDs           []pgtype.UUID
+	Locales       []string
+	PublishedOnly bool
+}
+
+// SqPartialUpdateProductParams upserts Translations and deletes the ones in
+// RemovedLocales in the same statement.
 type SqPartialUpdateProductParams struct {
-	ID    pgtype.UUID
-	Name  string `db:"name"`
-	Title string `db:"title"`
+	ID             pgtype.UUID
+	Name           string         `db:"name"`
+	Title          string         `db:"title"`
+	Attributes     map[string]any `db:"attributes"`
+	Translations   []SqTranslationRow
+	RemovedLocales []string
 }
 
 type SqDeleteProductParams struct {
 	ID pgtype.UUID `db:"id"`
 }
 
+type SqPurgeDeletedProductsParams struct {
+	DeletedBefore pgtype.Timestamp `db:"deleted_at"`
+}
+
 type SqBulkUpdateProductsParams struct {
 	UpdateFields []string
 	Products     []SqProductRow
@@ -65,22 +125,23 @@ func (q *RepoQueries) SqGetProducts(
 	if err != nil {
 		return nil, fmt.Errorf("sq get products build query error: %w", err)
 	}
-	rows, err := q.db.Query(ctx, query, args...)
+	rows, err := q.readDB.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	columns := selectedProductColumns(params.Columns)
 	var items []SqProductRow
 	for rows.Next() {
 		var i SqProductRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Title,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-		); err != nil {
+		dest := i.scanDest(columns)
+		if len(params.Filter.Locales) > 0 {
+			dest = append(dest, &i.Locale)
+		}
+		if params.Filter.Currency != "" {
+			dest = append(dest, &i.Price)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -94,13 +155,30 @@ func (q *RepoQueries) SqGetProducts(
 func buildGetProductsQuery(
 	params SqGetProductsParams,
 ) (string, []interface{}, error) {
-	dbFields := GetDbFieldsWithValues(params)
-	query := sq.Select("id", "name", "title", "created_at", "updated_at", "deleted_at").
+	if err := checkProductColumns(params.Columns); err != nil {
+		return "", nil, err
+	}
+	sort, ok := productSorts[params.Sort]
+	if params.Sort != "" && !ok {
+		return "", nil, fmt.Errorf("unknown product sort %q", params.Sort)
+	}
+	if strings.TrimPrefix(params.Sort, "-") == "price" && params.Filter.Currency == "" {
+		return "", nil, fmt.Errorf("sorting by price needs a currency")
+	}
+	query := localize(sq.Select(), selectedProductColumns(params.Columns), params.Filter.Locales).
 		From(ProductsTable).
+		OrderBy(localizeSort(sort, params.Filter.Locales)...).
 		Limit(params.Limit).
 		Offset(params.Offset).
 		PlaceholderFormat(sq.Dollar)
-	query = SelectBuilderAddWhereAnd([]string{"name", "title"}, query, dbFields)
+	if params.Filter.Currency != "" {
+		query = query.Column("current_price.amount").JoinClause(
+			"LEFT JOIN LATERAL (SELECT amount FROM product_prices WHERE product_id = products.id AND currency = ? AND "+
+				currentPriceCondition+") AS current_price ON true",
+			params.Filter.Currency,
+		)
+	}
+	query = params.Filter.apply(query)
 	sqlString, args, err := query.ToSql()
 	if err != nil {
 		return "", nil, fmt.Errorf("sq get products query to sql error: %w", err)
@@ -108,6 +186,87 @@ func buildGetProductsQuery(
 	return sqlString, args, nil
 }
 
+func (q *RepoQueries) SqGetProductsStats(
+	ctx context.Context,
+	params SqGetProductsParams,
+) (*SqProductsStatsRow, error) {
+	query, args, err := buildGetProductsStatsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products stats build query error: %w", err)
+	}
+	row := q.readDB.QueryRow(ctx, query, args...)
+	var i SqProductsStatsRow
+	err = row.Scan(
+		&i.Count,
+		&i.LastUpdatedAt,
+	)
+	return &i, err
+}
+
+func buildGetProductsStatsQuery(
+	params SqGetProductsParams,
+) (string, []interface{}, error) {
+	query := sq.Select("COUNT(*)", "MAX(updated_at)").
+		From(ProductsTable).
+		PlaceholderFormat(sq.Dollar)
+	query = params.Filter.apply(query)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get products stats query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqExportProducts streams matching rows to fn one by one while iterating the
+// pgx rows, so the result set is never held in memory.
+func (q *RepoQueries) SqExportProducts(
+	ctx context.Context,
+	params SqExportProductsParams,
+	fn func(SqProductRow) error,
+) error {
+	query, args, err := buildExportProductsQuery(params)
+	if err != nil {
+		return fmt.Errorf("sq export products build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i SqProductRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Title,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func buildExportProductsQuery(
+	params SqExportProductsParams,
+) (string, []interface{}, error) {
+	query := sq.Select("id", "name", "title", "created_at", "updated_at", "deleted_at").
+		From(ProductsTable).
+		OrderBy("created_at", "id").
+		PlaceholderFormat(sq.Dollar)
+	query = params.Filter.apply(query)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq export products query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
 func (q *RepoQueries) SqGetProduct(
 	ctx context.Context,
 	params SqGetProductParams,
@@ -116,27 +275,31 @@ func (q *RepoQueries) SqGetProduct(
 	if err != nil {
 		return nil, fmt.Errorf("sq get product build query error: %w", err)
 	}
-	row := q.db.QueryRow(ctx, query, args...)
+	row := q.readDB.QueryRow(ctx, query, args...)
 	var i SqProductRow
-	err = row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Title,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
+	dest := i.scanDest(selectedProductColumns(params.Columns))
+	if len(params.Locales) > 0 {
+		dest = append(dest, &i.Locale)
+	}
+	err = row.Scan(dest...)
 	return &i, err
 }
 
 func buildGetProductQuery(
 	params SqGetProductParams,
 ) (string, []interface{}, error) {
+	if err := checkProductColumns(params.Columns); err != nil {
+		return "", nil, err
+	}
 	dbFields := GetDbFieldsWithValues(params)
-	query := sq.Select("id", "name", "title", "created_at", "updated_at", "deleted_at").
+	query := localize(sq.Select(), selectedProductColumns(params.Columns), params.Locales).
 		From(ProductsTable).
 		PlaceholderFormat(sq.Dollar)
-	query = SelectBuilderAddWhereAnd([]string{"id"}, query, dbFields)
+	query = SelectBuilderAddWhereAnd([]string{"id"}, query, dbFields).
+		Where(liveCondition)
+	if params.PublishedOnly {
+		query = query.Where(publishedCondition)
+	}
 	sqlString, args, err := query.ToSql()
 	if err != nil {
 		return "", nil, fmt.Errorf("sq get product query to sql error: %w", err)
@@ -144,6 +307,55 @@ func buildGetProductQuery(
 	return sqlString, args, nil
 }
 
+func (q *RepoQueries) SqGetProductsByIDs(
+	ctx context.Context,
+	params SqGetProductsByIDsParams,
+) ([]SqProductRow, error) {
+	query, args, err := buildGetProductsByIDsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products by ids build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqProductRow
+	for rows.Next() {
+		var i SqProductRow
+		dest := i.scanDest(ProductColumns)
+		if len(params.Locales) > 0 {
+			dest = append(dest, &i.Locale)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func buildGetProductsByIDsQuery(
+	params SqGetProductsByIDsParams,
+) (string, []interface{}, error) {
+	query := localize(sq.Select(), ProductColumns, params.Locales).
+		From(ProductsTable).
+		Where(sq.Expr("id = ANY(?)", params.IDs)).
+		Where(liveCondition).
+		PlaceholderFormat(sq.Dollar)
+	if params.PublishedOnly {
+		query = query.Where(publishedCondition)
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get products by ids query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
 func (q *RepoQueries) SqCreateProduct(
 	ctx context.Context,
 	params SqCreateProductParams,
@@ -161,6 +373,10 @@ func (q *RepoQueries) SqCreateProduct(
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.UnpublishAt,
+		&i.Attributes,
 	)
 	return &i, err
 }
@@ -177,16 +393,26 @@ func buildCreateProductQuery(
 		values = append(values, v)
 	}
 
-	query := sq.Insert(ProductsTable).
+	insert, args, err := sq.Insert(ProductsTable).
 		Columns(columns...).
 		Values(values...).
 		Suffix(CreateProductSuffix).
-		PlaceholderFormat(sq.Dollar)
-	sqlString, args, err := query.ToSql()
+		ToSql()
 	if err != nil {
 		return "", nil, err
 	}
-	return sqlString, args, nil
+	var statements []sq.Sqlizer
+	if len(params.CategoryIDs) > 0 {
+		statements = append(statements, sq.Expr(
+			"INSERT INTO product_categories (product_id, category_id) "+
+				"SELECT DISTINCT product.id, category_id FROM product, unnest(?::uuid[]) AS category_id",
+			params.CategoryIDs,
+		))
+	}
+	if len(params.Translations) > 0 {
+		statements = append(statements, translationsUpsert(params.Translations))
+	}
+	return withProduct(insert, args, statements...)
 }
 
 func (q *RepoQueries) SqPartialUpdateProduct(
@@ -207,6 +433,10 @@ func (q *RepoQueries) SqPartialUpdateProduct(
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+		&i.PublishAt,
+		&i.UnpublishAt,
+		&i.Attributes,
 	)
 	return &i, err
 }
@@ -217,14 +447,25 @@ func buildPartialUpdateProductQuery(
 	dbFields := GetDbFieldsWithValues(params)
 	query := sq.Update(ProductsTable).
 		SetMap(dbFields).
-		Suffix(PartialUpdateProductSuffix).
-		PlaceholderFormat(sq.Dollar)
-	query = UpdateBuilderAddWhereAnd([]string{"id"}, query, map[string]interface{}{"id": params.ID})
-	sqlString, args, err := query.ToSql()
+		Set("updated_at", sq.Expr("NOW()")).
+		Suffix(PartialUpdateProductSuffix)
+	query = UpdateBuilderAddWhereAnd([]string{"id"}, query, map[string]interface{}{"id": params.ID}).
+		Where(liveCondition)
+	update, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	var statements []sq.Sqlizer
+	if len(params.Translations) > 0 {
+		statements = append(statements, translationsUpsert(params.Translations))
+	}
+	if len(params.RemovedLocales) > 0 {
+		statements = append(statements, translationsDelete(params.RemovedLocales))
+	}
+	sqlString, args, err := withProduct(update, args, statements...)
 	if err != nil {
 		return "", nil, err
 	}
-	fmt.Println(sqlString)
 	return sqlString, args, nil
 }
 
@@ -244,13 +485,59 @@ func (q *RepoQueries) SqDeleteProduct(
 	return &i, err
 }
 
+// buildDeleteProductQuery soft deletes the product, the row stays until
+// SqPurgeDeletedProducts removes it.
 func buildDeleteProductQuery(
 	params SqDeleteProductParams,
+) (string, []interface{}, error) {
+	query := sq.Update(ProductsTable).
+		Set("deleted_at", sq.Expr("NOW()")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Suffix(DeleteProductSuffix).
+		PlaceholderFormat(sq.Dollar)
+	query = UpdateBuilderAddWhereAnd([]string{"id"}, query, map[string]interface{}{"id": params.ID}).
+		Where(liveCondition)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqPurgeDeletedProducts(
+	ctx context.Context,
+	params SqPurgeDeletedProductsParams,
+) ([]pgtype.UUID, error) {
+	query, args, err := buildPurgeDeletedProductsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq purge deleted products build query error: %w", err)
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func buildPurgeDeletedProductsQuery(
+	params SqPurgeDeletedProductsParams,
 ) (string, []interface{}, error) {
 	query := sq.Delete(ProductsTable).
+		Where(sq.Lt{"deleted_at": params.DeletedBefore}).
 		Suffix(DeleteProductSuffix).
 		PlaceholderFormat(sq.Dollar)
-	query = DeleteBuilderAddWhereAnd([]string{"id"}, query, map[string]interface{}{"id": params.ID})
 	sqlString, args, err := query.ToSql()
 	if err != nil {
 		return "", nil, err
@@ -281,6 +568,10 @@ func (q *RepoQueries) SqBulkCreateProducts(
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.UnpublishAt,
+			&i.Attributes,
 		); err != nil {
 			return nil, err
 		}
@@ -301,7 +592,6 @@ func buildBulkCreateProductsQuery(params []SqProductRow) (string, []interface{},
 
 	for _, product := range params {
 		query = query.Values(
-			product.ID,
 			product.Name,
 			product.Title,
 		)
@@ -338,6 +628,10 @@ func (q *RepoQueries) SqBulkUpdateProducts(
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
+			&i.PublishAt,
+			&i.UnpublishAt,
+			&i.Attributes,
 		); err != nil {
 			return nil, err
 		}
@@ -357,28 +651,27 @@ func buildBulkUpdateProductsQuery(
 		updateFieldsMap[field] = true
 	}
 
-	query := sq.Update(ProductsTable).
-		Suffix(BulkUpdateProductsSuffix).
-		PlaceholderFormat(sq.Dollar)
-
+	ids := make([]pgtype.UUID, 0, len(params.Products))
 	nameCase := sq.Case()
 	titleCase := sq.Case()
 	for _, product := range params.Products {
-		if _, ok := updateFieldsMap["name"]; ok {
-			nameCase = nameCase.When(
-				sq.Eq{"id": product.ID.Bytes}, sq.Expr("?", product.Name),
-			).Else("name")
-		}
-		if _, ok := updateFieldsMap["title"]; ok {
-			nameCase = nameCase.When(
-				sq.Eq{"id": product.ID.Bytes}, sq.Expr("?", product.Title),
-			).Else("title")
-		}
+		ids = append(ids, product.ID)
+		nameCase = nameCase.When(sq.Eq{"id": product.ID}, sq.Expr("?", product.Name))
+		titleCase = titleCase.When(sq.Eq{"id": product.ID}, sq.Expr("?", product.Title))
 	}
 
-	query = query.
-		Set("name", nameCase).
-		Set("title", titleCase)
+	query := sq.Update(ProductsTable).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("id = ANY(?)", ids)).
+		Where(liveCondition).
+		Suffix(BulkUpdateProductsSuffix).
+		PlaceholderFormat(sq.Dollar)
+	if updateFieldsMap["name"] {
+		query = query.Set("name", nameCase.Else("name"))
+	}
+	if updateFieldsMap["title"] {
+		query = query.Set("title", titleCase.Else("title"))
+	}
 
 	sqlString, args, err := query.ToSql()
 
@@ -387,3 +680,49 @@ func buildBulkUpdateProductsQuery(
 	}
 	return sqlString, args, nil
 }
+
+// scanDest returns scan targets for the given columns in their order.
+func (i *SqProductRow) scanDest(columns []string) []interface{} {
+	dest := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		switch column {
+		case "id":
+			dest = append(dest, &i.ID)
+		case "name":
+			dest = append(dest, &i.Name)
+		case "title":
+			dest = append(dest, &i.Title)
+		case "created_at":
+			dest = append(dest, &i.CreatedAt)
+		case "updated_at":
+			dest = append(dest, &i.UpdatedAt)
+		case "deleted_at":
+			dest = append(dest, &i.DeletedAt)
+		case "status":
+			dest = append(dest, &i.Status)
+		case "publish_at":
+			dest = append(dest, &i.PublishAt)
+		case "unpublish_at":
+			dest = append(dest, &i.UnpublishAt)
+		case "attributes":
+			dest = append(dest, &i.Attributes)
+		}
+	}
+	return dest
+}
+
+func selectedProductColumns(columns []string) []string {
+	if len(columns) == 0 {
+		return ProductColumns
+	}
+	return columns
+}
+
+func checkProductColumns(columns []string) error {
+	for _, column := range columns {
+		if !slices.Contains(ProductColumns, column) {
+			return fmt.Errorf("unknown product column %q", column)
+		}
+	}
+	return nil
+}
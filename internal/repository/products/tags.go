@@ -0,0 +1,30 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	tagsDomain "go_template_project/internal/domain/tags"
+)
+
+func (r *Repository) GetTagCounts(
+	ctx context.Context,
+	data tagsDomain.GetTagCountsDTO,
+) ([]tagsDomain.TagCount, error) {
+	params := SqGetTagCountsParams{
+		Filter: newProductsFilter(data.Filter),
+		Limit:  uint64(data.Limit),
+	}
+	sqCounts, err := r.queries.SqGetTagCounts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get tag counts error: %w", err)
+	}
+	counts := make([]tagsDomain.TagCount, 0, len(sqCounts))
+	for _, sqCount := range sqCounts {
+		counts = append(counts, tagsDomain.TagCount{
+			ID:    sqCount.ID.Bytes,
+			Name:  sqCount.Name,
+			Count: sqCount.Count,
+		})
+	}
+	return counts, nil
+}
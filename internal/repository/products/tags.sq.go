@@ -0,0 +1,71 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SqGetTagCountsParams struct {
+	Filter SqProductsFilter
+	Limit  uint64
+}
+
+type SqTagCountRow struct {
+	ID    pgtype.UUID
+	Name  string
+	Count int64
+}
+
+// SqGetTagCounts counts the tags of the products matched by the filter, the
+// most used first.
+func (q *RepoQueries) SqGetTagCounts(
+	ctx context.Context,
+	params SqGetTagCountsParams,
+) ([]SqTagCountRow, error) {
+	query, args, err := buildGetTagCountsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get tag counts build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqTagCountRow
+	for rows.Next() {
+		var i SqTagCountRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func buildGetTagCountsQuery(
+	params SqGetTagCountsParams,
+) (string, []interface{}, error) {
+	query := sq.Select("t.id", "t.name", "COUNT(*)").
+		From(ProductsTable).
+		Join("product_tags pt ON pt.product_id = products.id").
+		Join("tags t ON t.id = pt.tag_id").
+		GroupBy("t.id", "t.name").
+		OrderBy("COUNT(*) DESC", "t.name").
+		Limit(params.Limit).
+		PlaceholderFormat(sq.Dollar)
+	query = params.Filter.apply(query)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get tag counts query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
@@ -0,0 +1,63 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	productsDomain "go_template_project/internal/domain/products"
+	"slices"
+)
+
+func (r *Repository) GetProductTranslations(
+	ctx context.Context,
+	data productsDomain.GetProductTranslationsDTO,
+) ([]productsDomain.ProductTranslation, error) {
+	sqTranslations, err := r.queries.SqGetProductTranslations(ctx, pgtype.UUID{Bytes: data.ProductID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("sq get product translations error: %w", err)
+	}
+	translations := make([]productsDomain.ProductTranslation, 0, len(sqTranslations))
+	for _, sqTranslation := range sqTranslations {
+		translations = append(translations, productsDomain.ProductTranslation{
+			Locale:    sqTranslation.Locale,
+			Name:      sqTranslation.Name,
+			Title:     sqTranslation.Title,
+			UpdatedAt: sqTranslation.UpdatedAt.Time,
+		})
+	}
+	return translations, nil
+}
+
+func (r *Repository) GetMissingTranslations(
+	ctx context.Context,
+	data productsDomain.GetMissingTranslationsDTO,
+) ([]productsDomain.MissingTranslation, error) {
+	params := SqGetMissingTranslationsParams{
+		Locales: make([]string, 0, len(data.Locales)),
+		Limit:   uint64(data.Limit),
+		Offset:  uint64(data.Offset),
+	}
+	for _, locale := range data.Locales {
+		locale, err := productsDomain.NormalizeLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(params.Locales, locale) {
+			params.Locales = append(params.Locales, locale)
+		}
+	}
+	sqMissing, err := r.queries.SqGetMissingTranslations(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get missing translations error: %w", err)
+	}
+	missing := make([]productsDomain.MissingTranslation, 0, len(sqMissing))
+	for _, sqProduct := range sqMissing {
+		missing = append(missing, productsDomain.MissingTranslation{
+			ProductID:      sqProduct.ProductID.Bytes,
+			Name:           sqProduct.Name,
+			Title:          sqProduct.Title,
+			MissingLocales: sqProduct.MissingLocales,
+		})
+	}
+	return missing, nil
+}
@@ -0,0 +1,226 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+	"strings"
+)
+
+const TranslationsTable = "product_translations"
+
+// translationJoin picks the translation of a product in the first of the
+// locales it has one in.
+const translationJoin = "LEFT JOIN LATERAL (SELECT locale, name, title FROM " + TranslationsTable +
+	" WHERE product_id = products.id AND locale = ANY(?::text[])" +
+	" ORDER BY array_position(?::text[], locale::text) LIMIT 1) AS translation ON true"
+
+// localizedColumns maps product columns to select expressions, name and
+// title fall back to the untranslated ones.
+var localizedColumns = map[string]string{
+	"name":  "COALESCE(translation.name, products.name)",
+	"title": "COALESCE(translation.title, products.title)",
+}
+
+type SqTranslationRow struct {
+	Locale    string
+	Name      string
+	Title     string
+	UpdatedAt pgtype.Timestamp
+}
+
+type SqGetMissingTranslationsParams struct {
+	Locales []string
+	Limit   uint64
+	Offset  uint64
+}
+
+type SqMissingTranslationRow struct {
+	ProductID      pgtype.UUID
+	Name           string
+	Title          string
+	MissingLocales []string
+}
+
+// localize joins the translations in locales and reads name and title from
+// them, the locale used is selected last. Without locales the query is
+// returned as it is.
+func localize(query sq.SelectBuilder, columns []string, locales []string) sq.SelectBuilder {
+	if len(locales) == 0 {
+		return query.Columns(columns...)
+	}
+	for _, column := range columns {
+		if expr, ok := localizedColumns[column]; ok {
+			query = query.Column(expr + " AS " + column)
+		} else {
+			query = query.Column(ProductsTable + "." + column)
+		}
+	}
+	return query.
+		Column("translation.locale").
+		JoinClause(translationJoin, locales, locales)
+}
+
+// localizeSort orders by translated names when translations are joined.
+func localizeSort(terms []string, locales []string) []string {
+	if len(locales) == 0 {
+		return terms
+	}
+	localized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		column, direction, _ := strings.Cut(term, " ")
+		if expr, ok := localizedColumns[column]; ok {
+			term = strings.TrimSpace(expr + " " + direction)
+		}
+		localized = append(localized, term)
+	}
+	return localized
+}
+
+// translationsUpsert writes translations of the product returned by the
+// statement withProduct wraps.
+func translationsUpsert(translations []SqTranslationRow) sq.Sqlizer {
+	locales := make([]string, 0, len(translations))
+	names := make([]string, 0, len(translations))
+	titles := make([]string, 0, len(translations))
+	for _, translation := range translations {
+		locales = append(locales, translation.Locale)
+		names = append(names, translation.Name)
+		titles = append(titles, translation.Title)
+	}
+	return sq.Expr(
+		"INSERT INTO "+TranslationsTable+" (product_id, locale, name, title) "+
+			"SELECT product.id, t.locale, t.name, t.title "+
+			"FROM product, unnest(?::text[], ?::text[], ?::text[]) AS t(locale, name, title) "+
+			"ON CONFLICT (product_id, locale) DO UPDATE "+
+			"SET name = EXCLUDED.name, title = EXCLUDED.title, updated_at = NOW()",
+		locales, names, titles,
+	)
+}
+
+// translationsDelete removes translations of the product returned by the
+// statement withProduct wraps.
+func translationsDelete(locales []string) sq.Sqlizer {
+	return sq.Expr(
+		"DELETE FROM "+TranslationsTable+" USING product "+
+			"WHERE "+TranslationsTable+".product_id = product.id AND "+TranslationsTable+".locale = ANY(?::text[])",
+		locales,
+	)
+}
+
+// withProduct runs statements along with a statement returning a product,
+// which they can read as product. Postgres runs data-modifying CTEs even
+// though the outer query does not read from them. The statement has to use
+// question placeholders, the result uses dollar ones.
+func withProduct(statement string, args []interface{}, statements ...sq.Sqlizer) (string, []interface{}, error) {
+	if len(statements) == 0 {
+		sqlString, err := sq.Dollar.ReplacePlaceholders(statement)
+		return sqlString, args, err
+	}
+	ctes := []string{"product AS (" + statement + ")"}
+	for i, cte := range statements {
+		cteString, cteArgs, err := cte.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+		ctes = append(ctes, fmt.Sprintf("modified_%d AS (%s)", i, cteString))
+		args = append(args, cteArgs...)
+	}
+	sqlString, err := sq.Dollar.ReplacePlaceholders("WITH " + strings.Join(ctes, ", ") + " SELECT * FROM product")
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetProductTranslations(
+	ctx context.Context,
+	productID pgtype.UUID,
+) ([]SqTranslationRow, error) {
+	query, args, err := sq.Select("locale", "name", "title", "updated_at").
+		From(TranslationsTable).
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("locale").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get product translations build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqTranslationRow
+	for rows.Next() {
+		var i SqTranslationRow
+		if err := rows.Scan(
+			&i.Locale,
+			&i.Name,
+			&i.Title,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (q *RepoQueries) SqGetMissingTranslations(
+	ctx context.Context,
+	params SqGetMissingTranslationsParams,
+) ([]SqMissingTranslationRow, error) {
+	query, args, err := buildGetMissingTranslationsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get missing translations build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqMissingTranslationRow
+	for rows.Next() {
+		var i SqMissingTranslationRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Name,
+			&i.Title,
+			&i.MissingLocales,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// buildGetMissingTranslationsQuery pairs every live product with every
+// locale and keeps the pairs without a translation, grouped by product.
+func buildGetMissingTranslationsQuery(
+	params SqGetMissingTranslationsParams,
+) (string, []interface{}, error) {
+	query := sq.Select("products.id", "products.name", "products.title", "array_agg(l.locale ORDER BY l.locale)").
+		From(ProductsTable).
+		JoinClause("CROSS JOIN unnest(?::text[]) AS l(locale)", params.Locales).
+		Where(sq.Eq{"products.deleted_at": nil}).
+		Where("NOT EXISTS (SELECT 1 FROM "+TranslationsTable+" t WHERE t.product_id = products.id AND t.locale = l.locale)").
+		GroupBy("products.id").
+		OrderBy("products.created_at", "products.id").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get missing translations query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
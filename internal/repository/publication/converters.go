@@ -0,0 +1,21 @@
+package publication
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func NConvertPgTimestamp(value pgtype.Timestamp) *time.Time {
+	if value.Valid {
+		return &value.Time
+	}
+	return nil
+}
+
+func NConvertTime(value *time.Time) pgtype.Timestamp {
+	if value != nil {
+		return pgtype.Timestamp{Time: value.UTC(), Valid: true}
+	}
+	return pgtype.Timestamp{}
+}
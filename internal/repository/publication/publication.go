@@ -0,0 +1,151 @@
+package publication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+// TransitionProduct moves the product to the new status if the workflow
+// allows it and records the transition, both under a lock on the product so
+// concurrent transitions are checked one after the other.
+func (r *Repository) TransitionProduct(
+	ctx context.Context,
+	data productsDomain.TransitionProductDTO,
+) (*productsDomain.ProductTransition, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+
+	var sqTransition *SqTransitionRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		status, err := q.SqLockProduct(ctx, productID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+		if err = productsDomain.CheckTransition(status, data.Status); err != nil {
+			return err
+		}
+		err = q.SqSetProductStatus(ctx, SqSetProductStatusParams{
+			ProductID: productID,
+			Status:    data.Status,
+		})
+		if err != nil {
+			return err
+		}
+		sqTransition, err = q.SqCreateTransition(ctx, SqTransitionRow{
+			ProductID:  productID,
+			FromStatus: status,
+			ToStatus:   data.Status,
+			Actor:      data.Actor,
+			Reason:     data.Reason,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sq transition product error: %w", err)
+	}
+	transition := convertTransition(*sqTransition)
+	return &transition, nil
+}
+
+func (r *Repository) GetProductTransitions(
+	ctx context.Context,
+	data productsDomain.GetProductTransitionsDTO,
+) ([]productsDomain.ProductTransition, error) {
+	sqTransitions, err := r.queries.SqGetProductTransitions(ctx, SqGetProductTransitionsParams{
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		Limit:     uint64(data.Limit),
+		Offset:    uint64(data.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sq get product transitions error: %w", err)
+	}
+	return convertTransitions(sqTransitions), nil
+}
+
+func (r *Repository) SetProductSchedule(
+	ctx context.Context,
+	data productsDomain.SetProductScheduleDTO,
+) (*productsDomain.ProductSchedule, error) {
+	sqSchedule, err := r.queries.SqSetProductSchedule(ctx, SqSetProductScheduleParams{
+		ProductID:   pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		PublishAt:   NConvertTime(data.PublishAt),
+		UnpublishAt: NConvertTime(data.UnpublishAt),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, productsDomain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("sq set product schedule error: %w", err)
+	}
+	return &productsDomain.ProductSchedule{
+		ProductID:   sqSchedule.ProductID.Bytes,
+		Status:      sqSchedule.Status,
+		PublishAt:   NConvertPgTimestamp(sqSchedule.PublishAt),
+		UnpublishAt: NConvertPgTimestamp(sqSchedule.UnpublishAt),
+	}, nil
+}
+
+// RunScheduledTransitions publishes due products that may be published and
+// archives published products due to be unpublished, up to Limit each.
+func (r *Repository) RunScheduledTransitions(
+	ctx context.Context,
+	data productsDomain.RunScheduledTransitionsDTO,
+) ([]productsDomain.ProductTransition, error) {
+	var transitions []productsDomain.ProductTransition
+	for _, params := range scheduledTransitions() {
+		params.Actor = data.Actor
+		params.Limit = uint64(data.Limit)
+		sqTransitions, err := r.queries.SqRunScheduledTransitions(ctx, params)
+		if err != nil {
+			return transitions, fmt.Errorf("sq run scheduled transitions error: %w", err)
+		}
+		transitions = append(transitions, convertTransitions(sqTransitions)...)
+	}
+	return transitions, nil
+}
+
+// scheduledTransitions are the moves the scheduler makes. Publishing takes
+// every status the workflow allows publishing from, so a draft is not
+// published before it was reviewed.
+func scheduledTransitions() []SqScheduledTransitionsParams {
+	return []SqScheduledTransitionsParams{
+		{
+			Column: "publish_at",
+			From:   productsDomain.TransitionSources(productsDomain.StatusPublished),
+			To:     productsDomain.StatusPublished,
+			Reason: "scheduled publish",
+		},
+		{
+			Column: "unpublish_at",
+			From:   []string{productsDomain.StatusPublished},
+			To:     productsDomain.StatusArchived,
+			Reason: "scheduled unpublish",
+		},
+	}
+}
+
+func convertTransition(sqTransition SqTransitionRow) productsDomain.ProductTransition {
+	return productsDomain.ProductTransition{
+		ID:         sqTransition.ID.Bytes,
+		ProductID:  sqTransition.ProductID.Bytes,
+		FromStatus: sqTransition.FromStatus,
+		ToStatus:   sqTransition.ToStatus,
+		Actor:      sqTransition.Actor,
+		Reason:     sqTransition.Reason,
+		CreatedAt:  sqTransition.CreatedAt.Time,
+	}
+}
+
+func convertTransitions(sqTransitions []SqTransitionRow) []productsDomain.ProductTransition {
+	transitions := make([]productsDomain.ProductTransition, 0, len(sqTransitions))
+	for _, sqTransition := range sqTransitions {
+		transitions = append(transitions, convertTransition(sqTransition))
+	}
+	return transitions
+}
@@ -0,0 +1,250 @@
+package publication
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ProductsTable           = "products"
+	ProductTransitionsTable = "product_transitions"
+)
+
+const transitionReturning = "RETURNING id, product_id, from_status, to_status, actor, reason, created_at"
+
+type SqTransitionRow struct {
+	ID         pgtype.UUID
+	ProductID  pgtype.UUID
+	FromStatus string
+	ToStatus   string
+	Actor      string
+	Reason     string
+	CreatedAt  pgtype.Timestamp
+}
+
+type SqScheduleRow struct {
+	ProductID   pgtype.UUID
+	Status      string
+	PublishAt   pgtype.Timestamp
+	UnpublishAt pgtype.Timestamp
+}
+
+type SqSetProductStatusParams struct {
+	ProductID pgtype.UUID
+	Status    string
+}
+
+type SqGetProductTransitionsParams struct {
+	ProductID pgtype.UUID
+	Limit     uint64
+	Offset    uint64
+}
+
+type SqSetProductScheduleParams struct {
+	ProductID   pgtype.UUID
+	PublishAt   pgtype.Timestamp
+	UnpublishAt pgtype.Timestamp
+}
+
+// SqScheduledTransitionsParams moves products in any of From whose time in
+// Column has come to To.
+type SqScheduledTransitionsParams struct {
+	Column string
+	From   []string
+	To     string
+	Actor  string
+	Reason string
+	Limit  uint64
+}
+
+// SqLockProduct locks a product that is not soft-deleted and returns its
+// status.
+func (q *RepoQueries) SqLockProduct(
+	ctx context.Context,
+	productID pgtype.UUID,
+) (string, error) {
+	var status string
+	err := q.db.QueryRow(
+		ctx,
+		"SELECT status FROM products WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE",
+		productID,
+	).Scan(&status)
+	return status, err
+}
+
+// SqSetProductStatus moves a product to the status. Publishing drops a
+// pending publish time, leaving published a pending unpublish time.
+func (q *RepoQueries) SqSetProductStatus(
+	ctx context.Context,
+	params SqSetProductStatusParams,
+) error {
+	query := sq.Update(ProductsTable).
+		Set("status", params.Status).
+		Set("unpublish_at", sq.Expr("CASE WHEN status = 'published' THEN NULL ELSE unpublish_at END")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ProductID}).
+		PlaceholderFormat(sq.Dollar)
+	if params.Status == "published" {
+		query = query.Set("publish_at", nil)
+	}
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("sq set product status build query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, sqlString, args...)
+	return err
+}
+
+func (q *RepoQueries) SqCreateTransition(
+	ctx context.Context,
+	params SqTransitionRow,
+) (*SqTransitionRow, error) {
+	query, args, err := sq.Insert(ProductTransitionsTable).
+		Columns("product_id", "from_status", "to_status", "actor", "reason").
+		Values(params.ProductID, params.FromStatus, params.ToStatus, params.Actor, params.Reason).
+		Suffix(transitionReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq create transition build query error: %w", err)
+	}
+	var i SqTransitionRow
+	err = q.db.QueryRow(ctx, query, args...).Scan(i.scanDest()...)
+	return &i, err
+}
+
+// SqGetProductTransitions lists the transitions of a product, the latest
+// first.
+func (q *RepoQueries) SqGetProductTransitions(
+	ctx context.Context,
+	params SqGetProductTransitionsParams,
+) ([]SqTransitionRow, error) {
+	query, args, err := sq.Select("id", "product_id", "from_status", "to_status", "actor", "reason", "created_at").
+		From(ProductTransitionsTable).
+		Where(sq.Eq{"product_id": params.ProductID}).
+		OrderBy("created_at DESC", "id").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get product transitions build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransitions(rows)
+}
+
+func (q *RepoQueries) SqSetProductSchedule(
+	ctx context.Context,
+	params SqSetProductScheduleParams,
+) (*SqScheduleRow, error) {
+	query, args, err := sq.Update(ProductsTable).
+		Set("publish_at", params.PublishAt).
+		Set("unpublish_at", params.UnpublishAt).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ProductID, "deleted_at": nil}).
+		Suffix("RETURNING id, status, publish_at, unpublish_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq set product schedule build query error: %w", err)
+	}
+	var i SqScheduleRow
+	err = q.db.QueryRow(ctx, query, args...).Scan(
+		&i.ProductID,
+		&i.Status,
+		&i.PublishAt,
+		&i.UnpublishAt,
+	)
+	return &i, err
+}
+
+// SqRunScheduledTransitions moves up to Limit due products and records
+// their transitions in one statement. Products locked by a running
+// transition are skipped and left to the next run.
+func (q *RepoQueries) SqRunScheduledTransitions(
+	ctx context.Context,
+	params SqScheduledTransitionsParams,
+) ([]SqTransitionRow, error) {
+	query, args, err := buildRunScheduledTransitionsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq run scheduled transitions build query error: %w", err)
+	}
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTransitions(rows)
+}
+
+func buildRunScheduledTransitionsQuery(
+	params SqScheduledTransitionsParams,
+) (string, []interface{}, error) {
+	due := sq.Select("id", "status").
+		From(ProductsTable).
+		Where(sq.Eq{"deleted_at": nil}).
+		Where(params.Column + " <= NOW()").
+		Where(sq.Expr("status = ANY(?::text[])", params.From)).
+		OrderBy(params.Column).
+		Limit(params.Limit).
+		Suffix("FOR NO KEY UPDATE SKIP LOCKED")
+	dueSQL, args, err := due.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	query := sq.Insert(ProductTransitionsTable).
+		Columns("product_id", "from_status", "to_status", "actor", "reason").
+		Select(sq.Select("id", "from_status").
+			Column("?::text", params.To).
+			Column("?::text", params.Actor).
+			Column("?::text", params.Reason).
+			From("moved")).
+		Prefix(
+			"WITH due AS ("+dueSQL+"), "+
+				"moved AS (UPDATE "+ProductsTable+" SET status = ?, "+params.Column+" = NULL, updated_at = NOW() "+
+				"FROM due WHERE "+ProductsTable+".id = due.id RETURNING "+ProductsTable+".id, due.status AS from_status)",
+			append(args, params.To)...,
+		).
+		Suffix(transitionReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return sqlString, args, nil
+}
+
+// scanDest returns scan targets in the order of transitionReturning.
+func (i *SqTransitionRow) scanDest() []interface{} {
+	return []interface{}{
+		&i.ID,
+		&i.ProductID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Actor,
+		&i.Reason,
+		&i.CreatedAt,
+	}
+}
+
+func scanTransitions(rows pgx.Rows) ([]SqTransitionRow, error) {
+	defer rows.Close()
+	var items []SqTransitionRow
+	for rows.Next() {
+		var i SqTransitionRow
+		if err := rows.Scan(i.scanDest()...); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
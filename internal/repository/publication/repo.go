@@ -0,0 +1,40 @@
+package publication
+
+import (
+	"context"
+	"go_template_project/internal/repository/dbtx"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RepoQueries runs writes on db and reads on readDB, which may be a replica.
+type RepoQueries struct {
+	db     DBTX
+	readDB DBTX
+}
+
+type Repository struct {
+	conn    Connect
+	queries RepoQueries
+}
+
+func NewPublicationRepository(conn Connect, readDB DBTX) *Repository {
+	return &Repository{
+		conn:    conn,
+		queries: *New(conn, readDB),
+	}
+}
+
+func New(db, readDB DBTX) *RepoQueries {
+	return &RepoQueries{db: db, readDB: readDB}
+}
+
+// WithTx returns queries running reads and writes in tx.
+func (q *RepoQueries) WithTx(tx pgx.Tx) *RepoQueries {
+	return &RepoQueries{db: tx, readDB: tx}
+}
+
+// inTx runs fn in a transaction, committed if fn returns nil.
+func (r *Repository) inTx(ctx context.Context, fn func(q *RepoQueries) error) error {
+	return dbtx.InTx(ctx, r.conn, r.queries.WithTx, fn)
+}
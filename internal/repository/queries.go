@@ -2,17 +2,54 @@ package repository
 
 import (
 	"context"
+	"github.com/google/uuid"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	mediaDomain "go_template_project/internal/domain/media"
+	pricesDomain "go_template_project/internal/domain/prices"
 	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	variantsDomain "go_template_project/internal/domain/variants"
 )
 
 func (r *Repository) GetProducts(ctx context.Context, data productsDomain.GetProductsDTO) ([]productsDomain.Product, error) {
 	return r.productsRepo.GetProducts(ctx, data)
 }
 
+func (r *Repository) GetProductsStats(
+	ctx context.Context,
+	data productsDomain.GetProductsDTO,
+) (*productsDomain.ProductsStats, error) {
+	return r.productsRepo.GetProductsStats(ctx, data)
+}
+
+func (r *Repository) GetProductsFacets(
+	ctx context.Context,
+	data productsDomain.GetProductsFacetsDTO,
+) (*productsDomain.ProductsFacets, error) {
+	return r.productsRepo.GetProductsFacets(ctx, data)
+}
+
 func (r *Repository) GetProduct(ctx context.Context, data productsDomain.GetProductDTO) (*productsDomain.Product, error) {
 	return r.productsRepo.GetProduct(ctx, data)
 }
 
+func (r *Repository) GetProductsByIDs(
+	ctx context.Context,
+	data productsDomain.GetProductsByIDsDTO,
+) ([]productsDomain.Product, error) {
+	return r.productsRepo.GetProductsByIDs(ctx, data)
+}
+
+func (r *Repository) ExportProducts(
+	ctx context.Context,
+	data productsDomain.ExportProductsDTO,
+	fn func(productsDomain.Product) error,
+) error {
+	return r.productsRepo.ExportProducts(ctx, data, fn)
+}
+
 func (r *Repository) CreateProduct(ctx context.Context, data productsDomain.CreateProductDTO) (*productsDomain.Product, error) {
 	return r.productsRepo.CreateProduct(ctx, data)
 }
@@ -31,6 +68,13 @@ func (r *Repository) DeleteProduct(
 	return r.productsRepo.DeleteProduct(ctx, data)
 }
 
+func (r *Repository) PurgeDeletedProducts(
+	ctx context.Context,
+	data productsDomain.PurgeDeletedProductsDTO,
+) ([]uuid.UUID, error) {
+	return r.productsRepo.PurgeDeletedProducts(ctx, data)
+}
+
 func (r *Repository) BulkCreateProducts(
 	ctx context.Context,
 	data []productsDomain.Product,
@@ -45,3 +89,402 @@ func (r *Repository) BulkUpdateProducts(
 ) ([]productsDomain.Product, error) {
 	return r.productsRepo.BulkUpdateProducts(ctx, updateFields, data)
 }
+
+func (r *Repository) GetProductTranslations(
+	ctx context.Context,
+	data productsDomain.GetProductTranslationsDTO,
+) ([]productsDomain.ProductTranslation, error) {
+	return r.productsRepo.GetProductTranslations(ctx, data)
+}
+
+func (r *Repository) GetMissingTranslations(
+	ctx context.Context,
+	data productsDomain.GetMissingTranslationsDTO,
+) ([]productsDomain.MissingTranslation, error) {
+	return r.productsRepo.GetMissingTranslations(ctx, data)
+}
+
+func (r *Repository) GetTagCounts(
+	ctx context.Context,
+	data tagsDomain.GetTagCountsDTO,
+) ([]tagsDomain.TagCount, error) {
+	return r.productsRepo.GetTagCounts(ctx, data)
+}
+
+func (r *Repository) GetCategories(
+	ctx context.Context,
+	data categoriesDomain.GetCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	return r.categoriesRepo.GetCategories(ctx, data)
+}
+
+func (r *Repository) GetCategory(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	return r.categoriesRepo.GetCategory(ctx, data)
+}
+
+func (r *Repository) GetCategoryBreadcrumbs(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryDTO,
+) ([]categoriesDomain.Category, error) {
+	return r.categoriesRepo.GetCategoryBreadcrumbs(ctx, data)
+}
+
+func (r *Repository) CreateCategory(
+	ctx context.Context,
+	data categoriesDomain.CreateCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	return r.categoriesRepo.CreateCategory(ctx, data)
+}
+
+func (r *Repository) PartialUpdateCategory(
+	ctx context.Context,
+	data categoriesDomain.PartialUpdateCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	return r.categoriesRepo.PartialUpdateCategory(ctx, data)
+}
+
+func (r *Repository) DeleteCategory(
+	ctx context.Context,
+	data categoriesDomain.DeleteCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	return r.categoriesRepo.DeleteCategory(ctx, data)
+}
+
+func (r *Repository) MoveCategory(
+	ctx context.Context,
+	data categoriesDomain.MoveCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	return r.categoriesRepo.MoveCategory(ctx, data)
+}
+
+func (r *Repository) ReorderCategories(
+	ctx context.Context,
+	data categoriesDomain.ReorderCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	return r.categoriesRepo.ReorderCategories(ctx, data)
+}
+
+func (r *Repository) GetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.GetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	return r.categoriesRepo.GetProductCategories(ctx, data)
+}
+
+func (r *Repository) GetProductsCategories(
+	ctx context.Context,
+	data categoriesDomain.GetProductsCategoriesDTO,
+) (map[uuid.UUID][]categoriesDomain.Category, error) {
+	return r.categoriesRepo.GetProductsCategories(ctx, data)
+}
+
+func (r *Repository) SetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.SetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	return r.categoriesRepo.SetProductCategories(ctx, data)
+}
+
+func (r *Repository) GetProductPrices(
+	ctx context.Context,
+	data pricesDomain.GetProductPricesDTO,
+) ([]pricesDomain.Price, error) {
+	return r.pricesRepo.GetProductPrices(ctx, data)
+}
+
+func (r *Repository) GetProductsPrices(
+	ctx context.Context,
+	data pricesDomain.GetProductsPricesDTO,
+) (map[uuid.UUID][]pricesDomain.Price, error) {
+	return r.pricesRepo.GetProductsPrices(ctx, data)
+}
+
+func (r *Repository) GetProductPriceHistory(
+	ctx context.Context,
+	data pricesDomain.GetProductPriceHistoryDTO,
+) ([]pricesDomain.PriceChange, error) {
+	return r.pricesRepo.GetProductPriceHistory(ctx, data)
+}
+
+func (r *Repository) SetProductPrice(
+	ctx context.Context,
+	data pricesDomain.SetProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	return r.pricesRepo.SetProductPrice(ctx, data)
+}
+
+func (r *Repository) DeleteProductPrice(
+	ctx context.Context,
+	data pricesDomain.DeleteProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	return r.pricesRepo.DeleteProductPrice(ctx, data)
+}
+
+func (r *Repository) GetOptionTypes(
+	ctx context.Context,
+	data variantsDomain.GetOptionTypesDTO,
+) ([]variantsDomain.OptionType, error) {
+	return r.variantsRepo.GetOptionTypes(ctx, data)
+}
+
+func (r *Repository) CreateOptionType(
+	ctx context.Context,
+	data variantsDomain.CreateOptionTypeDTO,
+) (*variantsDomain.OptionType, error) {
+	return r.variantsRepo.CreateOptionType(ctx, data)
+}
+
+func (r *Repository) GetVariants(
+	ctx context.Context,
+	data variantsDomain.GetVariantsDTO,
+) ([]variantsDomain.Variant, error) {
+	return r.variantsRepo.GetVariants(ctx, data)
+}
+
+func (r *Repository) GetProductsVariants(
+	ctx context.Context,
+	data variantsDomain.GetProductsVariantsDTO,
+) (map[uuid.UUID][]variantsDomain.Variant, error) {
+	return r.variantsRepo.GetProductsVariants(ctx, data)
+}
+
+func (r *Repository) GetVariant(
+	ctx context.Context,
+	data variantsDomain.GetVariantDTO,
+) (*variantsDomain.Variant, error) {
+	return r.variantsRepo.GetVariant(ctx, data)
+}
+
+func (r *Repository) CreateVariant(
+	ctx context.Context,
+	data variantsDomain.CreateVariantDTO,
+) (*variantsDomain.Variant, error) {
+	return r.variantsRepo.CreateVariant(ctx, data)
+}
+
+func (r *Repository) DeleteVariant(
+	ctx context.Context,
+	data variantsDomain.DeleteVariantDTO,
+) (*variantsDomain.Variant, error) {
+	return r.variantsRepo.DeleteVariant(ctx, data)
+}
+
+func (r *Repository) TagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	return r.tagsRepo.TagProducts(ctx, data)
+}
+
+func (r *Repository) UntagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	return r.tagsRepo.UntagProducts(ctx, data)
+}
+
+func (r *Repository) AutocompleteTags(
+	ctx context.Context,
+	data tagsDomain.AutocompleteTagsDTO,
+) ([]tagsDomain.TagCount, error) {
+	return r.tagsRepo.AutocompleteTags(ctx, data)
+}
+
+func (r *Repository) GetProductsTags(
+	ctx context.Context,
+	data tagsDomain.GetProductsTagsDTO,
+) (map[uuid.UUID][]string, error) {
+	return r.tagsRepo.GetProductsTags(ctx, data)
+}
+
+func (r *Repository) TransitionProduct(
+	ctx context.Context,
+	data productsDomain.TransitionProductDTO,
+) (*productsDomain.ProductTransition, error) {
+	return r.publicationRepo.TransitionProduct(ctx, data)
+}
+
+func (r *Repository) GetProductTransitions(
+	ctx context.Context,
+	data productsDomain.GetProductTransitionsDTO,
+) ([]productsDomain.ProductTransition, error) {
+	return r.publicationRepo.GetProductTransitions(ctx, data)
+}
+
+func (r *Repository) SetProductSchedule(
+	ctx context.Context,
+	data productsDomain.SetProductScheduleDTO,
+) (*productsDomain.ProductSchedule, error) {
+	return r.publicationRepo.SetProductSchedule(ctx, data)
+}
+
+func (r *Repository) RunScheduledTransitions(
+	ctx context.Context,
+	data productsDomain.RunScheduledTransitionsDTO,
+) ([]productsDomain.ProductTransition, error) {
+	return r.publicationRepo.RunScheduledTransitions(ctx, data)
+}
+
+func (r *Repository) CreateMedia(
+	ctx context.Context,
+	data mediaDomain.CreateMediaDTO,
+) (*mediaDomain.Media, error) {
+	return r.mediaRepo.CreateMedia(ctx, data)
+}
+
+func (r *Repository) GetMedia(
+	ctx context.Context,
+	data mediaDomain.GetMediaDTO,
+) (*mediaDomain.Media, error) {
+	return r.mediaRepo.GetMedia(ctx, data)
+}
+
+func (r *Repository) GetProductMedia(
+	ctx context.Context,
+	data mediaDomain.GetProductMediaDTO,
+) ([]mediaDomain.Media, error) {
+	return r.mediaRepo.GetProductMedia(ctx, data)
+}
+
+func (r *Repository) GetProductsMedia(
+	ctx context.Context,
+	data mediaDomain.GetProductsMediaDTO,
+) (map[uuid.UUID][]mediaDomain.Media, error) {
+	return r.mediaRepo.GetProductsMedia(ctx, data)
+}
+
+func (r *Repository) SetMediaOrder(
+	ctx context.Context,
+	data mediaDomain.SetMediaOrderDTO,
+) ([]mediaDomain.Media, error) {
+	return r.mediaRepo.SetMediaOrder(ctx, data)
+}
+
+func (r *Repository) DeleteMedia(
+	ctx context.Context,
+	data mediaDomain.DeleteMediaDTO,
+) (*mediaDomain.Media, error) {
+	return r.mediaRepo.DeleteMedia(ctx, data)
+}
+
+func (r *Repository) SetThumbnails(
+	ctx context.Context,
+	data mediaDomain.SetThumbnailsDTO,
+) (*mediaDomain.Media, error) {
+	return r.mediaRepo.SetThumbnails(ctx, data)
+}
+
+func (r *Repository) GetOrphanedMedia(
+	ctx context.Context,
+	data mediaDomain.GetOrphanedMediaDTO,
+) ([]uuid.UUID, error) {
+	return r.mediaRepo.GetOrphanedMedia(ctx, data)
+}
+
+func (r *Repository) PurgeMedia(
+	ctx context.Context,
+	data mediaDomain.PurgeMediaDTO,
+) (int64, error) {
+	return r.mediaRepo.PurgeMedia(ctx, data)
+}
+
+func (r *Repository) GetWarehouses(
+	ctx context.Context,
+	data inventoryDomain.GetWarehousesDTO,
+) ([]inventoryDomain.Warehouse, error) {
+	return r.inventoryRepo.GetWarehouses(ctx, data)
+}
+
+func (r *Repository) CreateWarehouse(
+	ctx context.Context,
+	data inventoryDomain.CreateWarehouseDTO,
+) (*inventoryDomain.Warehouse, error) {
+	return r.inventoryRepo.CreateWarehouse(ctx, data)
+}
+
+func (r *Repository) GetProductStock(
+	ctx context.Context,
+	data inventoryDomain.GetProductStockDTO,
+) ([]inventoryDomain.StockLevel, error) {
+	return r.inventoryRepo.GetProductStock(ctx, data)
+}
+
+func (r *Repository) AdjustStock(
+	ctx context.Context,
+	data inventoryDomain.AdjustStockDTO,
+) (*inventoryDomain.StockLevel, error) {
+	return r.inventoryRepo.AdjustStock(ctx, data)
+}
+
+func (r *Repository) GetStockAdjustments(
+	ctx context.Context,
+	data inventoryDomain.GetStockAdjustmentsDTO,
+) ([]inventoryDomain.Adjustment, error) {
+	return r.inventoryRepo.GetStockAdjustments(ctx, data)
+}
+
+func (r *Repository) ReserveStock(
+	ctx context.Context,
+	data inventoryDomain.ReserveStockDTO,
+) (*inventoryDomain.Reservation, error) {
+	return r.inventoryRepo.ReserveStock(ctx, data)
+}
+
+func (r *Repository) GetReservation(
+	ctx context.Context,
+	data inventoryDomain.GetReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	return r.inventoryRepo.GetReservation(ctx, data)
+}
+
+func (r *Repository) ReleaseReservation(
+	ctx context.Context,
+	data inventoryDomain.ReleaseReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	return r.inventoryRepo.ReleaseReservation(ctx, data)
+}
+
+func (r *Repository) CommitReservation(
+	ctx context.Context,
+	data inventoryDomain.CommitReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	return r.inventoryRepo.CommitReservation(ctx, data)
+}
+
+func (r *Repository) ExpireReservations(
+	ctx context.Context,
+	data inventoryDomain.ExpireReservationsDTO,
+) (int64, error) {
+	return r.inventoryRepo.ExpireReservations(ctx, data)
+}
+
+func (r *Repository) EnqueueJob(ctx context.Context, data jobsDomain.EnqueueJobDTO) (*jobsDomain.Job, error) {
+	return r.jobsRepo.EnqueueJob(ctx, data)
+}
+
+func (r *Repository) GetJob(ctx context.Context, data jobsDomain.GetJobDTO) (*jobsDomain.Job, error) {
+	return r.jobsRepo.GetJob(ctx, data)
+}
+
+func (r *Repository) GetJobArtifact(ctx context.Context, data jobsDomain.GetJobDTO) (*jobsDomain.Artifact, error) {
+	return r.jobsRepo.GetJobArtifact(ctx, data)
+}
+
+func (r *Repository) ClaimJob(ctx context.Context, data jobsDomain.ClaimJobDTO) (*jobsDomain.Job, error) {
+	return r.jobsRepo.ClaimJob(ctx, data)
+}
+
+func (r *Repository) HeartbeatJob(ctx context.Context, data jobsDomain.HeartbeatJobDTO) (*jobsDomain.Job, error) {
+	return r.jobsRepo.HeartbeatJob(ctx, data)
+}
+
+func (r *Repository) FinishJob(ctx context.Context, data jobsDomain.FinishJobDTO) (*jobsDomain.Job, error) {
+	return r.jobsRepo.FinishJob(ctx, data)
+}
+
+func (r *Repository) CancelJob(ctx context.Context, data jobsDomain.CancelJobDTO) (*jobsDomain.Job, error) {
+	return r.jobsRepo.CancelJob(ctx, data)
+}
@@ -0,0 +1,185 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"slices"
+)
+
+// TagProducts creates the tags that do not exist yet and adds them to the
+// products. Products that changed get a new updated_at.
+func (r *Repository) TagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	productIDs := convertIDs(data.ProductIDs)
+	names := slices.Sorted(slices.Values(data.Tags))
+
+	var (
+		existing []pgtype.UUID
+		sqTags   []SqTagRow
+		changed  []pgtype.UUID
+	)
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		var err error
+		if existing, err = q.SqLockProducts(ctx, productIDs); err != nil {
+			return err
+		}
+		if err = q.SqCreateTags(ctx, names); err != nil {
+			return err
+		}
+		if sqTags, err = q.SqGetTagsByNames(ctx, names); err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return nil
+		}
+		changed, err = q.SqAddProductTags(ctx, SqProductTagsParams{
+			ProductIDs: existing,
+			TagIDs:     tagIDs(sqTags),
+		})
+		if err != nil || len(changed) == 0 {
+			return err
+		}
+		return q.SqTouchProducts(ctx, changed)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sq tag products error: %w", err)
+	}
+	return &tagsDomain.TagProductsResult{
+		Tags:    convertTags(sqTags),
+		Changed: int64(len(changed)),
+		Missing: missingIDs(data.ProductIDs, existing),
+	}, nil
+}
+
+// UntagProducts removes the tags from the products, tags nobody carries
+// any more are kept for autocompletion. Products that changed get a new
+// updated_at.
+func (r *Repository) UntagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	productIDs := convertIDs(data.ProductIDs)
+
+	var (
+		existing []pgtype.UUID
+		sqTags   []SqTagRow
+		changed  []pgtype.UUID
+	)
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		var err error
+		if existing, err = q.SqLockProducts(ctx, productIDs); err != nil {
+			return err
+		}
+		if sqTags, err = q.SqGetTagsByNames(ctx, data.Tags); err != nil {
+			return err
+		}
+		if len(existing) == 0 || len(sqTags) == 0 {
+			return nil
+		}
+		changed, err = q.SqRemoveProductTags(ctx, SqProductTagsParams{
+			ProductIDs: existing,
+			TagIDs:     tagIDs(sqTags),
+		})
+		if err != nil || len(changed) == 0 {
+			return err
+		}
+		return q.SqTouchProducts(ctx, changed)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sq untag products error: %w", err)
+	}
+	return &tagsDomain.TagProductsResult{
+		Tags:    convertTags(sqTags),
+		Changed: int64(len(changed)),
+		Missing: missingIDs(data.ProductIDs, existing),
+	}, nil
+}
+
+func (r *Repository) AutocompleteTags(
+	ctx context.Context,
+	data tagsDomain.AutocompleteTagsDTO,
+) ([]tagsDomain.TagCount, error) {
+	params := SqAutocompleteTagsParams{
+		Prefix: data.Prefix,
+		Limit:  uint64(data.Limit),
+	}
+	sqCounts, err := r.queries.SqAutocompleteTags(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq autocomplete tags error: %w", err)
+	}
+	counts := make([]tagsDomain.TagCount, 0, len(sqCounts))
+	for _, sqCount := range sqCounts {
+		counts = append(counts, tagsDomain.TagCount{
+			ID:    sqCount.ID.Bytes,
+			Name:  sqCount.Name,
+			Count: sqCount.Count,
+		})
+	}
+	return counts, nil
+}
+
+// GetProductsTags groups the tag names by product, products without tags
+// are left out.
+func (r *Repository) GetProductsTags(
+	ctx context.Context,
+	data tagsDomain.GetProductsTagsDTO,
+) (map[uuid.UUID][]string, error) {
+	sqTags, err := r.queries.SqGetProductsTags(ctx, convertIDs(data.ProductIDs))
+	if err != nil {
+		return nil, fmt.Errorf("sq get products tags error: %w", err)
+	}
+	tags := make(map[uuid.UUID][]string)
+	for _, sqTag := range sqTags {
+		productID := uuid.UUID(sqTag.ProductID.Bytes)
+		tags[productID] = append(tags[productID], sqTag.Name)
+	}
+	return tags, nil
+}
+
+// missingIDs lists the ids, in request order, that are not in existing.
+func missingIDs(ids []uuid.UUID, existing []pgtype.UUID) []uuid.UUID {
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		found[id.Bytes] = true
+	}
+	missing := make([]uuid.UUID, 0)
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func convertIDs(ids []uuid.UUID) []pgtype.UUID {
+	converted := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		converted = append(converted, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	return converted
+}
+
+func tagIDs(sqTags []SqTagRow) []pgtype.UUID {
+	ids := make([]pgtype.UUID, 0, len(sqTags))
+	for _, sqTag := range sqTags {
+		ids = append(ids, sqTag.ID)
+	}
+	return ids
+}
+
+func convertTags(sqTags []SqTagRow) []tagsDomain.Tag {
+	tags := make([]tagsDomain.Tag, 0, len(sqTags))
+	for _, sqTag := range sqTags {
+		tags = append(tags, tagsDomain.Tag{
+			ID:        sqTag.ID.Bytes,
+			Name:      sqTag.Name,
+			CreatedAt: sqTag.CreatedAt.Time,
+		})
+	}
+	return tags
+}
@@ -0,0 +1,262 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	TagsTable        = "tags"
+	ProductTagsTable = "product_tags"
+)
+
+type SqTagRow struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamp
+}
+
+type SqTagCountRow struct {
+	ID    pgtype.UUID
+	Name  string
+	Count int64
+}
+
+type SqProductTagsParams struct {
+	ProductIDs []pgtype.UUID
+	TagIDs     []pgtype.UUID
+}
+
+// SqProductTagRow is the name of a tag a product carries.
+type SqProductTagRow struct {
+	ProductID pgtype.UUID
+	Name      string
+}
+
+type SqAutocompleteTagsParams struct {
+	Prefix string
+	Limit  uint64
+}
+
+// SqLockProducts locks the products that exist and are not soft-deleted,
+// in id order so concurrent bulk changes can not deadlock, and returns
+// their ids.
+func (q *RepoQueries) SqLockProducts(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(
+		ctx,
+		"SELECT id FROM products WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR NO KEY UPDATE",
+		productIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// SqCreateTags adds the tags not created yet. Names are expected sorted, so
+// concurrent inserts of the same new tags wait on each other in order.
+func (q *RepoQueries) SqCreateTags(
+	ctx context.Context,
+	names []string,
+) error {
+	query, args, err := sq.Insert(TagsTable).
+		Columns("name").
+		Select(sq.Select().Column("unnest(?::text[])", names)).
+		Suffix("ON CONFLICT (name) DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("sq create tags build query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, query, args...)
+	return err
+}
+
+func (q *RepoQueries) SqGetTagsByNames(
+	ctx context.Context,
+	names []string,
+) ([]SqTagRow, error) {
+	query, args, err := sq.Select("id", "name", "created_at").
+		From(TagsTable).
+		Where(sq.Expr("name = ANY(?::text[])", names)).
+		OrderBy("name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get tags by names build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqTagRow
+	for rows.Next() {
+		var i SqTagRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SqAddProductTags tags every product with every tag and returns the
+// products that got a tag they did not carry yet.
+func (q *RepoQueries) SqAddProductTags(
+	ctx context.Context,
+	params SqProductTagsParams,
+) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(
+		ctx,
+		"WITH added AS ("+
+			"INSERT INTO "+ProductTagsTable+" (product_id, tag_id) "+
+			"SELECT p.id, t.id FROM unnest($1::uuid[]) AS p(id) CROSS JOIN unnest($2::uuid[]) AS t(id) "+
+			"ON CONFLICT DO NOTHING RETURNING product_id"+
+			") SELECT DISTINCT product_id FROM added",
+		params.ProductIDs,
+		params.TagIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// SqRemoveProductTags removes the tags from every product and returns the
+// products that carried any of them.
+func (q *RepoQueries) SqRemoveProductTags(
+	ctx context.Context,
+	params SqProductTagsParams,
+) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(
+		ctx,
+		"WITH removed AS ("+
+			"DELETE FROM "+ProductTagsTable+" WHERE product_id = ANY($1) AND tag_id = ANY($2) RETURNING product_id"+
+			") SELECT DISTINCT product_id FROM removed",
+		params.ProductIDs,
+		params.TagIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// SqTouchProducts bumps updated_at of the products, so list validators
+// notice the tag change.
+func (q *RepoQueries) SqTouchProducts(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) error {
+	query, args, err := sq.Update("products").
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("id = ANY(?)", productIDs)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("sq touch products build query error: %w", err)
+	}
+	_, err = q.db.Exec(ctx, query, args...)
+	return err
+}
+
+func (q *RepoQueries) SqGetProductsTags(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) ([]SqProductTagRow, error) {
+	query, args, err := sq.Select("pt.product_id", "t.name").
+		From(ProductTagsTable+" AS pt").
+		Join(TagsTable+" AS t ON t.id = pt.tag_id").
+		Where(sq.Expr("pt.product_id = ANY(?)", productIDs)).
+		OrderBy("pt.product_id", "t.name").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get products tags build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqProductTagRow
+	for rows.Next() {
+		var i SqProductTagRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SqAutocompleteTags finds tags starting with the prefix, ordered by the
+// number of products carrying them. Soft-deleted products are not counted.
+func (q *RepoQueries) SqAutocompleteTags(
+	ctx context.Context,
+	params SqAutocompleteTagsParams,
+) ([]SqTagCountRow, error) {
+	query, args, err := buildAutocompleteTagsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq autocomplete tags build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqTagCountRow
+	for rows.Next() {
+		var i SqTagCountRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func buildAutocompleteTagsQuery(
+	params SqAutocompleteTagsParams,
+) (string, []interface{}, error) {
+	query := sq.Select("t.id", "t.name", "COUNT(p.id)").
+		From(TagsTable+" AS t").
+		LeftJoin(ProductTagsTable+" AS pt ON pt.tag_id = t.id").
+		LeftJoin("products AS p ON p.id = pt.product_id AND p.status = 'published' AND p.deleted_at IS NULL").
+		Where(sq.Like{"t.name": escapeLike(params.Prefix) + "%"}).
+		GroupBy("t.id", "t.name").
+		OrderBy("COUNT(p.id) DESC", "t.name").
+		Limit(params.Limit).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq autocomplete tags query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
@@ -0,0 +1,241 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"go_template_project/internal/repository/dbtx"
+)
+
+func (r *Repository) GetOptionTypes(
+	ctx context.Context,
+	data variantsDomain.GetOptionTypesDTO,
+) ([]variantsDomain.OptionType, error) {
+	sqOptionTypes, err := r.queries.SqGetOptionTypes(ctx, pgtype.UUID{Bytes: data.ProductID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("sq get option types error: %w", err)
+	}
+	optionTypes := make([]variantsDomain.OptionType, 0, len(sqOptionTypes))
+	for i := range sqOptionTypes {
+		optionTypes = append(optionTypes, *convertOptionType(&sqOptionTypes[i]))
+	}
+	return optionTypes, nil
+}
+
+// CreateOptionType adds an option type to a product without variants, every
+// variant has to pick a value of every option type.
+func (r *Repository) CreateOptionType(
+	ctx context.Context,
+	data variantsDomain.CreateOptionTypeDTO,
+) (*variantsDomain.OptionType, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+
+	var sqOptionType *SqOptionTypeRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockProduct(ctx, productID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+		hasVariants, err := q.SqHasVariants(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if hasVariants {
+			return variantsDomain.ErrOptionTypesInUse
+		}
+
+		sqOptionType, err = q.SqCreateOptionType(ctx, SqCreateOptionTypeParams{
+			ProductID: productID,
+			Name:      data.Name,
+			Values:    data.Values,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, variantError("sq create option type error", err)
+	}
+	return convertOptionType(sqOptionType), nil
+}
+
+func (r *Repository) GetVariants(
+	ctx context.Context,
+	data variantsDomain.GetVariantsDTO,
+) ([]variantsDomain.Variant, error) {
+	params := SqGetVariantsParams{
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+		Limit:     uint64(data.Limit),
+		Offset:    uint64(data.Offset),
+	}
+	sqVariants, err := r.queries.SqGetVariants(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get variants error: %w", err)
+	}
+	variants := make([]variantsDomain.Variant, 0, len(sqVariants))
+	for i := range sqVariants {
+		variants = append(variants, *convertVariant(&sqVariants[i]))
+	}
+	return variants, nil
+}
+
+// GetProductsVariants groups the variants by product, products without
+// variants are left out.
+func (r *Repository) GetProductsVariants(
+	ctx context.Context,
+	data variantsDomain.GetProductsVariantsDTO,
+) (map[uuid.UUID][]variantsDomain.Variant, error) {
+	productIDs := make([]pgtype.UUID, 0, len(data.ProductIDs))
+	for _, id := range data.ProductIDs {
+		productIDs = append(productIDs, pgtype.UUID{Bytes: id, Valid: true})
+	}
+	sqVariants, err := r.queries.SqGetProductsVariants(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sq get products variants error: %w", err)
+	}
+	variants := make(map[uuid.UUID][]variantsDomain.Variant)
+	for i := range sqVariants {
+		variant := convertVariant(&sqVariants[i])
+		variants[variant.ProductID] = append(variants[variant.ProductID], *variant)
+	}
+	return variants, nil
+}
+
+func (r *Repository) GetVariant(
+	ctx context.Context,
+	data variantsDomain.GetVariantDTO,
+) (*variantsDomain.Variant, error) {
+	params := SqGetVariantParams{
+		ID:        pgtype.UUID{Bytes: data.ID, Valid: true},
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+	}
+	sqVariant, err := r.queries.SqGetVariant(ctx, params)
+	if err != nil {
+		return nil, variantError("sq get variant error", err)
+	}
+	return convertVariant(sqVariant), nil
+}
+
+// CreateVariant checks the options against the option types of the product
+// while holding its lock, so option types can not change in between. SKU
+// and options uniqueness is left to the database.
+func (r *Repository) CreateVariant(
+	ctx context.Context,
+	data variantsDomain.CreateVariantDTO,
+) (*variantsDomain.Variant, error) {
+	productID := pgtype.UUID{Bytes: data.ProductID, Valid: true}
+	params := SqCreateVariantParams{
+		ProductID:  productID,
+		SKU:        data.SKU,
+		Options:    data.Options,
+		Attributes: data.Attributes,
+	}
+	if params.Options == nil {
+		params.Options = map[string]string{}
+	}
+	if params.Attributes == nil {
+		params.Attributes = map[string]any{}
+	}
+
+	var sqVariant *SqVariantRow
+	err := r.inTx(ctx, func(q *RepoQueries) error {
+		if err := q.SqLockProduct(ctx, productID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return productsDomain.ErrProductNotFound
+			}
+			return err
+		}
+		sqOptionTypes, err := q.SqGetOptionTypes(ctx, productID)
+		if err != nil {
+			return err
+		}
+		optionTypes := make([]variantsDomain.OptionType, 0, len(sqOptionTypes))
+		for i := range sqOptionTypes {
+			optionTypes = append(optionTypes, *convertOptionType(&sqOptionTypes[i]))
+		}
+		if err = variantsDomain.CheckOptions(optionTypes, params.Options); err != nil {
+			return err
+		}
+
+		sqVariant, err = q.SqCreateVariant(ctx, params)
+		return err
+	})
+	if err != nil {
+		return nil, variantError("sq create variant error", err)
+	}
+	return convertVariant(sqVariant), nil
+}
+
+func (r *Repository) DeleteVariant(
+	ctx context.Context,
+	data variantsDomain.DeleteVariantDTO,
+) (*variantsDomain.Variant, error) {
+	params := SqDeleteVariantParams{
+		ID:        pgtype.UUID{Bytes: data.ID, Valid: true},
+		ProductID: pgtype.UUID{Bytes: data.ProductID, Valid: true},
+	}
+	sqVariant, err := r.queries.SqDeleteVariant(ctx, params)
+	if err != nil {
+		return nil, variantError("sq delete variant error", err)
+	}
+	return convertVariant(sqVariant), nil
+}
+
+// variantError maps database errors to domain errors, wrapping anything
+// unexpected with msg.
+func variantError(msg string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return variantsDomain.ErrVariantNotFound
+	}
+	if constraint, ok := dbtx.ConstraintViolation(err, dbtx.UniqueViolation); ok {
+		switch constraint {
+		case skuConstraint:
+			return variantsDomain.ErrSKUTaken
+		case optionsConstraint:
+			return variantsDomain.ErrOptionsTaken
+		case optionTypeNameConstraint:
+			return variantsDomain.ErrOptionTypeTaken
+		}
+	}
+	if _, ok := dbtx.ConstraintViolation(err, dbtx.ForeignKeyViolation); ok {
+		return productsDomain.ErrProductNotFound
+	}
+	for _, domainErr := range []error{
+		productsDomain.ErrProductNotFound,
+		variantsDomain.ErrInvalidOptions,
+		variantsDomain.ErrOptionTypesInUse,
+	} {
+		if errors.Is(err, domainErr) {
+			return domainErr
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func convertOptionType(sqOptionType *SqOptionTypeRow) *variantsDomain.OptionType {
+	return &variantsDomain.OptionType{
+		ID:        sqOptionType.ID.Bytes,
+		ProductID: sqOptionType.ProductID.Bytes,
+		Name:      sqOptionType.Name,
+		Values:    sqOptionType.Values,
+		Position:  sqOptionType.Position,
+		CreatedAt: sqOptionType.CreatedAt.Time,
+	}
+}
+
+func convertVariant(sqVariant *SqVariantRow) *variantsDomain.Variant {
+	return &variantsDomain.Variant{
+		ID:         sqVariant.ID.Bytes,
+		ProductID:  sqVariant.ProductID.Bytes,
+		SKU:        sqVariant.SKU,
+		Options:    sqVariant.Options,
+		Attributes: sqVariant.Attributes,
+		CreatedAt:  sqVariant.CreatedAt.Time,
+		UpdatedAt:  sqVariant.UpdatedAt.Time,
+	}
+}
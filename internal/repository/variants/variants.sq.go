@@ -0,0 +1,338 @@
+package variants
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	OptionTypesTable = "product_option_types"
+	VariantsTable    = "product_variants"
+)
+
+// Names of the unique constraints, used to tell the conflicts apart.
+const (
+	optionTypeNameConstraint = "product_option_types_product_id_name_key"
+	skuConstraint            = "ux_product_variants_sku"
+	optionsConstraint        = "ux_product_variants_options"
+)
+
+var optionTypeColumns = []string{
+	"id",
+	"product_id",
+	"name",
+	"allowed_values",
+	"position",
+	"created_at",
+}
+
+var variantColumns = []string{
+	"id",
+	"product_id",
+	"sku",
+	"options",
+	"attributes",
+	"created_at",
+	"updated_at",
+}
+
+var (
+	optionTypeReturning = "RETURNING " + joinColumns(optionTypeColumns)
+	variantReturning    = "RETURNING " + joinColumns(variantColumns)
+)
+
+type SqOptionTypeRow struct {
+	ID        pgtype.UUID
+	ProductID pgtype.UUID
+	Name      string
+	Values    []string
+	Position  int32
+	CreatedAt pgtype.Timestamp
+}
+
+type SqVariantRow struct {
+	ID         pgtype.UUID
+	ProductID  pgtype.UUID
+	SKU        string
+	Options    map[string]string
+	Attributes map[string]any
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+}
+
+type SqCreateOptionTypeParams struct {
+	ProductID pgtype.UUID
+	Name      string
+	Values    []string
+}
+
+type SqGetVariantsParams struct {
+	ProductID pgtype.UUID
+	Limit     uint64
+	Offset    uint64
+}
+
+type SqGetVariantParams struct {
+	ID        pgtype.UUID
+	ProductID pgtype.UUID
+}
+
+type SqCreateVariantParams struct {
+	ProductID  pgtype.UUID
+	SKU        string
+	Options    map[string]string
+	Attributes map[string]any
+}
+
+type SqDeleteVariantParams struct {
+	ID        pgtype.UUID
+	ProductID pgtype.UUID
+}
+
+func scanOptionType(row pgx.Row) (*SqOptionTypeRow, error) {
+	var i SqOptionTypeRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Name,
+		&i.Values,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+func scanVariant(row pgx.Row) (*SqVariantRow, error) {
+	var i SqVariantRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.SKU,
+		&i.Options,
+		&i.Attributes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+// SqLockProduct locks a product that is not soft-deleted until the
+// transaction ends, serialising changes to its option types and variants.
+func (q *RepoQueries) SqLockProduct(
+	ctx context.Context,
+	productID pgtype.UUID,
+) error {
+	var id pgtype.UUID
+	return q.db.QueryRow(
+		ctx,
+		"SELECT id FROM products WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE",
+		productID,
+	).Scan(&id)
+}
+
+func (q *RepoQueries) SqGetOptionTypes(
+	ctx context.Context,
+	productID pgtype.UUID,
+) ([]SqOptionTypeRow, error) {
+	query, args, err := sq.Select(optionTypeColumns...).
+		From(OptionTypesTable).
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("position").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get option types build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqOptionTypeRow
+	for rows.Next() {
+		i, err := scanOptionType(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SqCreateOptionType appends the option type after the existing ones.
+func (q *RepoQueries) SqCreateOptionType(
+	ctx context.Context,
+	params SqCreateOptionTypeParams,
+) (*SqOptionTypeRow, error) {
+	query, args, err := buildCreateOptionTypeQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq create option type build query error: %w", err)
+	}
+	return scanOptionType(q.db.QueryRow(ctx, query, args...))
+}
+
+func buildCreateOptionTypeQuery(
+	params SqCreateOptionTypeParams,
+) (string, []interface{}, error) {
+	position := sq.Expr(
+		"(SELECT COALESCE(MAX(position) + 1, 0) FROM "+OptionTypesTable+" WHERE product_id = ?)",
+		params.ProductID,
+	)
+	query := sq.Insert(OptionTypesTable).
+		Columns("product_id", "name", "allowed_values", "position").
+		Values(params.ProductID, params.Name, params.Values, position).
+		Suffix(optionTypeReturning).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq create option type query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+// SqHasVariants reports whether the product has variants that are not
+// soft-deleted.
+func (q *RepoQueries) SqHasVariants(
+	ctx context.Context,
+	productID pgtype.UUID,
+) (bool, error) {
+	var exists bool
+	err := q.db.QueryRow(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM "+VariantsTable+" WHERE product_id = $1 AND deleted_at IS NULL)",
+		productID,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (q *RepoQueries) SqGetVariants(
+	ctx context.Context,
+	params SqGetVariantsParams,
+) ([]SqVariantRow, error) {
+	query, args, err := buildGetVariantsQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("sq get variants build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqVariantRow
+	for rows.Next() {
+		i, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func buildGetVariantsQuery(
+	params SqGetVariantsParams,
+) (string, []interface{}, error) {
+	query := sq.Select(variantColumns...).
+		From(VariantsTable).
+		Where(sq.Eq{"product_id": params.ProductID, "deleted_at": nil}).
+		OrderBy("created_at", "id").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		PlaceholderFormat(sq.Dollar)
+	sqlString, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, fmt.Errorf("sq get variants query to sql error: %w", err)
+	}
+	return sqlString, args, nil
+}
+
+func (q *RepoQueries) SqGetProductsVariants(
+	ctx context.Context,
+	productIDs []pgtype.UUID,
+) ([]SqVariantRow, error) {
+	query, args, err := sq.Select(variantColumns...).
+		From(VariantsTable).
+		Where(sq.Expr("product_id = ANY(?)", productIDs)).
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("product_id", "created_at", "id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get products variants build query error: %w", err)
+	}
+	rows, err := q.readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SqVariantRow
+	for rows.Next() {
+		i, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (q *RepoQueries) SqGetVariant(
+	ctx context.Context,
+	params SqGetVariantParams,
+) (*SqVariantRow, error) {
+	query, args, err := sq.Select(variantColumns...).
+		From(VariantsTable).
+		Where(sq.Eq{"id": params.ID, "product_id": params.ProductID, "deleted_at": nil}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq get variant build query error: %w", err)
+	}
+	return scanVariant(q.readDB.QueryRow(ctx, query, args...))
+}
+
+func (q *RepoQueries) SqCreateVariant(
+	ctx context.Context,
+	params SqCreateVariantParams,
+) (*SqVariantRow, error) {
+	query, args, err := sq.Insert(VariantsTable).
+		Columns("product_id", "sku", "options", "attributes").
+		Values(params.ProductID, params.SKU, params.Options, params.Attributes).
+		Suffix(variantReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq create variant build query error: %w", err)
+	}
+	return scanVariant(q.db.QueryRow(ctx, query, args...))
+}
+
+// SqDeleteVariant soft-deletes the variant, freeing its SKU and options.
+func (q *RepoQueries) SqDeleteVariant(
+	ctx context.Context,
+	params SqDeleteVariantParams,
+) (*SqVariantRow, error) {
+	query, args, err := sq.Update(VariantsTable).
+		Set("deleted_at", sq.Expr("NOW()")).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": params.ID, "product_id": params.ProductID, "deleted_at": nil}).
+		Suffix(variantReturning).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("sq delete variant build query error: %w", err)
+	}
+	return scanVariant(q.db.QueryRow(ctx, query, args...))
+}
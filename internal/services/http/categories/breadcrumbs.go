@@ -0,0 +1,23 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) GetCategoryBreadcrumbs(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryDTO,
+) ([]categoriesDomain.Category, error) {
+	categories, err := h.repository.GetCategoryBreadcrumbs(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return categories, nil
+}
@@ -0,0 +1,41 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go_template_project/internal/domain/attributes"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) CreateCategory(
+	ctx context.Context,
+	data categoriesDomain.CreateCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	if err := checkAttributeSchema(data.AttributeSchema); err != nil {
+		return nil, err
+	}
+
+	category, err := h.repository.CreateCategory(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrParentCategoryNotFound) || errors.Is(err, categoriesDomain.ErrCategorySlugTaken) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return category, nil
+}
+
+// checkAttributeSchema makes sure only schemas products can be validated
+// against are stored.
+func checkAttributeSchema(schema map[string]any) error {
+	if schema == nil {
+		return nil
+	}
+	if _, err := attributes.Compile(schema); err != nil {
+		return fmt.Errorf("%w: %s", categoriesDomain.ErrInvalidAttributeSchema, err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) DeleteCategory(
+	ctx context.Context,
+	data categoriesDomain.DeleteCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	category, err := h.repository.DeleteCategory(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) || errors.Is(err, categoriesDomain.ErrCategoryHasChildren) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return category, nil
+}
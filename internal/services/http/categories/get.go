@@ -0,0 +1,23 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) GetCategory(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	category, err := h.repository.GetCategory(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return category, nil
+}
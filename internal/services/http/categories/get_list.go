@@ -0,0 +1,19 @@
+package categories
+
+import (
+	"context"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) GetCategories(
+	ctx context.Context,
+	data categoriesDomain.GetCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	categories, err := h.repository.GetCategories(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return categories, nil
+}
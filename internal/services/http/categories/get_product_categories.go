@@ -0,0 +1,30 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.GetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	product := productsDomain.GetProductDTO{ID: data.ProductID, Fields: []string{"id"}}
+	if _, err := h.repository.GetProduct(ctx, product); err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	categories, err := h.repository.GetProductCategories(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return categories, nil
+}
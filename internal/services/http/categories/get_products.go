@@ -0,0 +1,36 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+// GetCategoryProducts lists the published products in the category and its
+// descendants.
+func (h Handler) GetCategoryProducts(
+	ctx context.Context,
+	data categoriesDomain.GetCategoryProductsDTO,
+) ([]productsDomain.Product, error) {
+	if _, err := h.repository.GetCategory(ctx, categoriesDomain.GetCategoryDTO{ID: data.ID}); err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	products, err := h.repository.GetProducts(ctx, productsDomain.GetProductsDTO{
+		Limit:         data.Limit,
+		Offset:        data.Offset,
+		Category:      &data.ID,
+		PublishedOnly: data.PublishedOnly,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return products, nil
+}
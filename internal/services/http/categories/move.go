@@ -0,0 +1,26 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) MoveCategory(
+	ctx context.Context,
+	data categoriesDomain.MoveCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	category, err := h.repository.MoveCategory(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) ||
+			errors.Is(err, categoriesDomain.ErrParentCategoryNotFound) ||
+			errors.Is(err, categoriesDomain.ErrCategoryCycle) ||
+			errors.Is(err, categoriesDomain.ErrCategorySlugTaken) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return category, nil
+}
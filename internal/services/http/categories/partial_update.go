@@ -0,0 +1,27 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) PartialUpdateCategory(
+	ctx context.Context,
+	data categoriesDomain.PartialUpdateCategoryDTO,
+) (*categoriesDomain.Category, error) {
+	if err := checkAttributeSchema(data.AttributeSchema); err != nil {
+		return nil, err
+	}
+
+	category, err := h.repository.PartialUpdateCategory(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) || errors.Is(err, categoriesDomain.ErrCategorySlugTaken) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return category, nil
+}
@@ -0,0 +1,58 @@
+package categories
+
+import (
+	"context"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type repository interface {
+	GetCategories(
+		ctx context.Context,
+		data categoriesDomain.GetCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	GetCategory(
+		ctx context.Context,
+		data categoriesDomain.GetCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	GetCategoryBreadcrumbs(
+		ctx context.Context,
+		data categoriesDomain.GetCategoryDTO,
+	) ([]categoriesDomain.Category, error)
+	CreateCategory(
+		ctx context.Context,
+		data categoriesDomain.CreateCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	PartialUpdateCategory(
+		ctx context.Context,
+		data categoriesDomain.PartialUpdateCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	DeleteCategory(
+		ctx context.Context,
+		data categoriesDomain.DeleteCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	MoveCategory(
+		ctx context.Context,
+		data categoriesDomain.MoveCategoryDTO,
+	) (*categoriesDomain.Category, error)
+	ReorderCategories(
+		ctx context.Context,
+		data categoriesDomain.ReorderCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	GetProductCategories(
+		ctx context.Context,
+		data categoriesDomain.GetProductCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	SetProductCategories(
+		ctx context.Context,
+		data categoriesDomain.SetProductCategoriesDTO,
+	) ([]categoriesDomain.Category, error)
+	GetProducts(
+		ctx context.Context,
+		data productsDomain.GetProductsDTO,
+	) ([]productsDomain.Product, error)
+	GetProduct(
+		ctx context.Context,
+		data productsDomain.GetProductDTO,
+	) (*productsDomain.Product, error)
+}
@@ -0,0 +1,23 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	"log"
+)
+
+func (h Handler) ReorderCategories(
+	ctx context.Context,
+	data categoriesDomain.ReorderCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	categories, err := h.repository.ReorderCategories(ctx, data)
+	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotChild) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return categories, nil
+}
@@ -0,0 +1,26 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) SetProductCategories(
+	ctx context.Context,
+	data categoriesDomain.SetProductCategoriesDTO,
+) ([]categoriesDomain.Category, error) {
+	categories, err := h.repository.SetProductCategories(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, categoriesDomain.ErrCategoryNotFound) ||
+			errors.Is(err, productsDomain.ErrInvalidAttributes) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return categories, nil
+}
@@ -0,0 +1,26 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) AdjustStock(
+	ctx context.Context,
+	data inventoryDomain.AdjustStockDTO,
+) (*inventoryDomain.StockLevel, error) {
+	level, err := h.repository.AdjustStock(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, inventoryDomain.ErrWarehouseNotFound) ||
+			errors.Is(err, inventoryDomain.ErrInsufficientStock) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return level, nil
+}
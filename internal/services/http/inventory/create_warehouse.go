@@ -0,0 +1,23 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"log"
+)
+
+func (h Handler) CreateWarehouse(
+	ctx context.Context,
+	data inventoryDomain.CreateWarehouseDTO,
+) (*inventoryDomain.Warehouse, error) {
+	warehouse, err := h.repository.CreateWarehouse(ctx, data)
+	if err != nil {
+		if errors.Is(err, inventoryDomain.ErrWarehouseCodeTaken) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return warehouse, nil
+}
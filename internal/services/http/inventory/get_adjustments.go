@@ -0,0 +1,23 @@
+package inventory
+
+import (
+	"context"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"log"
+)
+
+func (h Handler) GetStockAdjustments(
+	ctx context.Context,
+	data inventoryDomain.GetStockAdjustmentsDTO,
+) ([]inventoryDomain.Adjustment, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	adjustments, err := h.repository.GetStockAdjustments(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return adjustments, nil
+}
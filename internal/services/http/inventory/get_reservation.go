@@ -0,0 +1,23 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"log"
+)
+
+func (h Handler) GetReservation(
+	ctx context.Context,
+	data inventoryDomain.GetReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	reservation, err := h.repository.GetReservation(ctx, data)
+	if err != nil {
+		if errors.Is(err, inventoryDomain.ErrReservationNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return reservation, nil
+}
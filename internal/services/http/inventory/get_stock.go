@@ -0,0 +1,39 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductStock(
+	ctx context.Context,
+	data inventoryDomain.GetProductStockDTO,
+) ([]inventoryDomain.StockLevel, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	levels, err := h.repository.GetProductStock(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return levels, nil
+}
+
+// checkProduct tells a product without stock from a missing one.
+func (h Handler) checkProduct(ctx context.Context, id uuid.UUID) error {
+	product := productsDomain.GetProductDTO{ID: id, Fields: []string{"id"}}
+	if _, err := h.repository.GetProduct(ctx, product); err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return err
+		}
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package inventory
+
+import (
+	"context"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"log"
+)
+
+func (h Handler) GetWarehouses(
+	ctx context.Context,
+	data inventoryDomain.GetWarehousesDTO,
+) ([]inventoryDomain.Warehouse, error) {
+	warehouses, err := h.repository.GetWarehouses(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return warehouses, nil
+}
@@ -0,0 +1,50 @@
+package inventory
+
+import (
+	"context"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type repository interface {
+	GetWarehouses(
+		ctx context.Context,
+		data inventoryDomain.GetWarehousesDTO,
+	) ([]inventoryDomain.Warehouse, error)
+	CreateWarehouse(
+		ctx context.Context,
+		data inventoryDomain.CreateWarehouseDTO,
+	) (*inventoryDomain.Warehouse, error)
+	GetProductStock(
+		ctx context.Context,
+		data inventoryDomain.GetProductStockDTO,
+	) ([]inventoryDomain.StockLevel, error)
+	AdjustStock(
+		ctx context.Context,
+		data inventoryDomain.AdjustStockDTO,
+	) (*inventoryDomain.StockLevel, error)
+	GetStockAdjustments(
+		ctx context.Context,
+		data inventoryDomain.GetStockAdjustmentsDTO,
+	) ([]inventoryDomain.Adjustment, error)
+	ReserveStock(
+		ctx context.Context,
+		data inventoryDomain.ReserveStockDTO,
+	) (*inventoryDomain.Reservation, error)
+	GetReservation(
+		ctx context.Context,
+		data inventoryDomain.GetReservationDTO,
+	) (*inventoryDomain.Reservation, error)
+	ReleaseReservation(
+		ctx context.Context,
+		data inventoryDomain.ReleaseReservationDTO,
+	) (*inventoryDomain.Reservation, error)
+	CommitReservation(
+		ctx context.Context,
+		data inventoryDomain.CommitReservationDTO,
+	) (*inventoryDomain.Reservation, error)
+	GetProduct(
+		ctx context.Context,
+		data productsDomain.GetProductDTO,
+	) (*productsDomain.Product, error)
+}
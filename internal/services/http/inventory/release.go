@@ -0,0 +1,23 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"log"
+)
+
+func (h Handler) ReleaseReservation(
+	ctx context.Context,
+	data inventoryDomain.ReleaseReservationDTO,
+) (*inventoryDomain.Reservation, error) {
+	reservation, err := h.repository.ReleaseReservation(ctx, data)
+	if err != nil {
+		if errors.Is(err, inventoryDomain.ErrReservationNotFound) || errors.Is(err, inventoryDomain.ErrReservationNotActive) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return reservation, nil
+}
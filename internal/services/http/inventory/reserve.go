@@ -0,0 +1,37 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+	"time"
+)
+
+func (h Handler) ReserveStock(
+	ctx context.Context,
+	data inventoryDomain.ReserveStockDTO,
+) (*inventoryDomain.Reservation, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	ttl := h.reservationTTL
+	if data.ExpiresIn > 0 {
+		ttl = time.Duration(data.ExpiresIn) * time.Second
+	}
+	data.ExpiresAt = time.Now().Add(ttl)
+
+	reservation, err := h.repository.ReserveStock(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, inventoryDomain.ErrWarehouseNotFound) ||
+			errors.Is(err, inventoryDomain.ErrInsufficientStock) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return reservation, nil
+}
@@ -0,0 +1,16 @@
+package inventory
+
+import "time"
+
+type Handler struct {
+	repository
+	// reservationTTL applies to reservations asked for without an expiry
+	reservationTTL time.Duration
+}
+
+func New(repo repository, reservationTTL time.Duration) Handler {
+	return Handler{
+		repository:     repo,
+		reservationTTL: reservationTTL,
+	}
+}
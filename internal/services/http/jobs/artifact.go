@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"go_template_project/internal/storage"
+	"log"
+)
+
+// GetJobArtifact opens the file a job stored.
+func (h Handler) GetJobArtifact(
+	ctx context.Context,
+	data jobsDomain.GetJobDTO,
+) (*jobsDomain.ArtifactFile, error) {
+	artifact, err := h.repository.GetJobArtifact(ctx, data)
+	if err != nil {
+		if errors.Is(err, jobsDomain.ErrJobArtifactNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	body, err := h.storage.Open(ctx, artifact.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, jobsDomain.ErrJobArtifactNotFound
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return &jobsDomain.ArtifactFile{
+		Name:        artifact.Name,
+		ContentType: artifact.ContentType,
+		Body:        body,
+	}, nil
+}
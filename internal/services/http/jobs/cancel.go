@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"log"
+)
+
+func (h Handler) CancelJob(
+	ctx context.Context,
+	data jobsDomain.CancelJobDTO,
+) (*jobsDomain.Job, error) {
+	job, err := h.repository.CancelJob(ctx, data)
+	if err != nil {
+		if errors.Is(err, jobsDomain.ErrJobNotFound) || errors.Is(err, jobsDomain.ErrJobFinished) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return job, nil
+}
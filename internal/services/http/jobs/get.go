@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"log"
+)
+
+func (h Handler) GetJob(
+	ctx context.Context,
+	data jobsDomain.GetJobDTO,
+) (*jobsDomain.Job, error) {
+	job, err := h.repository.GetJob(ctx, data)
+	if err != nil {
+		if errors.Is(err, jobsDomain.ErrJobNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	jobsDomain "go_template_project/internal/domain/jobs"
+)
+
+type repository interface {
+	GetJob(
+		ctx context.Context,
+		data jobsDomain.GetJobDTO,
+	) (*jobsDomain.Job, error)
+	GetJobArtifact(
+		ctx context.Context,
+		data jobsDomain.GetJobDTO,
+	) (*jobsDomain.Artifact, error)
+	CancelJob(
+		ctx context.Context,
+		data jobsDomain.CancelJobDTO,
+	) (*jobsDomain.Job, error)
+}
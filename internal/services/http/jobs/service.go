@@ -0,0 +1,15 @@
+package jobs
+
+import "go_template_project/internal/storage"
+
+type Handler struct {
+	repository
+	storage storage.Storage
+}
+
+func New(repo repository, store storage.Storage) Handler {
+	return Handler{
+		repository: repo,
+		storage:    store,
+	}
+}
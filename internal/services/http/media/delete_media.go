@@ -0,0 +1,25 @@
+package media
+
+import (
+	"context"
+	"errors"
+	mediaDomain "go_template_project/internal/domain/media"
+	"log"
+)
+
+// DeleteMedia takes the media out of the gallery right away, its files are
+// removed by the cleaner.
+func (h Handler) DeleteMedia(
+	ctx context.Context,
+	data mediaDomain.DeleteMediaDTO,
+) (*mediaDomain.Media, error) {
+	media, err := h.repository.DeleteMedia(ctx, data)
+	if err != nil {
+		if errors.Is(err, mediaDomain.ErrMediaNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return media, nil
+}
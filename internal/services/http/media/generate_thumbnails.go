@@ -0,0 +1,93 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	mediaDomain "go_template_project/internal/domain/media"
+	"go_template_project/internal/storage"
+	"image"
+	"io"
+	"log"
+)
+
+// GenerateThumbnails scales the original down to every configured size.
+// Thumbnails are written under fixed keys, so running it again after a
+// failure just overwrites them. An original that can not be decoded marks
+// the media as failed.
+func (h Handler) GenerateThumbnails(
+	ctx context.Context,
+	data mediaDomain.GenerateThumbnailsDTO,
+) (*mediaDomain.Media, error) {
+	media, err := h.repository.GetMedia(ctx, mediaDomain.GetMediaDTO{
+		ID:                 data.MediaID,
+		WithDeletedProduct: true,
+	})
+	if err != nil {
+		if errors.Is(err, mediaDomain.ErrMediaNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	original, err := h.storage.Open(ctx, mediaDomain.Key(media.ID, mediaDomain.Original))
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, mediaDomain.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	body, err := io.ReadAll(original)
+	if closeErr := original.Close(); closeErr != nil {
+		log.Println(closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		_, setErr := h.repository.SetThumbnails(ctx, mediaDomain.SetThumbnailsDTO{
+			ID:     media.ID,
+			Status: mediaDomain.StatusFailed,
+		})
+		if setErr != nil && !errors.Is(setErr, mediaDomain.ErrMediaNotFound) {
+			return nil, setErr
+		}
+		return nil, fmt.Errorf("%w: %w", mediaDomain.ErrInvalidImage, err)
+	}
+
+	thumbnails := make([]mediaDomain.Thumbnail, 0, len(h.config.ThumbnailSizes))
+	for _, size := range h.config.ThumbnailSizes {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		thumbnail, thumbnailBody, err := makeThumbnail(img, media.ContentType, size)
+		if err != nil {
+			return nil, fmt.Errorf("thumbnail %d encoding failed: %w", size, err)
+		}
+		key := mediaDomain.Key(media.ID, mediaDomain.ThumbnailName(size))
+		if err = h.storage.Put(ctx, key, bytes.NewReader(thumbnailBody)); err != nil {
+			return nil, err
+		}
+		thumbnails = append(thumbnails, *thumbnail)
+	}
+
+	media, err = h.repository.SetThumbnails(ctx, mediaDomain.SetThumbnailsDTO{
+		ID:         media.ID,
+		Status:     mediaDomain.StatusReady,
+		Thumbnails: thumbnails,
+	})
+	if err != nil {
+		if errors.Is(err, mediaDomain.ErrMediaNotFound) {
+			// deleted meanwhile, the cleaner may have missed the thumbnails
+			h.deleteFiles(ctx, data.MediaID)
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return media, nil
+}
@@ -0,0 +1,51 @@
+package media
+
+import (
+	"context"
+	"errors"
+	mediaDomain "go_template_project/internal/domain/media"
+	"go_template_project/internal/storage"
+	"log"
+)
+
+// GetMediaFile opens the original or a thumbnail of a media in a gallery.
+func (h Handler) GetMediaFile(
+	ctx context.Context,
+	data mediaDomain.GetMediaFileDTO,
+) (*mediaDomain.File, error) {
+	media, err := h.repository.GetMedia(ctx, mediaDomain.GetMediaDTO{ID: data.ID})
+	if err != nil {
+		if errors.Is(err, mediaDomain.ErrMediaNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	file := &mediaDomain.File{}
+	if data.Name == mediaDomain.Original {
+		file.ContentType = media.ContentType
+		file.FileSize = media.FileSize
+	} else {
+		for _, thumbnail := range media.Thumbnails {
+			if mediaDomain.ThumbnailName(thumbnail.Size) == data.Name {
+				file.ContentType = thumbnail.ContentType
+				file.FileSize = thumbnail.FileSize
+				break
+			}
+		}
+		if file.ContentType == "" {
+			return nil, mediaDomain.ErrMediaNotFound
+		}
+	}
+
+	file.Body, err = h.storage.Open(ctx, mediaDomain.Key(media.ID, data.Name))
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, mediaDomain.ErrMediaNotFound
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return file, nil
+}
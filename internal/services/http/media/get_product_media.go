@@ -0,0 +1,38 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductMedia(
+	ctx context.Context,
+	data mediaDomain.GetProductMediaDTO,
+) ([]mediaDomain.Media, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	media, err := h.repository.GetProductMedia(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return media, nil
+}
+
+func (h Handler) checkProduct(ctx context.Context, id uuid.UUID) error {
+	product := productsDomain.GetProductDTO{ID: id, Fields: []string{"id"}}
+	if _, err := h.repository.GetProduct(ctx, product); err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return err
+		}
+		log.Println(err)
+		return err
+	}
+	return nil
+}
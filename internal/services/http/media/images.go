@@ -0,0 +1,78 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	mediaDomain "go_template_project/internal/domain/media"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"slices"
+
+	"golang.org/x/image/draw"
+
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+)
+
+const thumbnailJPEGQuality = 85
+
+// inspectImage sniffs the content type of an uploaded image and reads its
+// dimensions without decoding it.
+func inspectImage(body []byte) (contentType string, width, height int, err error) {
+	contentType = http.DetectContentType(body)
+	if !slices.Contains(mediaDomain.ContentTypes, contentType) {
+		return "", 0, 0, mediaDomain.ErrUnsupportedMediaType
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("%w: %w", mediaDomain.ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return "", 0, 0, mediaDomain.ErrInvalidImage
+	}
+	if config.Width*config.Height > mediaDomain.MaxPixels {
+		return "", 0, 0, mediaDomain.ErrMediaTooLarge
+	}
+	return contentType, config.Width, config.Height, nil
+}
+
+// makeThumbnail scales img down to fit in a size by size box, smaller
+// images keep their dimensions. JPEG images stay JPEG, anything else
+// becomes PNG to keep transparency.
+func makeThumbnail(img image.Image, contentType string, size int) (*mediaDomain.Thumbnail, []byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, bounds, draw.Src, nil)
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: thumbnailJPEGQuality})
+	} else {
+		contentType = "image/png"
+		err = png.Encode(&buf, scaled)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &mediaDomain.Thumbnail{
+		Size:        size,
+		Width:       width,
+		Height:      height,
+		ContentType: contentType,
+		FileSize:    int64(buf.Len()),
+	}, buf.Bytes(), nil
+}
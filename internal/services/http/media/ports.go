@@ -0,0 +1,43 @@
+package media
+
+import (
+	"context"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type repository interface {
+	CreateMedia(
+		ctx context.Context,
+		data mediaDomain.CreateMediaDTO,
+	) (*mediaDomain.Media, error)
+	GetMedia(
+		ctx context.Context,
+		data mediaDomain.GetMediaDTO,
+	) (*mediaDomain.Media, error)
+	GetProductMedia(
+		ctx context.Context,
+		data mediaDomain.GetProductMediaDTO,
+	) ([]mediaDomain.Media, error)
+	SetMediaOrder(
+		ctx context.Context,
+		data mediaDomain.SetMediaOrderDTO,
+	) ([]mediaDomain.Media, error)
+	DeleteMedia(
+		ctx context.Context,
+		data mediaDomain.DeleteMediaDTO,
+	) (*mediaDomain.Media, error)
+	SetThumbnails(
+		ctx context.Context,
+		data mediaDomain.SetThumbnailsDTO,
+	) (*mediaDomain.Media, error)
+	GetProduct(
+		ctx context.Context,
+		data productsDomain.GetProductDTO,
+	) (*productsDomain.Product, error)
+	EnqueueJob(
+		ctx context.Context,
+		data jobsDomain.EnqueueJobDTO,
+	) (*jobsDomain.Job, error)
+}
@@ -0,0 +1,20 @@
+package media
+
+import (
+	mediaService "go_template_project/internal/services/media"
+	"go_template_project/internal/storage"
+)
+
+type Handler struct {
+	repository
+	storage storage.Storage
+	config  mediaService.Config
+}
+
+func New(repo repository, store storage.Storage, config mediaService.Config) Handler {
+	return Handler{
+		repository: repo,
+		storage:    store,
+		config:     config,
+	}
+}
@@ -0,0 +1,25 @@
+package media
+
+import (
+	"context"
+	"errors"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) SetMediaOrder(
+	ctx context.Context,
+	data mediaDomain.SetMediaOrderDTO,
+) ([]mediaDomain.Media, error) {
+	media, err := h.repository.SetMediaOrder(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, mediaDomain.ErrInvalidMediaOrder) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return media, nil
+}
@@ -0,0 +1,91 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	mediaDomain "go_template_project/internal/domain/media"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+)
+
+// UploadMedia stores the image and queues generating its thumbnails. The
+// content type is sniffed from the data, whatever the client claims is
+// ignored.
+func (h Handler) UploadMedia(
+	ctx context.Context,
+	data mediaDomain.UploadMediaDTO,
+) (*mediaDomain.Media, error) {
+	body, err := io.ReadAll(io.LimitReader(data.Body, h.config.MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > h.config.MaxFileSize {
+		return nil, mediaDomain.ErrMediaTooLarge
+	}
+	contentType, width, height, err := inspectImage(body)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	if err = h.storage.Put(ctx, mediaDomain.Key(id, mediaDomain.Original), bytes.NewReader(body)); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	media, err := h.repository.CreateMedia(ctx, mediaDomain.CreateMediaDTO{
+		ID:          id,
+		ProductID:   data.ProductID,
+		ContentType: contentType,
+		FileSize:    int64(len(body)),
+		Width:       width,
+		Height:      height,
+	})
+	if err != nil {
+		h.deleteFiles(ctx, id)
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	if err = h.enqueueThumbnails(ctx, media.ID); err != nil {
+		log.Println(err)
+		// detached media is removed along with its files by the cleaner
+		_, deleteErr := h.repository.DeleteMedia(ctx, mediaDomain.DeleteMediaDTO{
+			ProductID: media.ProductID,
+			ID:        media.ID,
+		})
+		if deleteErr != nil {
+			log.Println(deleteErr)
+		}
+		return nil, err
+	}
+	return media, nil
+}
+
+func (h Handler) enqueueThumbnails(ctx context.Context, id uuid.UUID) error {
+	rawPayload, err := json.Marshal(mediaDomain.GenerateThumbnailsDTO{MediaID: id})
+	if err != nil {
+		return fmt.Errorf("job payload marshalling failed: %w", err)
+	}
+	_, err = h.repository.EnqueueJob(ctx, jobsDomain.EnqueueJobDTO{
+		Kind:    mediaDomain.JobKindThumbnails,
+		Payload: rawPayload,
+	})
+	return err
+}
+
+// deleteFiles removes the files of a media that is not in a gallery, the
+// cleaner only knows about media still in the database.
+func (h Handler) deleteFiles(ctx context.Context, id uuid.UUID) {
+	if err := h.storage.DeleteAll(context.WithoutCancel(ctx), mediaDomain.Prefix(id)); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,23 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	pricesDomain "go_template_project/internal/domain/prices"
+	"log"
+)
+
+func (h Handler) DeleteProductPrice(
+	ctx context.Context,
+	data pricesDomain.DeleteProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	price, err := h.repository.DeleteProductPrice(ctx, data)
+	if err != nil {
+		if errors.Is(err, pricesDomain.ErrPriceNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return price, nil
+}
@@ -0,0 +1,39 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductPrices(
+	ctx context.Context,
+	data pricesDomain.GetProductPricesDTO,
+) ([]pricesDomain.Price, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	prices, err := h.repository.GetProductPrices(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return prices, nil
+}
+
+// checkProduct tells a product without prices from a missing one.
+func (h Handler) checkProduct(ctx context.Context, id uuid.UUID) error {
+	product := productsDomain.GetProductDTO{ID: id, Fields: []string{"id"}}
+	if _, err := h.repository.GetProduct(ctx, product); err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return err
+		}
+		log.Println(err)
+		return err
+	}
+	return nil
+}
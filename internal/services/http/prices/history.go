@@ -0,0 +1,23 @@
+package prices
+
+import (
+	"context"
+	pricesDomain "go_template_project/internal/domain/prices"
+	"log"
+)
+
+func (h Handler) GetProductPriceHistory(
+	ctx context.Context,
+	data pricesDomain.GetProductPriceHistoryDTO,
+) ([]pricesDomain.PriceChange, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	changes, err := h.repository.GetProductPriceHistory(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return changes, nil
+}
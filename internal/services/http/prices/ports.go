@@ -0,0 +1,30 @@
+package prices
+
+import (
+	"context"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type repository interface {
+	GetProductPrices(
+		ctx context.Context,
+		data pricesDomain.GetProductPricesDTO,
+	) ([]pricesDomain.Price, error)
+	GetProductPriceHistory(
+		ctx context.Context,
+		data pricesDomain.GetProductPriceHistoryDTO,
+	) ([]pricesDomain.PriceChange, error)
+	SetProductPrice(
+		ctx context.Context,
+		data pricesDomain.SetProductPriceDTO,
+	) (*pricesDomain.Price, error)
+	DeleteProductPrice(
+		ctx context.Context,
+		data pricesDomain.DeleteProductPriceDTO,
+	) (*pricesDomain.Price, error)
+	GetProduct(
+		ctx context.Context,
+		data productsDomain.GetProductDTO,
+	) (*productsDomain.Product, error)
+}
@@ -0,0 +1,11 @@
+package prices
+
+type Handler struct {
+	repository
+}
+
+func New(repo repository) Handler {
+	return Handler{
+		repository: repo,
+	}
+}
@@ -0,0 +1,32 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+	"time"
+)
+
+func (h Handler) SetProductPrice(
+	ctx context.Context,
+	data pricesDomain.SetProductPriceDTO,
+) (*pricesDomain.Price, error) {
+	if data.ValidFrom.IsZero() {
+		data.ValidFrom = time.Now()
+	}
+	if data.ValidTo != nil && !data.ValidTo.After(data.ValidFrom) {
+		return nil, pricesDomain.ErrInvalidPriceRange
+	}
+
+	price, err := h.repository.SetProductPrice(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) || errors.Is(err, pricesDomain.ErrPriceConflict) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return price, nil
+}
@@ -0,0 +1,70 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"log"
+)
+
+func (h Handler) EnqueueBulkCreateProducts(
+	ctx context.Context,
+	data []productsDomain.Product,
+) (*jobsDomain.Job, error) {
+	return h.enqueue(ctx, productsDomain.JobKindBulkCreate, data)
+}
+
+func (h Handler) EnqueueBulkUpdateProducts(
+	ctx context.Context,
+	data []productsDomain.Product,
+) (*jobsDomain.Job, error) {
+	return h.enqueue(ctx, productsDomain.JobKindBulkUpdate, data)
+}
+
+// EnqueueBulkTagProducts checks the tags up front, so a job is only queued
+// for a valid request.
+func (h Handler) EnqueueBulkTagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*jobsDomain.Job, error) {
+	if err := normalizeTags(&data); err != nil {
+		return nil, err
+	}
+	return h.enqueue(ctx, productsDomain.JobKindBulkTag, data)
+}
+
+func (h Handler) EnqueueBulkUntagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*jobsDomain.Job, error) {
+	if err := normalizeTags(&data); err != nil {
+		return nil, err
+	}
+	return h.enqueue(ctx, productsDomain.JobKindBulkUntag, data)
+}
+
+func (h Handler) EnqueueExportProducts(
+	ctx context.Context,
+	data productsDomain.ExportProductsDTO,
+) (*jobsDomain.Job, error) {
+	return h.enqueue(ctx, productsDomain.JobKindExport, data)
+}
+
+func (h Handler) enqueue(ctx context.Context, kind string, payload any) (*jobsDomain.Job, error) {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("job payload marshalling failed: %w", err)
+	}
+	job, err := h.repository.EnqueueJob(ctx, jobsDomain.EnqueueJobDTO{
+		Kind:    kind,
+		Payload: rawPayload,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return job, nil
+}
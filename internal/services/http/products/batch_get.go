@@ -0,0 +1,52 @@
+package products
+
+import (
+	"context"
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) BatchGetProducts(
+	ctx context.Context,
+	data productsDomain.GetProductsByIDsDTO,
+) (*productsDomain.BatchGetProductsResult, error) {
+	ids := make([]uuid.UUID, 0, len(data.IDs))
+	seen := make(map[uuid.UUID]bool, len(data.IDs))
+	for _, id := range data.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	products, err := h.repository.GetProductsByIDs(ctx, productsDomain.GetProductsByIDsDTO{
+		IDs:           ids,
+		Locales:       data.Locales,
+		PublishedOnly: data.PublishedOnly,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]productsDomain.Product, len(products))
+	for _, product := range products {
+		found[product.ID] = product
+	}
+
+	// Keep the order of the request, so clients can zip results with their ids
+	result := &productsDomain.BatchGetProductsResult{
+		Products: make([]productsDomain.Product, 0, len(found)),
+		Missing:  make([]uuid.UUID, 0),
+	}
+	for _, id := range ids {
+		product, ok := found[id]
+		if !ok {
+			result.Missing = append(result.Missing, id)
+			continue
+		}
+		result.Products = append(result.Products, product)
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package products
+
+import (
+	"context"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"log"
+)
+
+func (h Handler) BulkTagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	if err := normalizeTags(&data); err != nil {
+		return nil, err
+	}
+	result, err := h.repository.TagProducts(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (h Handler) BulkUntagProducts(
+	ctx context.Context,
+	data tagsDomain.TagProductsDTO,
+) (*tagsDomain.TagProductsResult, error) {
+	if err := normalizeTags(&data); err != nil {
+		return nil, err
+	}
+	result, err := h.repository.UntagProducts(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// normalizeTags brings the tags of data to their stored form, blank tags
+// are rejected.
+func normalizeTags(data *tagsDomain.TagProductsDTO) error {
+	for _, name := range data.Tags {
+		if tagsDomain.NormalizeTag(name) == "" {
+			return tagsDomain.ErrInvalidTag
+		}
+	}
+	data.Tags = tagsDomain.NormalizeTags(data.Tags)
+	if len(data.Tags) == 0 {
+		return tagsDomain.ErrInvalidTag
+	}
+	return nil
+}
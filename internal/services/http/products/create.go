@@ -2,6 +2,8 @@ package products
 
 import (
 	"context"
+	"errors"
+	categoriesDomain "go_template_project/internal/domain/categories"
 	productsDomain "go_template_project/internal/domain/products"
 	"log"
 )
@@ -12,6 +14,9 @@ func (h Handler) CreateProduct(
 ) (*productsDomain.Product, error) {
 	product, err := h.repository.CreateProduct(ctx, data)
 	if err != nil {
+		if errors.Is(err, categoriesDomain.ErrCategoryNotFound) || errors.Is(err, productsDomain.ErrInvalidAttributes) {
+			return nil, err
+		}
 		log.Println(err)
 		return nil, err
 	}
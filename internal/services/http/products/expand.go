@@ -0,0 +1,83 @@
+package products
+
+import (
+	"context"
+	"github.com/google/uuid"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	mediaDomain "go_template_project/internal/domain/media"
+	pricesDomain "go_template_project/internal/domain/prices"
+	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"slices"
+)
+
+// expandedFields adds the id to narrowed fields, expansions are matched to
+// the products by it.
+func expandedFields(fields, expand []string) []string {
+	if len(expand) == 0 || len(fields) == 0 || slices.Contains(fields, "id") {
+		return fields
+	}
+	return append(slices.Clip(fields), "id")
+}
+
+// expandProducts embeds the related resources named in expand, with one
+// query per expansion however many products there are.
+func (h Handler) expandProducts(
+	ctx context.Context,
+	products []productsDomain.Product,
+	expand []string,
+) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	for _, name := range expand {
+		switch name {
+		case productsDomain.ExpandCategories:
+			categories, err := h.repository.GetProductsCategories(ctx, categoriesDomain.GetProductsCategoriesDTO{ProductIDs: ids})
+			if err != nil {
+				return err
+			}
+			for i := range products {
+				products[i].Categories = categories[products[i].ID]
+			}
+		case productsDomain.ExpandPrices:
+			prices, err := h.repository.GetProductsPrices(ctx, pricesDomain.GetProductsPricesDTO{ProductIDs: ids})
+			if err != nil {
+				return err
+			}
+			for i := range products {
+				products[i].Prices = prices[products[i].ID]
+			}
+		case productsDomain.ExpandTags:
+			tags, err := h.repository.GetProductsTags(ctx, tagsDomain.GetProductsTagsDTO{ProductIDs: ids})
+			if err != nil {
+				return err
+			}
+			for i := range products {
+				products[i].Tags = tags[products[i].ID]
+			}
+		case productsDomain.ExpandMedia:
+			media, err := h.repository.GetProductsMedia(ctx, mediaDomain.GetProductsMediaDTO{ProductIDs: ids})
+			if err != nil {
+				return err
+			}
+			for i := range products {
+				products[i].Media = media[products[i].ID]
+			}
+		case productsDomain.ExpandVariants:
+			variants, err := h.repository.GetProductsVariants(ctx, variantsDomain.GetProductsVariantsDTO{ProductIDs: ids})
+			if err != nil {
+				return err
+			}
+			for i := range products {
+				products[i].Variants = variants[products[i].ID]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+)
+
+// Buffered rows are pushed to the client every exportFlushEvery products.
+const exportFlushEvery = 1000
+
+type (
+	flusher interface {
+		Flush()
+	}
+
+	// progressReporter is implemented by writers that track how many
+	// products were exported so far, e.g. by background export jobs.
+	progressReporter interface {
+		ReportProgress(done int64)
+	}
+)
+
+func (h Handler) ExportProducts(
+	ctx context.Context,
+	data productsDomain.ExportProductsDTO,
+	w io.Writer,
+) error {
+	columns := data.Columns
+	if len(columns) == 0 {
+		columns = productsDomain.ExportColumns
+	}
+
+	encoder, err := newProductsEncoder(data.Format, w, columns)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = h.repository.ExportProducts(ctx, data, func(product productsDomain.Product) error {
+		if err := encoder.Encode(product); err != nil {
+			return fmt.Errorf("encode product %s: %w", product.ID, err)
+		}
+		count++
+		if count%exportFlushEvery == 0 {
+			if err := encoder.Flush(); err != nil {
+				return err
+			}
+			if f, ok := w.(flusher); ok {
+				f.Flush()
+			}
+			if p, ok := w.(progressReporter); ok {
+				p.ReportProgress(int64(count))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if p, ok := w.(progressReporter); ok {
+		p.ReportProgress(int64(count))
+	}
+	return encoder.Close()
+}
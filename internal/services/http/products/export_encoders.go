@@ -0,0 +1,224 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxSheet = "Products"
+
+type (
+	productsEncoder interface {
+		Encode(product productsDomain.Product) error
+		Flush() error
+		Close() error
+	}
+
+	csvProductsEncoder struct {
+		writer  *csv.Writer
+		columns []string
+		record  []string
+	}
+
+	ndjsonProductsEncoder struct {
+		writer  *bufio.Writer
+		columns []string
+		line    bytes.Buffer
+	}
+
+	// xlsxProductsEncoder relies on the excelize stream writer, which spills
+	// rows to a temporary file instead of keeping the sheet in memory. The
+	// workbook can only be written out once all rows are known. A sheet holds
+	// at most excelize.TotalRows rows, the export continues on "Products 2",
+	// "Products 3" and so on, each with its own header row.
+	xlsxProductsEncoder struct {
+		file    *excelize.File
+		stream  *excelize.StreamWriter
+		out     io.Writer
+		columns []string
+		header  []interface{}
+		sheets  int
+		row     int
+	}
+)
+
+func newProductsEncoder(format string, w io.Writer, columns []string) (productsEncoder, error) {
+	switch format {
+	case productsDomain.ExportFormatCSV:
+		encoder := &csvProductsEncoder{
+			writer:  csv.NewWriter(w),
+			columns: columns,
+			record:  make([]string, len(columns)),
+		}
+		return encoder, encoder.writer.Write(columns)
+	case productsDomain.ExportFormatNDJSON:
+		return &ndjsonProductsEncoder{
+			writer:  bufio.NewWriter(w),
+			columns: columns,
+		}, nil
+	case productsDomain.ExportFormatXLSX:
+		return newXLSXProductsEncoder(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func exportValue(product productsDomain.Product, column string) interface{} {
+	switch column {
+	case "id":
+		return product.ID.String()
+	case "name":
+		return product.Name
+	case "title":
+		return product.Title
+	case "created_at":
+		return product.CreatedAt
+	case "updated_at":
+		return product.UpdatedAt
+	case "deleted_at":
+		if product.DeletedAt == nil {
+			return nil
+		}
+		return *product.DeletedAt
+	}
+	return nil
+}
+
+func (e *csvProductsEncoder) Encode(product productsDomain.Product) error {
+	for i, column := range e.columns {
+		switch value := exportValue(product, column).(type) {
+		case nil:
+			e.record[i] = ""
+		case time.Time:
+			e.record[i] = value.Format(time.RFC3339Nano)
+		case string:
+			e.record[i] = value
+		}
+	}
+	return e.writer.Write(e.record)
+}
+
+func (e *csvProductsEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvProductsEncoder) Close() error {
+	return e.Flush()
+}
+
+func (e *ndjsonProductsEncoder) Encode(product productsDomain.Product) error {
+	e.line.Reset()
+	e.line.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			e.line.WriteByte(',')
+		}
+		value, err := json.Marshal(exportValue(product, column))
+		if err != nil {
+			return err
+		}
+		e.line.WriteByte('"')
+		e.line.WriteString(column)
+		e.line.WriteString(`":`)
+		e.line.Write(value)
+	}
+	e.line.WriteString("}\n")
+	_, err := e.writer.Write(e.line.Bytes())
+	return err
+}
+
+func (e *ndjsonProductsEncoder) Flush() error {
+	return e.writer.Flush()
+}
+
+func (e *ndjsonProductsEncoder) Close() error {
+	return e.Flush()
+}
+
+func newXLSXProductsEncoder(w io.Writer, columns []string) (*xlsxProductsEncoder, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), xlsxSheet); err != nil {
+		return nil, err
+	}
+	header := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column)
+	}
+	encoder := &xlsxProductsEncoder{
+		file:    file,
+		out:     w,
+		columns: columns,
+		header:  header,
+	}
+	return encoder, encoder.nextSheet()
+}
+
+// nextSheet finishes the current sheet, if any, and starts the next one
+// with the header row.
+func (e *xlsxProductsEncoder) nextSheet() error {
+	sheet := xlsxSheet
+	if e.sheets > 0 {
+		if err := e.stream.Flush(); err != nil {
+			return err
+		}
+		sheet = fmt.Sprintf("%s %d", xlsxSheet, e.sheets+1)
+		if _, err := e.file.NewSheet(sheet); err != nil {
+			return err
+		}
+	}
+	stream, err := e.file.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	e.stream = stream
+	e.sheets++
+	e.row = 1
+	return e.writeRow(e.header)
+}
+
+func (e *xlsxProductsEncoder) writeRow(values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	e.row++
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *xlsxProductsEncoder) Encode(product productsDomain.Product) error {
+	if e.row > excelize.TotalRows {
+		if err := e.nextSheet(); err != nil {
+			return err
+		}
+	}
+	values := make([]interface{}, 0, len(e.columns))
+	for _, column := range e.columns {
+		values = append(values, exportValue(product, column))
+	}
+	return e.writeRow(values)
+}
+
+// Flush is a no-op: nothing can be sent before the workbook is complete.
+func (e *xlsxProductsEncoder) Flush() error {
+	return nil
+}
+
+func (e *xlsxProductsEncoder) Close() error {
+	defer func() {
+		_ = e.file.Close()
+	}()
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := e.file.WriteTo(e.out)
+	return err
+}
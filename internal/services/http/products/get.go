@@ -11,6 +11,7 @@ func (h Handler) GetProduct(
 	ctx context.Context,
 	data productsDomain.GetProductDTO,
 ) (*productsDomain.Product, error) {
+	data.Fields = expandedFields(data.Fields, data.Expand)
 	product, err := h.repository.GetProduct(ctx, data)
 	if err != nil {
 		if errors.Is(err, productsDomain.ErrProductNotFound) {
@@ -19,5 +20,10 @@ func (h Handler) GetProduct(
 		log.Println(err)
 		return nil, err
 	}
-	return product, nil
+	products := []productsDomain.Product{*product}
+	if err = h.expandProducts(ctx, products, data.Expand); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &products[0], nil
 }
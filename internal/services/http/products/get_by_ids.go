@@ -0,0 +1,19 @@
+package products
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductsByIDs(
+	ctx context.Context,
+	data productsDomain.GetProductsByIDsDTO,
+) ([]productsDomain.Product, error) {
+	products, err := h.repository.GetProductsByIDs(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return products, nil
+}
@@ -0,0 +1,22 @@
+package products
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductsFacets(
+	ctx context.Context,
+	data productsDomain.GetProductsFacetsDTO,
+) (*productsDomain.ProductsFacets, error) {
+	if err := productsDomain.CheckFacets(&data); err != nil {
+		return nil, err
+	}
+	facets, err := h.repository.GetProductsFacets(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return facets, nil
+}
@@ -10,10 +10,15 @@ func (h Handler) GetProducts(
 	ctx context.Context,
 	data productsDomain.GetProductsDTO,
 ) ([]productsDomain.Product, error) {
+	data.Fields = expandedFields(data.Fields, data.Expand)
 	products, err := h.repository.GetProducts(ctx, data)
 	if err != nil {
 		log.Println(err)
 		return products, err
 	}
+	if err = h.expandProducts(ctx, products, data.Expand); err != nil {
+		log.Println(err)
+		return nil, err
+	}
 	return products, nil
 }
@@ -0,0 +1,19 @@
+package products
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetMissingTranslations(
+	ctx context.Context,
+	data productsDomain.GetMissingTranslationsDTO,
+) ([]productsDomain.MissingTranslation, error) {
+	missing, err := h.repository.GetMissingTranslations(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return missing, nil
+}
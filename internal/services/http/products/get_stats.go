@@ -0,0 +1,19 @@
+package products
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductsStats(
+	ctx context.Context,
+	data productsDomain.GetProductsDTO,
+) (*productsDomain.ProductsStats, error) {
+	stats, err := h.repository.GetProductsStats(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,21 @@
+package products
+
+import (
+	"context"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"log"
+)
+
+// GetTagCounts counts the tags of the products matched by the list
+// filters, for facet displays.
+func (h Handler) GetTagCounts(
+	ctx context.Context,
+	data tagsDomain.GetTagCountsDTO,
+) ([]tagsDomain.TagCount, error) {
+	counts, err := h.repository.GetTagCounts(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return counts, nil
+}
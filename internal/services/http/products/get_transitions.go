@@ -0,0 +1,31 @@
+package products
+
+import (
+	"context"
+	"errors"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+// GetProductTransitions lists the status changes of a product, telling a
+// product that never moved from a missing one.
+func (h Handler) GetProductTransitions(
+	ctx context.Context,
+	data productsDomain.GetProductTransitionsDTO,
+) ([]productsDomain.ProductTransition, error) {
+	product := productsDomain.GetProductDTO{ID: data.ProductID, Fields: []string{"id"}}
+	if _, err := h.repository.GetProduct(ctx, product); err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	transitions, err := h.repository.GetProductTransitions(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return transitions, nil
+}
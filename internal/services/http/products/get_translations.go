@@ -0,0 +1,32 @@
+package products
+
+import (
+	"context"
+	"errors"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+func (h Handler) GetProductTranslations(
+	ctx context.Context,
+	data productsDomain.GetProductTranslationsDTO,
+) ([]productsDomain.ProductTranslation, error) {
+	_, err := h.repository.GetProduct(ctx, productsDomain.GetProductDTO{
+		ID:     data.ProductID,
+		Fields: []string{"id"},
+	})
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+
+	translations, err := h.repository.GetProductTranslations(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return translations, nil
+}
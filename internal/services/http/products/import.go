@@ -0,0 +1,104 @@
+package products
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	productsDomain "go_template_project/internal/domain/products"
+	"io"
+	"log"
+	"slices"
+)
+
+// Products are inserted importBatchSize at a time.
+const importBatchSize = 500
+
+// ImportProducts creates products read from r, a CSV file with a header row
+// or NDJSON, and returns how many were created. Only name and title are
+// imported, other columns such as those of an export are ignored.
+func (h Handler) ImportProducts(
+	ctx context.Context,
+	data productsDomain.ImportProductsDTO,
+	r io.Reader,
+) (int64, error) {
+	next, err := newProductsDecoder(data.Format, r)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		created int64
+		batch   = make([]productsDomain.Product, 0, importBatchSize)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		products, err := h.repository.BulkCreateProducts(ctx, batch)
+		if err != nil {
+			return err
+		}
+		created += int64(len(products))
+		batch = batch[:0]
+		return nil
+	}
+
+	for line := 1; ; line++ {
+		product, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return created, fmt.Errorf("record %d: %w", line, err)
+		}
+		batch = append(batch, product)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				log.Println(err)
+				return created, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		log.Println(err)
+		return created, err
+	}
+	return created, nil
+}
+
+func newProductsDecoder(format string, r io.Reader) (func() (productsDomain.Product, error), error) {
+	switch format {
+	case productsDomain.ExportFormatCSV:
+		reader := csv.NewReader(r)
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read csv header: %w", err)
+		}
+		nameIdx, titleIdx := slices.Index(header, "name"), slices.Index(header, "title")
+		if nameIdx < 0 || titleIdx < 0 {
+			return nil, errors.New("csv header must contain name and title")
+		}
+		return func() (productsDomain.Product, error) {
+			record, err := reader.Read()
+			if err != nil {
+				return productsDomain.Product{}, err
+			}
+			return productsDomain.Product{Name: record[nameIdx], Title: record[titleIdx]}, nil
+		}, nil
+	case productsDomain.ExportFormatNDJSON:
+		decoder := json.NewDecoder(r)
+		return func() (productsDomain.Product, error) {
+			var record struct {
+				Name  string `json:"name"`
+				Title string `json:"title"`
+			}
+			err := decoder.Decode(&record)
+			return productsDomain.Product{Name: record.Name, Title: record.Title}, err
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
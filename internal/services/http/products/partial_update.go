@@ -13,7 +13,7 @@ func (h Handler) PartialUpdateProduct(
 ) (*productsDomain.Product, error) {
 	product, err := h.repository.PartialUpdateProduct(ctx, data)
 	if err != nil {
-		if errors.Is(err, productsDomain.ErrProductNotFound) {
+		if errors.Is(err, productsDomain.ErrProductNotFound) || errors.Is(err, productsDomain.ErrInvalidAttributes) {
 			return nil, err
 		}
 		log.Println(err)
@@ -2,10 +2,29 @@ package products
 
 import (
 	"context"
+	"github.com/google/uuid"
+	categoriesDomain "go_template_project/internal/domain/categories"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	mediaDomain "go_template_project/internal/domain/media"
+	pricesDomain "go_template_project/internal/domain/prices"
 	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	variantsDomain "go_template_project/internal/domain/variants"
 )
 
 type repository interface {
+	TransitionProduct(
+		ctx context.Context,
+		data productsDomain.TransitionProductDTO,
+	) (*productsDomain.ProductTransition, error)
+	GetProductTransitions(
+		ctx context.Context,
+		data productsDomain.GetProductTransitionsDTO,
+	) ([]productsDomain.ProductTransition, error)
+	SetProductSchedule(
+		ctx context.Context,
+		data productsDomain.SetProductScheduleDTO,
+	) (*productsDomain.ProductSchedule, error)
 	CreateProduct(
 		ctx context.Context,
 		data productsDomain.CreateProductDTO,
@@ -14,10 +33,27 @@ type repository interface {
 		ctx context.Context,
 		data productsDomain.GetProductsDTO,
 	) ([]productsDomain.Product, error)
+	GetProductsStats(
+		ctx context.Context,
+		data productsDomain.GetProductsDTO,
+	) (*productsDomain.ProductsStats, error)
+	GetProductsFacets(
+		ctx context.Context,
+		data productsDomain.GetProductsFacetsDTO,
+	) (*productsDomain.ProductsFacets, error)
 	GetProduct(
 		ctx context.Context,
 		data productsDomain.GetProductDTO,
 	) (*productsDomain.Product, error)
+	GetProductsByIDs(
+		ctx context.Context,
+		data productsDomain.GetProductsByIDsDTO,
+	) ([]productsDomain.Product, error)
+	ExportProducts(
+		ctx context.Context,
+		data productsDomain.ExportProductsDTO,
+		fn func(productsDomain.Product) error,
+	) error
 	PartialUpdateProduct(
 		ctx context.Context,
 		data productsDomain.PartialUpdateProductDTO,
@@ -26,6 +62,10 @@ type repository interface {
 		ctx context.Context,
 		data productsDomain.DeleteProductDTO,
 	) (*productsDomain.Product, error)
+	PurgeDeletedProducts(
+		ctx context.Context,
+		data productsDomain.PurgeDeletedProductsDTO,
+	) ([]uuid.UUID, error)
 	BulkCreateProducts(
 		ctx context.Context,
 		data []productsDomain.Product,
@@ -35,4 +75,48 @@ type repository interface {
 		updateFields []string,
 		data []productsDomain.Product,
 	) ([]productsDomain.Product, error)
+	EnqueueJob(
+		ctx context.Context,
+		data jobsDomain.EnqueueJobDTO,
+	) (*jobsDomain.Job, error)
+	GetProductTranslations(
+		ctx context.Context,
+		data productsDomain.GetProductTranslationsDTO,
+	) ([]productsDomain.ProductTranslation, error)
+	GetMissingTranslations(
+		ctx context.Context,
+		data productsDomain.GetMissingTranslationsDTO,
+	) ([]productsDomain.MissingTranslation, error)
+	TagProducts(
+		ctx context.Context,
+		data tagsDomain.TagProductsDTO,
+	) (*tagsDomain.TagProductsResult, error)
+	UntagProducts(
+		ctx context.Context,
+		data tagsDomain.TagProductsDTO,
+	) (*tagsDomain.TagProductsResult, error)
+	GetTagCounts(
+		ctx context.Context,
+		data tagsDomain.GetTagCountsDTO,
+	) ([]tagsDomain.TagCount, error)
+	GetProductsCategories(
+		ctx context.Context,
+		data categoriesDomain.GetProductsCategoriesDTO,
+	) (map[uuid.UUID][]categoriesDomain.Category, error)
+	GetProductsPrices(
+		ctx context.Context,
+		data pricesDomain.GetProductsPricesDTO,
+	) (map[uuid.UUID][]pricesDomain.Price, error)
+	GetProductsTags(
+		ctx context.Context,
+		data tagsDomain.GetProductsTagsDTO,
+	) (map[uuid.UUID][]string, error)
+	GetProductsMedia(
+		ctx context.Context,
+		data mediaDomain.GetProductsMediaDTO,
+	) (map[uuid.UUID][]mediaDomain.Media, error)
+	GetProductsVariants(
+		ctx context.Context,
+		data variantsDomain.GetProductsVariantsDTO,
+	) (map[uuid.UUID][]variantsDomain.Variant, error)
 }
@@ -0,0 +1,21 @@
+package products
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+func (h Handler) PurgeDeletedProducts(
+	ctx context.Context,
+	data productsDomain.PurgeDeletedProductsDTO,
+) ([]uuid.UUID, error) {
+	ids, err := h.repository.PurgeDeletedProducts(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return ids, nil
+}
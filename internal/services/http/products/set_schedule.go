@@ -0,0 +1,29 @@
+package products
+
+import (
+	"context"
+	"errors"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+// SetProductSchedule sets when the scheduler publishes and unpublishes a
+// product.
+func (h Handler) SetProductSchedule(
+	ctx context.Context,
+	data productsDomain.SetProductScheduleDTO,
+) (*productsDomain.ProductSchedule, error) {
+	if err := productsDomain.CheckSchedule(data); err != nil {
+		return nil, err
+	}
+
+	schedule, err := h.repository.SetProductSchedule(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return schedule, nil
+}
@@ -0,0 +1,25 @@
+package products
+
+import (
+	"context"
+	"errors"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+)
+
+// TransitionProduct moves a product along the publication workflow.
+func (h Handler) TransitionProduct(
+	ctx context.Context,
+	data productsDomain.TransitionProductDTO,
+) (*productsDomain.ProductTransition, error) {
+	transition, err := h.repository.TransitionProduct(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, productsDomain.ErrIllegalTransition) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return transition, nil
+}
@@ -0,0 +1,25 @@
+package tags
+
+import (
+	"context"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"log"
+)
+
+// AutocompleteTags suggests tags for what has been typed so far, matched in
+// the same normalized form tags are stored in.
+func (h Handler) AutocompleteTags(
+	ctx context.Context,
+	data tagsDomain.AutocompleteTagsDTO,
+) ([]tagsDomain.TagCount, error) {
+	data.Prefix = tagsDomain.NormalizeTag(data.Prefix)
+	if data.Prefix == "" {
+		return nil, tagsDomain.ErrInvalidTag
+	}
+	tags, err := h.repository.AutocompleteTags(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return tags, nil
+}
@@ -0,0 +1,13 @@
+package tags
+
+import (
+	"context"
+	tagsDomain "go_template_project/internal/domain/tags"
+)
+
+type repository interface {
+	AutocompleteTags(
+		ctx context.Context,
+		data tagsDomain.AutocompleteTagsDTO,
+	) ([]tagsDomain.TagCount, error)
+}
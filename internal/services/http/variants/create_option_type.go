@@ -0,0 +1,26 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"log"
+)
+
+func (h Handler) CreateOptionType(
+	ctx context.Context,
+	data variantsDomain.CreateOptionTypeDTO,
+) (*variantsDomain.OptionType, error) {
+	optionType, err := h.repository.CreateOptionType(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, variantsDomain.ErrOptionTypeTaken) ||
+			errors.Is(err, variantsDomain.ErrOptionTypesInUse) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return optionType, nil
+}
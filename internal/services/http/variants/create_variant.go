@@ -0,0 +1,27 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"log"
+)
+
+func (h Handler) CreateVariant(
+	ctx context.Context,
+	data variantsDomain.CreateVariantDTO,
+) (*variantsDomain.Variant, error) {
+	variant, err := h.repository.CreateVariant(ctx, data)
+	if err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) ||
+			errors.Is(err, variantsDomain.ErrInvalidOptions) ||
+			errors.Is(err, variantsDomain.ErrSKUTaken) ||
+			errors.Is(err, variantsDomain.ErrOptionsTaken) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return variant, nil
+}
@@ -0,0 +1,23 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"log"
+)
+
+func (h Handler) DeleteVariant(
+	ctx context.Context,
+	data variantsDomain.DeleteVariantDTO,
+) (*variantsDomain.Variant, error) {
+	variant, err := h.repository.DeleteVariant(ctx, data)
+	if err != nil {
+		if errors.Is(err, variantsDomain.ErrVariantNotFound) {
+			return nil, err
+		}
+		log.Println(err)
+		return nil, err
+	}
+	return variant, nil
+}
@@ -0,0 +1,40 @@
+package variants
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"log"
+)
+
+func (h Handler) GetOptionTypes(
+	ctx context.Context,
+	data variantsDomain.GetOptionTypesDTO,
+) ([]variantsDomain.OptionType, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	optionTypes, err := h.repository.GetOptionTypes(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return optionTypes, nil
+}
+
+// checkProduct tells a product without option types or variants from a
+// missing one.
+func (h Handler) checkProduct(ctx context.Context, id uuid.UUID) error {
+	product := productsDomain.GetProductDTO{ID: id, Fields: []string{"id"}}
+	if _, err := h.repository.GetProduct(ctx, product); err != nil {
+		if errors.Is(err, productsDomain.ErrProductNotFound) {
+			return err
+		}
+		log.Println(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package variants
+
+import (
+	"context"
+	variantsDomain "go_template_project/internal/domain/variants"
+	"log"
+)
+
+func (h Handler) GetVariants(
+	ctx context.Context,
+	data variantsDomain.GetVariantsDTO,
+) ([]variantsDomain.Variant, error) {
+	if err := h.checkProduct(ctx, data.ProductID); err != nil {
+		return nil, err
+	}
+
+	variants, err := h.repository.GetVariants(ctx, data)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return variants, nil
+}
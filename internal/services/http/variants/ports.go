@@ -0,0 +1,38 @@
+package variants
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	variantsDomain "go_template_project/internal/domain/variants"
+)
+
+type repository interface {
+	GetOptionTypes(
+		ctx context.Context,
+		data variantsDomain.GetOptionTypesDTO,
+	) ([]variantsDomain.OptionType, error)
+	CreateOptionType(
+		ctx context.Context,
+		data variantsDomain.CreateOptionTypeDTO,
+	) (*variantsDomain.OptionType, error)
+	GetVariants(
+		ctx context.Context,
+		data variantsDomain.GetVariantsDTO,
+	) ([]variantsDomain.Variant, error)
+	GetVariant(
+		ctx context.Context,
+		data variantsDomain.GetVariantDTO,
+	) (*variantsDomain.Variant, error)
+	CreateVariant(
+		ctx context.Context,
+		data variantsDomain.CreateVariantDTO,
+	) (*variantsDomain.Variant, error)
+	DeleteVariant(
+		ctx context.Context,
+		data variantsDomain.DeleteVariantDTO,
+	) (*variantsDomain.Variant, error)
+	GetProduct(
+		ctx context.Context,
+		data productsDomain.GetProductDTO,
+	) (*productsDomain.Product, error)
+}
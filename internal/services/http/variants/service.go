@@ -0,0 +1,11 @@
+package variants
+
+type Handler struct {
+	repository
+}
+
+func New(repo repository) Handler {
+	return Handler{
+		repository: repo,
+	}
+}
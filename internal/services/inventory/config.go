@@ -0,0 +1,12 @@
+package inventory
+
+import "time"
+
+type Config struct {
+	// ReservationTTL applies to reservations asked for without an expiry
+	ReservationTTL time.Duration
+	// SweepInterval is how often overdue reservations are expired, at most
+	// SweepBatchSize per statement
+	SweepInterval  time.Duration
+	SweepBatchSize int
+}
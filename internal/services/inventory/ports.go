@@ -0,0 +1,13 @@
+package inventory
+
+import (
+	"context"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+)
+
+type repository interface {
+	ExpireReservations(
+		ctx context.Context,
+		data inventoryDomain.ExpireReservationsDTO,
+	) (int64, error)
+}
@@ -0,0 +1,64 @@
+package inventory
+
+import (
+	"context"
+	inventoryDomain "go_template_project/internal/domain/inventory"
+	"log"
+	"sync"
+	"time"
+)
+
+// Sweeper expires overdue reservations, returning their units to stock.
+// Running it on several instances is safe, each reservation is expired
+// once.
+type Sweeper struct {
+	repository
+	config Config
+}
+
+func NewSweeper(repo repository, config Config) *Sweeper {
+	return &Sweeper{
+		repository: repo,
+		config:     config,
+	}
+}
+
+// Run starts the sweeper, it stops once ctx is done.
+func (s *Sweeper) Run(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.config.SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			s.Sweep(ctx)
+		}
+	}()
+	log.Printf("[inventory] reservation sweeper started, every %s", s.config.SweepInterval)
+}
+
+// Sweep expires overdue reservations batch by batch until none are left.
+func (s *Sweeper) Sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		expired, err := s.ExpireReservations(ctx, inventoryDomain.ExpireReservationsDTO{
+			Limit: int64(s.config.SweepBatchSize),
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("[inventory] expire reservations failed: %v", err)
+			}
+			return
+		}
+		if expired > 0 {
+			log.Printf("[inventory] expired %d reservations", expired)
+		}
+		if expired < int64(s.config.SweepBatchSize) {
+			return
+		}
+	}
+}
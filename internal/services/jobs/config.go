@@ -0,0 +1,11 @@
+package jobs
+
+import "time"
+
+type Config struct {
+	Workers           int
+	PollInterval      time.Duration
+	HeartbeatInterval time.Duration
+	LeaseTimeout      time.Duration
+	RetryBackoff      time.Duration
+}
@@ -0,0 +1,30 @@
+package jobs
+
+import "errors"
+
+var (
+	errJobCanceled  = errors.New("job canceled")
+	errJobLeaseLost = errors.New("job lease lost")
+)
+
+type permanentError struct {
+	err error
+}
+
+// Permanent marks err as not worth retrying, e.g. a malformed payload.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+func isPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
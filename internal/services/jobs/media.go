@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	mediaDomain "go_template_project/internal/domain/media"
+)
+
+type mediaCommand interface {
+	GenerateThumbnails(ctx context.Context, data mediaDomain.GenerateThumbnailsDTO) (*mediaDomain.Media, error)
+}
+
+func RegisterMediaHandlers(pool *Pool, command mediaCommand) {
+	pool.Register(mediaDomain.JobKindThumbnails, generateThumbnailsHandler(command))
+}
+
+func generateThumbnailsHandler(command mediaCommand) HandlerFunc {
+	return func(ctx context.Context, job jobsDomain.Job, progress *Progress) (*jobsDomain.Outcome, error) {
+		var data mediaDomain.GenerateThumbnailsDTO
+		if err := json.Unmarshal(job.Payload, &data); err != nil {
+			return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+		}
+		progress.SetTotal(1)
+		progress.Set(0)
+
+		media, err := command.GenerateThumbnails(ctx, data)
+		// not found is retried, the media row may not be visible yet
+		if errors.Is(err, mediaDomain.ErrInvalidImage) {
+			return nil, Permanent(err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		progress.Set(1)
+
+		return &jobsDomain.Outcome{Result: media.Thumbnails}, nil
+	}
+}
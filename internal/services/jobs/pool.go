@@ -0,0 +1,215 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	maxRetryBackoff = time.Hour
+	finishTimeout   = 10 * time.Second
+)
+
+type (
+	HandlerFunc func(ctx context.Context, job jobsDomain.Job, progress *Progress) (*jobsDomain.Outcome, error)
+
+	Pool struct {
+		repository
+		config   Config
+		handlers map[string]HandlerFunc
+		kinds    []string
+	}
+)
+
+func NewPool(repo repository, config Config) *Pool {
+	return &Pool{
+		repository: repo,
+		config:     config,
+		handlers:   make(map[string]HandlerFunc),
+	}
+}
+
+// Register must be called before Run.
+func (p *Pool) Register(kind string, handler HandlerFunc) {
+	if _, ok := p.handlers[kind]; !ok {
+		p.kinds = append(p.kinds, kind)
+	}
+	p.handlers[kind] = handler
+}
+
+// Run starts the workers, they stop once ctx is done.
+func (p *Pool) Run(ctx context.Context, wg *sync.WaitGroup) {
+	hostname, _ := os.Hostname()
+	for i := 0; i < p.config.Workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx, workerID)
+		}()
+	}
+	log.Printf("[jobs] %d workers started", p.config.Workers)
+}
+
+func (p *Pool) work(ctx context.Context, workerID string) {
+	for ctx.Err() == nil {
+		job, err := p.ClaimJob(ctx, jobsDomain.ClaimJobDTO{
+			WorkerID:     workerID,
+			Kinds:        p.kinds,
+			LeaseTimeout: p.config.LeaseTimeout,
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[jobs] %s: claim failed: %v", workerID, err)
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.config.PollInterval):
+			}
+			continue
+		}
+		p.execute(ctx, workerID, *job)
+	}
+}
+
+func (p *Pool) execute(ctx context.Context, workerID string, job jobsDomain.Job) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	progress := &Progress{}
+	progress.SetTotal(job.Progress.Total)
+	progress.Set(job.Progress.Done)
+
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		p.heartbeat(jobCtx, cancel, workerID, job, progress)
+	}()
+
+	var (
+		outcome *jobsDomain.Outcome
+		err     error
+	)
+	if job.Attempts > job.MaxAttempts {
+		err = Permanent(fmt.Errorf("attempts exhausted after %d tries", job.MaxAttempts))
+	} else {
+		outcome, err = p.run(jobCtx, job, progress)
+	}
+	cause := context.Cause(jobCtx)
+	cancel(nil)
+	<-heartbeatDone
+
+	finish := jobsDomain.FinishJobDTO{
+		ID:       job.ID,
+		WorkerID: workerID,
+		Progress: progress.snapshot(),
+	}
+	switch {
+	case errors.Is(cause, errJobLeaseLost):
+		log.Printf("[jobs] %s: lost lease on job %s", workerID, job.ID)
+		return
+	case errors.Is(cause, errJobCanceled):
+		finish.Status = jobsDomain.StatusCanceled
+		finish.Error = errJobCanceled.Error()
+	case ctx.Err() != nil:
+		// Shutting down, let another worker pick the job up again
+		finish.Retry = true
+		finish.Error = "worker stopped"
+	case err != nil:
+		finish.Error = err.Error()
+		if isPermanent(err) || job.Attempts >= job.MaxAttempts {
+			finish.Status = jobsDomain.StatusFailed
+		} else {
+			finish.Retry = true
+			finish.RetryAfter = p.backoff(job.Attempts)
+		}
+	default:
+		finish.Status = jobsDomain.StatusSucceeded
+		if outcome != nil {
+			if outcome.Result != nil {
+				finish.Result, err = json.Marshal(outcome.Result)
+				if err != nil {
+					finish.Status = jobsDomain.StatusFailed
+					finish.Error = fmt.Sprintf("result marshalling failed: %v", err)
+				}
+			}
+			finish.Artifact = outcome.Artifact
+		}
+	}
+
+	finishCtx, finishCancel := context.WithTimeout(context.WithoutCancel(ctx), finishTimeout)
+	defer finishCancel()
+	if _, err := p.FinishJob(finishCtx, finish); err != nil {
+		log.Printf("[jobs] %s: finish job %s failed: %v", workerID, job.ID, err)
+	}
+}
+
+// run calls the job handler and turns its panics into errors.
+func (p *Pool) run(
+	ctx context.Context,
+	job jobsDomain.Job,
+	progress *Progress,
+) (outcome *jobsDomain.Outcome, err error) {
+	handler, ok := p.handlers[job.Kind]
+	if !ok {
+		return nil, Permanent(fmt.Errorf("no handler for job kind %q", job.Kind))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, job, progress)
+}
+
+// heartbeat renews the job lease, stores progress and stops the job once
+// cancellation was requested through the API.
+func (p *Pool) heartbeat(
+	ctx context.Context,
+	cancel context.CancelCauseFunc,
+	workerID string,
+	job jobsDomain.Job,
+	progress *Progress,
+) {
+	ticker := time.NewTicker(p.config.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current, err := p.HeartbeatJob(ctx, jobsDomain.HeartbeatJobDTO{
+			ID:       job.ID,
+			WorkerID: workerID,
+			Progress: progress.snapshot(),
+		})
+		switch {
+		case errors.Is(err, jobsDomain.ErrJobLeaseLost):
+			cancel(errJobLeaseLost)
+			return
+		case err != nil:
+			if ctx.Err() == nil {
+				log.Printf("[jobs] %s: heartbeat for job %s failed: %v", workerID, job.ID, err)
+			}
+		case current.CancelRequested:
+			cancel(errJobCanceled)
+			return
+		}
+	}
+}
+
+func (p *Pool) backoff(attempt int) time.Duration {
+	delay := p.config.RetryBackoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	jobsDomain "go_template_project/internal/domain/jobs"
+)
+
+type repository interface {
+	ClaimJob(
+		ctx context.Context,
+		data jobsDomain.ClaimJobDTO,
+	) (*jobsDomain.Job, error)
+	HeartbeatJob(
+		ctx context.Context,
+		data jobsDomain.HeartbeatJobDTO,
+	) (*jobsDomain.Job, error)
+	FinishJob(
+		ctx context.Context,
+		data jobsDomain.FinishJobDTO,
+	) (*jobsDomain.Job, error)
+}
@@ -0,0 +1,164 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	jobsDomain "go_template_project/internal/domain/jobs"
+	productsDomain "go_template_project/internal/domain/products"
+	tagsDomain "go_template_project/internal/domain/tags"
+	"go_template_project/internal/storage"
+	"io"
+)
+
+type (
+	productsCommand interface {
+		BulkCreateProducts(ctx context.Context, data []productsDomain.Product) ([]productsDomain.Product, error)
+		BulkUpdateProducts(ctx context.Context, data []productsDomain.Product) ([]productsDomain.Product, error)
+		ExportProducts(ctx context.Context, data productsDomain.ExportProductsDTO, w io.Writer) error
+		BulkTagProducts(ctx context.Context, data tagsDomain.TagProductsDTO) (*tagsDomain.TagProductsResult, error)
+		BulkUntagProducts(ctx context.Context, data tagsDomain.TagProductsDTO) (*tagsDomain.TagProductsResult, error)
+	}
+
+	// exportWriter streams the export artifact to the storage and forwards
+	// the number of exported products to the job progress.
+	exportWriter struct {
+		*io.PipeWriter
+		progress *Progress
+	}
+)
+
+// bulkUpdateChunkSize bounds a single bulk update statement, so progress and
+// cancellation are observed between chunks. Updates are idempotent, retrying
+// a partially applied job is safe.
+const bulkUpdateChunkSize = 500
+
+func RegisterProductsHandlers(pool *Pool, command productsCommand, store storage.Storage) {
+	pool.Register(productsDomain.JobKindBulkCreate, bulkCreateProductsHandler(command))
+	pool.Register(productsDomain.JobKindBulkUpdate, bulkUpdateProductsHandler(command))
+	pool.Register(productsDomain.JobKindExport, exportProductsHandler(command, store))
+	pool.Register(productsDomain.JobKindBulkTag, bulkTagProductsHandler(command.BulkTagProducts))
+	pool.Register(productsDomain.JobKindBulkUntag, bulkTagProductsHandler(command.BulkUntagProducts))
+}
+
+func bulkCreateProductsHandler(command productsCommand) HandlerFunc {
+	return func(ctx context.Context, job jobsDomain.Job, progress *Progress) (*jobsDomain.Outcome, error) {
+		var data []productsDomain.Product
+		if err := json.Unmarshal(job.Payload, &data); err != nil {
+			return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+		}
+		progress.SetTotal(int64(len(data)))
+
+		// A single insert statement, so a failed attempt leaves nothing behind.
+		products, err := command.BulkCreateProducts(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		progress.Set(int64(len(products)))
+
+		return &jobsDomain.Outcome{Result: products}, nil
+	}
+}
+
+func bulkUpdateProductsHandler(command productsCommand) HandlerFunc {
+	return func(ctx context.Context, job jobsDomain.Job, progress *Progress) (*jobsDomain.Outcome, error) {
+		var data []productsDomain.Product
+		if err := json.Unmarshal(job.Payload, &data); err != nil {
+			return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+		}
+		progress.SetTotal(int64(len(data)))
+		progress.Set(0)
+
+		products := make([]productsDomain.Product, 0, len(data))
+		for start := 0; start < len(data); start += bulkUpdateChunkSize {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			end := min(start+bulkUpdateChunkSize, len(data))
+			updated, err := command.BulkUpdateProducts(ctx, data[start:end])
+			if err != nil {
+				return nil, err
+			}
+			products = append(products, updated...)
+			progress.Set(int64(end))
+		}
+
+		return &jobsDomain.Outcome{Result: products}, nil
+	}
+}
+
+// bulkTagProductsHandler runs a bulk tag or untag, both are a single
+// transaction and adding or removing a tag twice changes nothing, so a
+// retry is safe.
+func bulkTagProductsHandler(
+	apply func(ctx context.Context, data tagsDomain.TagProductsDTO) (*tagsDomain.TagProductsResult, error),
+) HandlerFunc {
+	return func(ctx context.Context, job jobsDomain.Job, progress *Progress) (*jobsDomain.Outcome, error) {
+		var data tagsDomain.TagProductsDTO
+		if err := json.Unmarshal(job.Payload, &data); err != nil {
+			return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+		}
+		progress.SetTotal(int64(len(data.ProductIDs)))
+		progress.Set(0)
+
+		result, err := apply(ctx, data)
+		if errors.Is(err, tagsDomain.ErrInvalidTag) {
+			return nil, Permanent(err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		progress.Set(int64(len(data.ProductIDs)))
+
+		return &jobsDomain.Outcome{Result: result}, nil
+	}
+}
+
+// exportProductsHandler writes the export straight to the storage, a failed
+// attempt leaves nothing behind as the storage only publishes complete
+// objects.
+func exportProductsHandler(command productsCommand, store storage.Storage) HandlerFunc {
+	return func(ctx context.Context, job jobsDomain.Job, progress *Progress) (*jobsDomain.Outcome, error) {
+		var data productsDomain.ExportProductsDTO
+		if err := json.Unmarshal(job.Payload, &data); err != nil {
+			return nil, Permanent(fmt.Errorf("invalid payload: %w", err))
+		}
+		contentType, ok := productsDomain.ExportContentTypes[data.Format]
+		if !ok {
+			return nil, Permanent(fmt.Errorf("unsupported export format %q", data.Format))
+		}
+		progress.Set(0)
+
+		key := jobsDomain.ArtifactKey(job.ID)
+		reader, writer := io.Pipe()
+		exported := make(chan error, 1)
+		go func() {
+			err := command.ExportProducts(ctx, data, &exportWriter{PipeWriter: writer, progress: progress})
+			_ = writer.CloseWithError(err)
+			exported <- err
+		}()
+		stored := store.Put(ctx, key, reader)
+		// stops the export when the storage gave up reading
+		_ = reader.CloseWithError(stored)
+		if err := <-exported; err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			return nil, fmt.Errorf("store export: %w", stored)
+		}
+
+		return &jobsDomain.Outcome{
+			Result: map[string]int64{"exported": progress.snapshot().Done},
+			Artifact: &jobsDomain.Artifact{
+				Name:        "products." + data.Format,
+				ContentType: contentType,
+				Key:         key,
+			},
+		}, nil
+	}
+}
+
+func (w *exportWriter) ReportProgress(done int64) {
+	w.progress.Set(done)
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"sync/atomic"
+
+	jobsDomain "go_template_project/internal/domain/jobs"
+)
+
+// Progress is updated by job handlers and persisted by the worker on every
+// heartbeat, so handlers can report as often as they like.
+type Progress struct {
+	done  atomic.Int64
+	total atomic.Int64
+}
+
+func (p *Progress) SetTotal(total int64) {
+	p.total.Store(total)
+}
+
+func (p *Progress) Set(done int64) {
+	p.done.Store(done)
+}
+
+func (p *Progress) Add(delta int64) {
+	p.done.Add(delta)
+}
+
+func (p *Progress) snapshot() jobsDomain.Progress {
+	return jobsDomain.Progress{
+		Done:  p.done.Load(),
+		Total: p.total.Load(),
+	}
+}
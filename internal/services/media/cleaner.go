@@ -0,0 +1,89 @@
+package media
+
+import (
+	"context"
+	"github.com/google/uuid"
+	mediaDomain "go_template_project/internal/domain/media"
+	"go_template_project/internal/storage"
+	"log"
+	"sync"
+	"time"
+)
+
+// Cleaner removes the files of media detached from their product, either
+// deleted on their own or left behind by a purged product, and then their
+// rows. Running it on several instances is safe, removing files twice is
+// not an error.
+type Cleaner struct {
+	repository
+	storage storage.Storage
+	config  Config
+}
+
+func NewCleaner(repo repository, store storage.Storage, config Config) *Cleaner {
+	return &Cleaner{
+		repository: repo,
+		storage:    store,
+		config:     config,
+	}
+}
+
+// Run starts the cleaner, it stops once ctx is done.
+func (c *Cleaner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(c.config.CleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			c.Clean(ctx)
+		}
+	}()
+	log.Printf("[media] cleaner started, every %s", c.config.CleanupInterval)
+}
+
+// Clean removes orphaned media batch by batch until none are left. A batch
+// with files that could not be removed ends the run, they are retried on
+// the next one.
+func (c *Cleaner) Clean(ctx context.Context) {
+	for ctx.Err() == nil {
+		ids, err := c.GetOrphanedMedia(ctx, mediaDomain.GetOrphanedMediaDTO{
+			Limit: int64(c.config.CleanupBatchSize),
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("[media] get orphaned media failed: %v", err)
+			}
+			return
+		}
+
+		removed := make([]uuid.UUID, 0, len(ids))
+		for _, id := range ids {
+			if err = c.storage.DeleteAll(ctx, mediaDomain.Prefix(id)); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("[media] remove files of media %s failed: %v", id, err)
+				}
+				continue
+			}
+			removed = append(removed, id)
+		}
+		if len(removed) > 0 {
+			purged, err := c.PurgeMedia(ctx, mediaDomain.PurgeMediaDTO{IDs: removed})
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("[media] purge media failed: %v", err)
+				}
+				return
+			}
+			log.Printf("[media] removed %d orphaned media", purged)
+		}
+		if len(removed) < len(ids) || len(ids) < c.config.CleanupBatchSize {
+			return
+		}
+	}
+}
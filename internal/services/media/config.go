@@ -0,0 +1,15 @@
+package media
+
+import "time"
+
+type Config struct {
+	// MaxFileSize bounds uploaded files, in bytes
+	MaxFileSize int64
+	// ThumbnailSizes are the boxes thumbnails are scaled to fit in, in
+	// pixels
+	ThumbnailSizes []int
+	// CleanupInterval is how often files of deleted media are removed, at
+	// most CleanupBatchSize media at a time
+	CleanupInterval  time.Duration
+	CleanupBatchSize int
+}
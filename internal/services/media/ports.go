@@ -0,0 +1,18 @@
+package media
+
+import (
+	"context"
+	"github.com/google/uuid"
+	mediaDomain "go_template_project/internal/domain/media"
+)
+
+type repository interface {
+	GetOrphanedMedia(
+		ctx context.Context,
+		data mediaDomain.GetOrphanedMediaDTO,
+	) ([]uuid.UUID, error)
+	PurgeMedia(
+		ctx context.Context,
+		data mediaDomain.PurgeMediaDTO,
+	) (int64, error)
+}
@@ -0,0 +1,10 @@
+package publication
+
+import "time"
+
+type Config struct {
+	// ScheduleInterval is how often due products are published and
+	// unpublished, at most ScheduleBatchSize of each per statement
+	ScheduleInterval  time.Duration
+	ScheduleBatchSize int
+}
@@ -0,0 +1,13 @@
+package publication
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+)
+
+type repository interface {
+	RunScheduledTransitions(
+		ctx context.Context,
+		data productsDomain.RunScheduledTransitionsDTO,
+	) ([]productsDomain.ProductTransition, error)
+}
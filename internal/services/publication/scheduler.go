@@ -0,0 +1,71 @@
+package publication
+
+import (
+	"context"
+	productsDomain "go_template_project/internal/domain/products"
+	"log"
+	"sync"
+	"time"
+)
+
+// schedulerActor is recorded as who moved products the scheduler moved.
+const schedulerActor = "scheduler"
+
+// Scheduler publishes and unpublishes products once their publish_at and
+// unpublish_at times have come. Running it on several instances is safe,
+// each product is moved once.
+type Scheduler struct {
+	repository
+	config Config
+}
+
+func NewScheduler(repo repository, config Config) *Scheduler {
+	return &Scheduler{
+		repository: repo,
+		config:     config,
+	}
+}
+
+// Run starts the scheduler, it stops once ctx is done.
+func (s *Scheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.config.ScheduleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			s.Schedule(ctx)
+		}
+	}()
+	log.Printf("[publication] scheduler started, every %s", s.config.ScheduleInterval)
+}
+
+// Schedule moves due products batch by batch until none are left.
+func (s *Scheduler) Schedule(ctx context.Context) {
+	for ctx.Err() == nil {
+		transitions, err := s.RunScheduledTransitions(ctx, productsDomain.RunScheduledTransitionsDTO{
+			Limit: int64(s.config.ScheduleBatchSize),
+			Actor: schedulerActor,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("[publication] run scheduled transitions failed: %v", err)
+			}
+			return
+		}
+		for _, transition := range transitions {
+			log.Printf("[publication] moved product %s from %s to %s",
+				transition.ProductID, transition.FromStatus, transition.ToStatus)
+		}
+		// Every kind of move is limited on its own, only a full batch of
+		// one of them can leave due products behind
+		if len(transitions) < s.config.ScheduleBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// Local keeps objects as files under a directory, keys map to paths
+	// relative to it.
+	Local struct {
+		dir string
+	}
+
+	// readerWithContext stops reading once ctx is done.
+	readerWithContext struct {
+		ctx context.Context
+		r   io.Reader
+	}
+)
+
+func NewLocal(config Config) (*Local, error) {
+	dir, err := filepath.Abs(config.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("storage dir error: %w", err)
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage dir error: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+// Put writes to a temporary file next to the object and renames it into
+// place once complete.
+func (l *Local) Put(ctx context.Context, key string, body io.Reader) (err error) {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = io.Copy(tmp, readerWithContext{ctx: ctx, r: body}); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Open returns an *os.File, so callers can seek in it.
+func (l *Local) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (l *Local) DeleteAll(_ context.Context, prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return ErrInvalidKey
+	}
+	path, err := l.path(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// path maps key to a file under the storage dir, keys leading out of it
+// are rejected.
+func (l *Local) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+func (r readerWithContext) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
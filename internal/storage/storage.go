@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrInvalidKey     = errors.New("invalid object key")
+)
+
+// Storage keeps files by slash separated keys, e.g. the local filesystem or
+// an S3 compatible bucket.
+type Storage interface {
+	// Put stores body under key, replacing what was there. Readers never
+	// see a partially written object.
+	Put(ctx context.Context, key string, body io.Reader) error
+	// Open returns the object under key or ErrObjectNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// DeleteAll removes every object with keys starting with prefix, which
+	// has to end with a slash. Nothing to delete is not an error.
+	DeleteAll(ctx context.Context, prefix string) error
+}
+
+type Config struct {
+	// Dir is where the local storage keeps its files
+	Dir string
+}
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE jobs
+(
+    id                    uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind                  varchar(100)                    NOT NULL,
+    status                varchar(20)                     DEFAULT 'queued' NOT NULL,
+    payload               jsonb                           DEFAULT '{}' NOT NULL,
+    result                jsonb                           NULL,
+    artifact              bytea                           NULL,
+    artifact_name         varchar(250)                    NULL,
+    artifact_content_type varchar(250)                    NULL,
+    progress_done         bigint                          DEFAULT 0 NOT NULL,
+    progress_total        bigint                          DEFAULT 0 NOT NULL,
+    attempts              integer                         DEFAULT 0 NOT NULL,
+    max_attempts          integer                         DEFAULT 3 NOT NULL,
+    last_error            text                            NULL,
+    cancel_requested      boolean                         DEFAULT false NOT NULL,
+    locked_by             varchar(250)                    NULL,
+    locked_at             TIMESTAMP                       NULL,
+    run_at                TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    created_at            TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at            TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    started_at            TIMESTAMP                       NULL,
+    finished_at           TIMESTAMP                       NULL
+);
+
+CREATE INDEX ix_jobs_queued ON jobs (run_at) WHERE status = 'queued';
+CREATE INDEX ix_jobs_running ON jobs (locked_at) WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS jobs CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backfill_checkpoints
+(
+    name                  varchar(100)                    PRIMARY KEY,
+    status                varchar(20)                     DEFAULT 'pending' NOT NULL,
+    cursor                text                            DEFAULT '' NOT NULL,
+    processed             bigint                          DEFAULT 0 NOT NULL,
+    last_error            text                            NULL,
+    created_at            TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at            TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    started_at            TIMESTAMP                       NULL,
+    finished_at           TIMESTAMP                       NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS backfill_checkpoints;
+-- +goose StatementEnd
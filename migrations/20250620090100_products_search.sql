@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Existing rows are filled by the products_search backfill, new and updated
+-- rows by the trigger.
+ALTER TABLE products ADD COLUMN search tsvector NULL;
+
+CREATE FUNCTION products_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search := to_tsvector('simple', NEW.name || ' ' || NEW.title);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_products_search
+    BEFORE INSERT OR UPDATE OF name, title ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_update();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS tr_products_search ON products;
+DROP FUNCTION IF EXISTS products_search_update();
+ALTER TABLE products DROP COLUMN IF EXISTS search;
+-- +goose StatementEnd
@@ -0,0 +1,6 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ix_products_search ON products USING GIN (search);
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS ix_products_search;
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"go_template_project/internal/migrator/backfill"
+
+	"github.com/pressly/goose/v3"
+)
+
+// productsSearchBackfill fills products.search for rows created before the
+// column existed, newer rows are kept up to date by tr_products_search.
+const productsSearchBackfill = "products_search"
+
+func init() {
+	goose.AddMigrationContext(upProductsSearchBackfill, downProductsSearchBackfill)
+
+	backfill.Register(backfill.Backfill{
+		Name:        productsSearchBackfill,
+		Description: "populate products.search",
+		Batch:       productsSearchBatch,
+		Remaining:   productsSearchRemaining,
+	})
+}
+
+func upProductsSearchBackfill(ctx context.Context, tx *sql.Tx) error {
+	return backfill.Enqueue(ctx, tx, productsSearchBackfill)
+}
+
+func downProductsSearchBackfill(ctx context.Context, tx *sql.Tx) error {
+	return backfill.Remove(ctx, tx, productsSearchBackfill)
+}
+
+func productsSearchBatch(ctx context.Context, tx *sql.Tx, cursor string, limit int) (string, int64, error) {
+	var (
+		next      sql.NullString
+		processed int64
+	)
+	err := tx.QueryRowContext(ctx, `
+		WITH batch AS (
+			SELECT id FROM products
+			WHERE $1 = '' OR id > $1::uuid
+			ORDER BY id
+			LIMIT $2
+		), updated AS (
+			UPDATE products p
+			SET search = to_tsvector('simple', p.name || ' ' || p.title)
+			FROM batch
+			WHERE p.id = batch.id AND p.search IS NULL
+		)
+		SELECT count(*), (SELECT id::text FROM batch ORDER BY id DESC LIMIT 1) FROM batch`,
+		cursor, limit,
+	).Scan(&processed, &next)
+	return next.String, processed, err
+}
+
+func productsSearchRemaining(ctx context.Context, db *sql.DB) (int64, error) {
+	var remaining int64
+	err := db.QueryRowContext(ctx, `SELECT count(*) FROM products WHERE search IS NULL`).Scan(&remaining)
+	return remaining, err
+}
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- path holds the ids from the root down to the category, each followed by
+-- "/", so a subtree is every row whose path starts with the root's path.
+CREATE TABLE categories
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parent_id         uuid                            NULL REFERENCES categories (id) ON DELETE RESTRICT,
+    name              varchar(250)                    NOT NULL,
+    slug              varchar(250)                    NOT NULL,
+    path              text                            NOT NULL,
+    depth             integer                         NOT NULL,
+    position          integer                         DEFAULT 0 NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at        TIMESTAMP                       DEFAULT NOW() NOT NULL
+);
+
+CREATE UNIQUE INDEX ux_categories_parent_slug
+    ON categories (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), slug);
+CREATE INDEX ix_categories_path ON categories (path text_pattern_ops);
+CREATE INDEX ix_categories_parent_position ON categories (parent_id, position);
+
+CREATE TABLE product_categories
+(
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    category_id       uuid                            NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX ix_product_categories_category_id ON product_categories (category_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_categories;
+DROP TABLE IF EXISTS categories;
+-- +goose StatementEnd
@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- amount is in minor units of the currency (cents for USD), a NULL valid_to
+-- means the price holds until replaced. Ranges of one product and currency
+-- never overlap, so at most one price is current at any time.
+CREATE TABLE product_prices
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    currency          char(3)                         NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    amount            bigint                          NOT NULL CHECK (amount >= 0),
+    valid_from        TIMESTAMP                       NOT NULL,
+    valid_to          TIMESTAMP                       NULL CHECK (valid_to > valid_from),
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    EXCLUDE USING gist (product_id WITH =, currency WITH =, tsrange(valid_from, valid_to) WITH &&)
+);
+
+-- Every change to product_prices, kept after prices are replaced or deleted.
+CREATE TABLE product_price_history
+(
+    id                bigserial                       PRIMARY KEY,
+    price_id          uuid                            NOT NULL,
+    product_id        uuid                            NOT NULL,
+    currency          char(3)                         NOT NULL,
+    amount            bigint                          NOT NULL,
+    valid_from        TIMESTAMP                       NOT NULL,
+    valid_to          TIMESTAMP                       NULL,
+    operation         varchar(10)                     NOT NULL,
+    changed_at        TIMESTAMP                       DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX ix_product_price_history_product_id ON product_price_history (product_id, changed_at);
+
+CREATE FUNCTION product_prices_history() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO product_price_history (price_id, product_id, currency, amount, valid_from, valid_to, operation)
+        VALUES (OLD.id, OLD.product_id, OLD.currency, OLD.amount, OLD.valid_from, OLD.valid_to, 'delete');
+        RETURN OLD;
+    END IF;
+    INSERT INTO product_price_history (price_id, product_id, currency, amount, valid_from, valid_to, operation)
+    VALUES (NEW.id, NEW.product_id, NEW.currency, NEW.amount, NEW.valid_from, NEW.valid_to, lower(TG_OP));
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_product_prices_history
+    AFTER INSERT OR UPDATE OR DELETE ON product_prices
+    FOR EACH ROW EXECUTE FUNCTION product_prices_history();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS tr_product_prices_history ON product_prices;
+DROP FUNCTION IF EXISTS product_prices_history();
+DROP TABLE IF EXISTS product_price_history;
+DROP TABLE IF EXISTS product_prices;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE warehouses
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code              varchar(50)                     NOT NULL UNIQUE,
+    name              varchar(250)                    NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL
+);
+
+-- reserved units are part of on_hand, the checks keep reservations from
+-- ever exceeding the stock.
+CREATE TABLE stock_levels
+(
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    warehouse_id      uuid                            NOT NULL REFERENCES warehouses (id) ON DELETE RESTRICT,
+    on_hand           bigint                          DEFAULT 0 NOT NULL CHECK (on_hand >= 0),
+    reserved          bigint                          DEFAULT 0 NOT NULL CHECK (reserved >= 0),
+    updated_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (product_id, warehouse_id),
+    CONSTRAINT stock_levels_reserved_on_hand_check CHECK (reserved <= on_hand)
+);
+
+CREATE INDEX ix_stock_levels_warehouse_id ON stock_levels (warehouse_id);
+
+CREATE TABLE stock_reservations
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id        uuid                            NOT NULL,
+    warehouse_id      uuid                            NOT NULL,
+    quantity          bigint                          NOT NULL CHECK (quantity > 0),
+    status            varchar(20)                     DEFAULT 'active' NOT NULL
+        CHECK (status IN ('active', 'committed', 'released', 'expired')),
+    expires_at        TIMESTAMP                       NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    FOREIGN KEY (product_id, warehouse_id) REFERENCES stock_levels (product_id, warehouse_id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_stock_reservations_expires_at ON stock_reservations (expires_at) WHERE status = 'active';
+
+CREATE TABLE stock_adjustments
+(
+    id                bigserial                       PRIMARY KEY,
+    product_id        uuid                            NOT NULL,
+    warehouse_id      uuid                            NOT NULL,
+    delta             bigint                          NOT NULL,
+    reason            varchar(20)                     NOT NULL,
+    note              text                            DEFAULT '' NOT NULL,
+    reservation_id    uuid                            NULL REFERENCES stock_reservations (id) ON DELETE SET NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    FOREIGN KEY (product_id, warehouse_id) REFERENCES stock_levels (product_id, warehouse_id) ON DELETE CASCADE
+);
+
+CREATE INDEX ix_stock_adjustments_product_id ON stock_adjustments (product_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_adjustments;
+DROP TABLE IF EXISTS stock_reservations;
+DROP TABLE IF EXISTS stock_levels;
+DROP TABLE IF EXISTS warehouses;
+-- +goose StatementEnd
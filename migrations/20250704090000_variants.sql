@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Option types of a product (size, colour) with their allowed values, in
+-- display order.
+CREATE TABLE product_option_types
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    name              varchar(100)                    NOT NULL,
+    allowed_values    text[]                          NOT NULL CHECK (cardinality(allowed_values) > 0),
+    position          integer                         NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    UNIQUE (product_id, name)
+);
+
+-- options maps every option type name of the product to one of its values.
+-- Variants are soft-deleted like products, a SKU is only taken by live rows.
+CREATE TABLE product_variants
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    sku               varchar(100)                    NOT NULL,
+    options           jsonb                           DEFAULT '{}' NOT NULL,
+    attributes        jsonb                           DEFAULT '{}' NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    deleted_at        TIMESTAMP                       NULL
+);
+
+CREATE UNIQUE INDEX ux_product_variants_sku ON product_variants (sku) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX ux_product_variants_options ON product_variants (product_id, options) WHERE deleted_at IS NULL;
+CREATE INDEX ix_product_variants_product_id ON product_variants (product_id, created_at);
+
+-- Deleting a product (a soft delete, see DeleteProduct) soft-deletes its
+-- live variants with the same timestamp, freeing their SKUs. Products are
+-- never restored, purging them cascades through the foreign key.
+CREATE FUNCTION products_cascade_soft_delete() RETURNS trigger AS $$
+BEGIN
+    IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        UPDATE product_variants SET deleted_at = NEW.deleted_at
+        WHERE product_id = NEW.id AND deleted_at IS NULL;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_products_cascade_soft_delete
+    AFTER UPDATE OF deleted_at ON products
+    FOR EACH ROW EXECUTE FUNCTION products_cascade_soft_delete();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS tr_products_cascade_soft_delete ON products;
+DROP FUNCTION IF EXISTS products_cascade_soft_delete();
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS product_option_types;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- attribute_schema is a JSON Schema the attributes of products in the
+-- category, or in any of its descendants, have to satisfy.
+ALTER TABLE categories ADD COLUMN attribute_schema jsonb NULL;
+
+-- A constant default does not rewrite the table.
+ALTER TABLE products ADD COLUMN attributes jsonb DEFAULT '{}' NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE products DROP COLUMN IF EXISTS attributes;
+ALTER TABLE categories DROP COLUMN IF EXISTS attribute_schema;
+-- +goose StatementEnd
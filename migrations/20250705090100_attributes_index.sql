@@ -0,0 +1,7 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- jsonb_path_ops only serves @>, which is all attribute filters use.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS ix_products_attributes ON products USING GIN (attributes jsonb_path_ops);
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS ix_products_attributes;
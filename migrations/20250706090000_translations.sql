@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Name and title of a product per locale, the columns on products are the
+-- untranslated fallback. search mirrors products.search for the locale.
+CREATE TABLE product_translations
+(
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    locale            varchar(35)                     NOT NULL,
+    name              varchar(250)                    NOT NULL,
+    title             varchar(250)                    NOT NULL,
+    search            tsvector                        GENERATED ALWAYS AS (to_tsvector('simple', name || ' ' || title)) STORED,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (product_id, locale)
+);
+
+CREATE INDEX ix_product_translations_locale ON product_translations (locale);
+CREATE INDEX ix_product_translations_search ON product_translations USING GIN (search);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_translations;
+-- +goose StatementEnd
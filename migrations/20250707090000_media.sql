@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Images of a product in gallery order. The files live in the media
+-- storage under the media id. Deleting a media or purging its product only
+-- detaches the row, the media cleaner removes its files and then the row.
+-- Media of soft-deleted products is kept, so restoring them keeps the
+-- gallery.
+CREATE TABLE product_media
+(
+    id                uuid                            PRIMARY KEY,
+    product_id        uuid                            NULL REFERENCES products (id) ON DELETE SET NULL,
+    content_type      varchar(100)                    NOT NULL,
+    file_size         bigint                          NOT NULL,
+    width             integer                         NOT NULL,
+    height            integer                         NOT NULL,
+    position          integer                         NOT NULL,
+    status            varchar(20)                     DEFAULT 'processing' NOT NULL,
+    thumbnails        jsonb                           DEFAULT '[]' NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    updated_at        TIMESTAMP                       DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX ix_product_media_product_id ON product_media (product_id, position);
+CREATE INDEX ix_product_media_orphaned ON product_media (updated_at) WHERE product_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_media;
+-- +goose StatementEnd
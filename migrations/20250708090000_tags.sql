@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tags are free-form and created on first use, names are stored normalized
+-- so differently spelled duplicates collapse into one tag.
+CREATE TABLE tags
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name              varchar(100)                    NOT NULL,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL
+);
+
+CREATE UNIQUE INDEX ux_tags_name ON tags (name);
+CREATE INDEX ix_tags_name_pattern ON tags (name text_pattern_ops);
+
+CREATE TABLE product_tags
+(
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    tag_id            uuid                            NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX ix_product_tags_tag_id ON product_tags (tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_tags;
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Products created before the workflow existed were live, they start out
+-- published. New products start as drafts.
+ALTER TABLE products
+    ADD COLUMN status       varchar(20)     NOT NULL DEFAULT 'published'
+        CHECK (status IN ('draft', 'in_review', 'published', 'archived')),
+    ADD COLUMN publish_at   TIMESTAMP,
+    ADD COLUMN unpublish_at TIMESTAMP;
+
+ALTER TABLE products ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX ix_products_status ON products (status);
+CREATE INDEX ix_products_publish_at ON products (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX ix_products_unpublish_at ON products (unpublish_at) WHERE unpublish_at IS NOT NULL;
+
+-- Every status change is recorded with who made it and why.
+CREATE TABLE product_transitions
+(
+    id                uuid                            PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id        uuid                            NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    from_status       varchar(20)                     NOT NULL,
+    to_status         varchar(20)                     NOT NULL,
+    actor             varchar(250)                    NOT NULL,
+    reason            varchar(1000)                   NOT NULL DEFAULT '',
+    created_at        TIMESTAMP                       DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX ix_product_transitions_product_id ON product_transitions (product_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_transitions;
+DROP INDEX IF EXISTS ix_products_unpublish_at;
+DROP INDEX IF EXISTS ix_products_publish_at;
+DROP INDEX IF EXISTS ix_products_status;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS unpublish_at,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
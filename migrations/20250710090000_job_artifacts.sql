@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Artifacts are written to the file storage, jobs only keep their key.
+-- Artifacts stored in the table so far are dropped with it.
+ALTER TABLE jobs
+    ADD COLUMN artifact_key varchar(250) NULL,
+    DROP COLUMN artifact;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs
+    ADD COLUMN artifact bytea NULL,
+    DROP COLUMN IF EXISTS artifact_key;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+// Package migrations holds the goose migrations, embedded so the binaries
+// do not depend on the working directory. Go migrations register themselves
+// in init, their sources are embedded too so migrate verify covers them.
+package migrations
+
+import "embed"
+
+//go:embed *.sql *.go
+var FS embed.FS